@@ -40,6 +40,14 @@ func SignalHandledContext() (context.Context, context.CancelFunc) {
 
 		options := GetOptions()
 
+		if options.Global.WaitOnCancel != nil {
+			log.Info("waiting for the deployment to be rolled back")
+
+			<-options.Global.WaitOnCancel
+
+			return
+		}
+
 		if !options.Global.CleanOnCancel {
 			log.Debug("clean on cancel is not true, exiting")
 
@@ -52,6 +60,11 @@ func SignalHandledContext() (context.Context, context.CancelFunc) {
 		)
 		defer destroyCancel()
 
+		// the lab is only destroyed if its lock is held by this process,
+		// a deploy waiting for the lock of another process must not destroy the lab
+		options.LabLock.Wait = false
+		options.LabLock.ForceUnlock = false
+
 		// destroyFn requires a cobra.Command but only needs the ctx from it
 		destroyCmd := &cobra.Command{}
 		destroyCmd.SetContext(destroyCtx)
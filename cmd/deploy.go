@@ -89,6 +89,35 @@ func deployCmd(o *Options) (*cobra.Command, error) { //nolint: funlen
 		"regenerate configuration artifacts and overwrite previous ones if any",
 	)
 
+	c.Flags().BoolVar(
+		&o.Deploy.Resume,
+		"resume",
+		o.Deploy.Resume,
+		"resume a partially failed deployment, retrying only the failed and missing nodes",
+	)
+
+	c.Flags().BoolVar(
+		&o.Deploy.Atomic,
+		"atomic",
+		o.Deploy.Atomic,
+		"roll back the lab when the deployment fails, is interrupted or does not "+
+			"complete within --atomic-timeout",
+	)
+
+	c.Flags().DurationVar(
+		&o.Deploy.AtomicTimeout,
+		"atomic-timeout",
+		o.Deploy.AtomicTimeout,
+		"time an atomic deployment has to complete within, no deadline when not set",
+	)
+
+	c.Flags().IntVar(
+		&o.Deploy.ToRevision,
+		"to-revision",
+		o.Deploy.ToRevision,
+		"roll the lab back to the topology of the given revision of the lab state history",
+	)
+
 	c.Flags().BoolVar(
 		&o.Deploy.DryRun,
 		"dry-run",
@@ -168,9 +197,55 @@ func deployCmd(o *Options) (*cobra.Command, error) { //nolint: funlen
 			"Can be specified multiple times. Overrides --restore-all for specified nodes.",
 	)
 
+	c.Flags().StringVar(
+		&o.Deploy.ProfileTrace,
+		"profile-trace",
+		"",
+		"write the deployment timeline as a Chrome trace (chrome://tracing, Perfetto) to the given file path",
+	)
+
+	addTopologyOverrideFlags(c, o)
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
 
+// addTopologyOverrideFlags adds the flags overriding the topology fields.
+func addTopologyOverrideFlags(c *cobra.Command, o *Options) {
+	c.Flags().StringArrayVar(
+		&o.Deploy.Set,
+		"set",
+		nil,
+		"override a topology field (format: path=value, e.g. nodes.leaf1.image=srlinux:24.10). "+
+			"Can be specified multiple times.",
+	)
+
+	c.Flags().StringArrayVar(
+		&o.Deploy.SetFiles,
+		"set-file",
+		nil,
+		"YAML file with topology field overrides as path: value pairs. "+
+			"Can be specified multiple times, --set overrides are applied last.",
+	)
+}
+
+// addLabLockFlags adds the flags handling the lab lock held by another process.
+func addLabLockFlags(c *cobra.Command, o *Options) {
+	c.Flags().BoolVar(
+		&o.LabLock.Wait,
+		"wait",
+		o.LabLock.Wait,
+		"wait for the lab lock held by another containerlab process to be released",
+	)
+
+	c.Flags().BoolVar(
+		&o.LabLock.ForceUnlock,
+		"force-unlock",
+		o.LabLock.ForceUnlock,
+		"remove the lab lock held by another containerlab process",
+	)
+}
+
 // deployFn function runs deploy sub command.
 func deployFn(cobraCmd *cobra.Command, o *Options) error {
 	if o.Deploy.DryRun && o.Deploy.Reconfigure {
@@ -180,6 +255,24 @@ func deployFn(cobraCmd *cobra.Command, o *Options) error {
 		)
 	}
 
+	if o.Deploy.Resume && o.Deploy.Reconfigure {
+		return fmt.Errorf(
+			"--resume cannot be combined with --reconfigure: " +
+				"reconfigure always destroys and redeploys the full lab",
+		)
+	}
+
+	if o.Deploy.ToRevision != 0 && (len(o.Deploy.Set) != 0 || len(o.Deploy.SetFiles) != 0) {
+		return fmt.Errorf(
+			"--to-revision cannot be combined with --set or --set-file: " +
+				"the lab is rolled back with the overrides recorded in the revision",
+		)
+	}
+
+	if o.Deploy.ToRevision < 0 {
+		return fmt.Errorf("--to-revision must be a positive revision number")
+	}
+
 	o.Global.BackupTopologyFile = !o.Deploy.DryRun
 
 	var err error
@@ -194,8 +287,14 @@ func deployFn(cobraCmd *cobra.Command, o *Options) error {
 	}
 
 	// destroy-on-cancel must only be armed when deploy creates the lab from scratch;
-	// canceling a reconciliation of an already deployed lab must not destroy it
-	if !o.Deploy.DryRun {
+	// canceling a reconciliation of an already deployed lab must not destroy it.
+	// An atomic deploy rolls back the lab itself, the context handler waits for it.
+	if o.Deploy.Atomic && !o.Deploy.DryRun {
+		rolledBack := make(chan struct{})
+		defer close(rolledBack)
+
+		o.Global.WaitOnCancel = rolledBack
+	} else if !o.Deploy.DryRun {
 		cleanOnCancel := o.Deploy.Reconfigure
 		if !cleanOnCancel {
 			cleanOnCancel, err = c.NeedsInitialDeploy(cobraCmd.Context())
@@ -214,12 +313,16 @@ func deployFn(cobraCmd *cobra.Command, o *Options) error {
 
 	deploymentOptions.SetExportTemplate(o.Deploy.ExportTemplate).
 		SetReconfigure(o.Deploy.Reconfigure).
+		SetResume(o.Deploy.Resume).
+		SetAtomic(o.Deploy.Atomic).
+		SetTimeout(o.Deploy.AtomicTimeout).
 		SetDryRun(o.Deploy.DryRun).
 		SetGraph(o.Deploy.GenerateGraph).
 		SetSkipPostDeploy(o.Deploy.SkipPostDeploy).
 		SetSkipLabDirFileACLs(o.Deploy.SkipLabDirectoryFileACLs).
 		SetRestoreAll(o.Deploy.RestoreAll).
-		SetRestoreNodeSnapshots(o.Deploy.RestoreNodeSnapshots)
+		SetRestoreNodeSnapshots(o.Deploy.RestoreNodeSnapshots).
+		SetProfileTrace(o.Deploy.ProfileTrace)
 
 	result, err := c.Deploy(cobraCmd.Context(), deploymentOptions)
 	if err != nil {
@@ -32,7 +32,9 @@ func TestApplyIsDeployAlias(t *testing.T) {
 		t.Fatal("deploy command is missing the apply alias")
 	}
 
-	for _, flagName := range []string{"dry-run", "max-workers", "skip-post-deploy", "export-template"} {
+	for _, flagName := range []string{
+		"dry-run", "max-workers", "skip-post-deploy", "export-template", "atomic-timeout",
+	} {
 		if deploy.Flags().Lookup(flagName) == nil {
 			t.Fatalf("deploy command missing %q flag", flagName)
 		}
@@ -77,6 +77,8 @@ func destroyCmd(o *Options) (*cobra.Command, error) {
 		"comma separated list of nodes to include",
 	)
 
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
 
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcore "github.com/srl-labs/containerlab/core"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"gopkg.in/yaml.v2"
+)
+
+//nolint:gochecknoglobals
+var (
+	diffHeaderStyle  = lipgloss.NewStyle().Bold(true)
+	diffSectionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	diffDeletedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+func diffCmd(o *Options) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:   "diff",
+		Short: "show the differences between the topology file and the deployed lab",
+		Long: "compare the topology file with the topology the lab was deployed with and " +
+			"with the running lab, without changing the lab" +
+			"\nreference: https://containerlab.dev/cmd/diff/",
+		SilenceUsage: true,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return diffFn(cobraCmd, o)
+		},
+	}
+
+	c.Flags().StringVarP(
+		&o.Diff.Format,
+		"format",
+		"f",
+		o.Diff.Format,
+		"output format. One of [plain, json]",
+	)
+
+	return c, nil
+}
+
+func diffFn(cobraCmd *cobra.Command, o *Options) error {
+	if o.Global.TopologyName == "" && o.Global.TopologyFile == "" {
+		return fmt.Errorf("provide topology file path with --topo flag or lab name with --name flag")
+	}
+
+	switch o.Diff.Format {
+	case clabconstants.FormatPlain, clabconstants.FormatJSON:
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of plain, json", o.Diff.Format)
+	}
+
+	c, err := clabcore.NewContainerLab(o.ToClabOptions()...)
+	if err != nil {
+		return err
+	}
+
+	diff, err := c.Diff(cobraCmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if o.Diff.Format == clabconstants.FormatJSON {
+		b, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(b))
+
+		return nil
+	}
+
+	topoFile := c.TopoPaths.TopologyFilenameAbsPath()
+
+	switch {
+	case diff.State == nil && diff.Runtime == nil:
+		fmt.Printf("Lab %s is not deployed\n", diff.LabName)
+	case diff.Empty():
+		fmt.Printf("Lab %s matches the topology file %s\n", diff.LabName, topoFile)
+	default:
+		printTopologyChanges(os.Stdout, diff.State,
+			"lab state "+c.TopoPaths.StateFile(), "topology "+topoFile)
+		printTopologyChanges(os.Stdout, diff.Runtime,
+			"runtime "+o.Global.Runtime, "topology "+topoFile)
+	}
+
+	return nil
+}
+
+// printTopologyChanges prints the changes in the unified diff format, the lines
+// removed by the changes are prefixed with - and the added lines with +.
+func printTopologyChanges(w io.Writer, changes *clabcore.TopologyChanges, from, to string) {
+	if changes.Empty() {
+		return
+	}
+
+	fmt.Fprintln(w, diffHeaderStyle.Render("--- "+from))
+	fmt.Fprintln(w, diffHeaderStyle.Render("+++ "+to))
+
+	if len(changes.AddedNodes) > 0 || len(changes.DeletedNodes) > 0 {
+		printDiffSection(w, "nodes", changes.DeletedNodes, changes.AddedNodes)
+	}
+
+	for _, node := range changes.ChangedNodes {
+		var deleted, added []string
+
+		for _, field := range node.Fields {
+			deleted = append(deleted, diffFieldLines(field.Field, field.Old)...)
+			added = append(added, diffFieldLines(field.Field, field.New)...)
+		}
+
+		printDiffSection(w, "node "+node.Node, deleted, added)
+	}
+
+	if len(changes.AddedLinks) > 0 || len(changes.DeletedLinks) > 0 {
+		printDiffSection(w, "links", changes.DeletedLinks, changes.AddedLinks)
+	}
+
+	if len(changes.DeletedEndpoints) > 0 {
+		printDiffSection(w, "link endpoints", changes.DeletedEndpoints, nil)
+	}
+
+	if len(changes.Impairments) > 0 {
+		impairments := make([]string, 0, len(changes.Impairments))
+		for i := range changes.Impairments {
+			impairments = append(impairments, impairmentSummary(&changes.Impairments[i]))
+		}
+
+		printDiffSection(w, "link impairments", impairments, nil)
+	}
+
+	fmt.Fprintln(w)
+}
+
+func printDiffSection(w io.Writer, name string, deleted, added []string) {
+	fmt.Fprintln(w, diffSectionStyle.Render("@@ "+name+" @@"))
+
+	for _, line := range deleted {
+		fmt.Fprintln(w, diffDeletedStyle.Render("-"+line))
+	}
+
+	for _, line := range added {
+		fmt.Fprintln(w, diffAddedStyle.Render("+"+line))
+	}
+}
+
+// diffFieldLines returns the YAML lines of the node configuration field value.
+func diffFieldLines(field string, value any) []string {
+	b, err := yaml.Marshal(yaml.MapSlice{{Key: field, Value: value}})
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %v", field, value)}
+	}
+
+	return strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+}
+
+func impairmentSummary(i *clabtypes.ImpairmentData) string {
+	parts := []string{i.Interface}
+
+	if i.Delay != "" {
+		parts = append(parts, "delay="+i.Delay)
+	}
+
+	if i.Jitter != "" {
+		parts = append(parts, "jitter="+i.Jitter)
+	}
+
+	if i.PacketLoss != 0 {
+		parts = append(parts, fmt.Sprintf("loss=%.2f%%", i.PacketLoss))
+	}
+
+	if i.Rate != 0 {
+		parts = append(parts, fmt.Sprintf("rate=%dkbit", i.Rate))
+	}
+
+	if i.Corruption != 0 {
+		parts = append(parts, fmt.Sprintf("corruption=%.2f%%", i.Corruption))
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/spf13/cobra"
 	clabevents "github.com/srl-labs/containerlab/core/events"
 	clabutils "github.com/srl-labs/containerlab/utils"
@@ -21,14 +25,6 @@ func eventsCmd(o *Options) (*cobra.Command, error) {
 		},
 	}
 
-	c.Flags().StringVarP(
-		&o.Events.Format,
-		"format",
-		"f",
-		o.Events.Format,
-		"output format. One of [plain, json]",
-	)
-
 	c.Flags().BoolVarP(
 		&o.Events.IncludeInitialState,
 		"initial-state",
@@ -48,28 +44,217 @@ func eventsCmd(o *Options) (*cobra.Command, error) {
 		&o.Events.StatsInterval,
 		"interface-stats-interval",
 		o.Events.StatsInterval,
-		"interval between interface statistics samples (requires --interface-stats)",
+		"interval between interface statistics samples "+
+			"(requires --interface-stats or --metrics-listen)",
 	)
 
+	c.Flags().StringVar(
+		&o.Events.Record,
+		"record",
+		o.Events.Record,
+		"record the emitted events to the JSONL file for a later replay",
+	)
+
+	addEventOutputFlags(c, o)
+
 	c.Example = `# Stream container and interface events in plain text
 containerlab events
 
 # Stream events as JSON
-containerlab events --format json`
+containerlab events --format json
+
+# Watch a single interface of the leaf1 node of the lab1 lab going down
+containerlab events --filter lab=lab1,node=leaf1,type=interface,attr.ifname=e1-1,attr.state=down
+
+# Post the events to a webhook and stream them to the local consumers of a unix socket
+containerlab events --webhook https://ci.example.com/clab-events --socket /run/clab-events.sock
+
+# Expose the lab node and interface metrics to Prometheus at http://<host>:9100/metrics
+containerlab events --metrics-listen :9100 > /dev/null
+
+# Record the events of an incident for a later replay
+containerlab events --interface-stats --record incident.jsonl`
+
+	replayC := &cobra.Command{
+		Use:   "replay FILE",
+		Short: "replay recorded events",
+		Long: "re-emit the events recorded with --record through the same formatters, " +
+			"metrics and sinks\n" +
+			"reference: https://containerlab.dev/cmd/events/#record-and-replay-events",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return eventsReplayFn(cmd, o, args[0])
+		},
+	}
+
+	replayC.Flags().StringVar(
+		&o.Events.ReplaySpeed,
+		"speed",
+		o.Events.ReplaySpeed,
+		"replay speed relative to the recorded pace (e.g. 10x), 0 replays without delays",
+	)
+
+	addEventOutputFlags(replayC, o)
+
+	replayC.Example = `# Replay a recording at the recorded pace
+containerlab events replay incident.jsonl
+
+# Replay the interface events of a recording ten times faster as JSON
+containerlab events replay incident.jsonl --speed 10x --format json --filter type=interface`
+
+	c.AddCommand(replayC)
 
 	return c, nil
 }
 
+// addEventOutputFlags adds the flags selecting how the streamed or replayed events
+// are filtered, written and delivered.
+func addEventOutputFlags(c *cobra.Command, o *Options) {
+	c.Flags().StringVarP(
+		&o.Events.Format,
+		"format",
+		"f",
+		o.Events.Format,
+		"output format. One of [plain, json]",
+	)
+
+	// the filter expressions are comma separated, so every flag value is a single expression
+	c.Flags().StringArrayVar(
+		&o.Events.Filters,
+		"filter",
+		o.Events.Filters,
+		"only emit the events matching the comma separated key=value conditions, keys are "+
+			"lab, node, kind, type, action and attr.<name>. Can be specified multiple times",
+	)
+
+	c.Flags().StringVar(
+		&o.Events.MetricsListen,
+		"metrics-listen",
+		o.Events.MetricsListen,
+		"serve the node and interface state as Prometheus metrics on the given address "+
+			"(e.g. :9100)",
+	)
+
+	c.Flags().StringVar(
+		&o.Events.SinksConfig,
+		"sinks-config",
+		o.Events.SinksConfig,
+		"YAML file configuring the event sinks",
+	)
+
+	c.Flags().StringSliceVar(
+		&o.Events.Webhooks,
+		"webhook",
+		o.Events.Webhooks,
+		"post the events in batches to the webhook URL. Can be specified multiple times",
+	)
+
+	c.Flags().StringVar(
+		&o.Events.WebhookSecret,
+		"webhook-secret",
+		o.Events.WebhookSecret,
+		"key signing the webhook requests with HMAC-SHA256",
+	)
+
+	c.Flags().BoolVar(
+		&o.Events.FileSink,
+		"file-sink",
+		o.Events.FileSink,
+		"write the events of every lab to the rotated events.jsonl file in the lab directory",
+	)
+
+	c.Flags().StringVar(
+		&o.Events.Socket,
+		"socket",
+		o.Events.Socket,
+		"stream the events as JSONL to the clients of the unix socket at the given path",
+	)
+}
+
 func eventsFn(cmd *cobra.Command, o *Options) error {
+	sinks, err := eventSinks(o.Events)
+	if err != nil {
+		return err
+	}
+
 	opts := clabevents.Options{
 		Format:                o.Events.Format,
 		Runtime:               o.Global.Runtime,
 		IncludeInitialState:   o.Events.IncludeInitialState,
 		IncludeInterfaceStats: o.Events.IncludeInterfaceStats,
 		StatsInterval:         o.Events.StatsInterval,
+		MetricsListen:         o.Events.MetricsListen,
+		Sinks:                 sinks,
+		Filters:               o.Events.Filters,
+		Record:                o.Events.Record,
 		ClabOptions:           o.ToClabOptions(),
 		Writer:                cmd.OutOrStdout(),
 	}
 
 	return clabevents.Stream(cmd.Context(), opts)
 }
+
+// eventSinks returns the sinks configured in the sinks config file and with the flags,
+// the flags add to the sinks of the config file.
+func eventSinks(o *EventsOptions) (*clabevents.SinkConfig, error) {
+	cfg := &clabevents.SinkConfig{}
+
+	if o.SinksConfig != "" {
+		var err error
+
+		cfg, err = clabevents.LoadSinkConfig(o.SinksConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, url := range o.Webhooks {
+		cfg.Webhooks = append(cfg.Webhooks, &clabevents.WebhookSinkConfig{
+			URL:    url,
+			Secret: o.WebhookSecret,
+		})
+	}
+
+	if o.FileSink && cfg.File == nil {
+		cfg.File = &clabevents.FileSinkConfig{}
+	}
+
+	if o.Socket != "" {
+		cfg.Socket = &clabevents.SocketSinkConfig{Path: o.Socket}
+	}
+
+	return cfg, nil
+}
+
+func eventsReplayFn(cmd *cobra.Command, o *Options, path string) error {
+	speed, err := parseReplaySpeed(o.Events.ReplaySpeed)
+	if err != nil {
+		return err
+	}
+
+	sinks, err := eventSinks(o.Events)
+	if err != nil {
+		return err
+	}
+
+	opts := clabevents.Options{
+		Format:        o.Events.Format,
+		MetricsListen: o.Events.MetricsListen,
+		Sinks:         sinks,
+		Filters:       o.Events.Filters,
+		Writer:        cmd.OutOrStdout(),
+	}
+
+	return clabevents.Replay(cmd.Context(), path, speed, opts)
+}
+
+// parseReplaySpeed parses the replay speed given as a factor with an optional x suffix,
+// e.g. 10x or 0.5.
+func parseReplaySpeed(s string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "x"), 64)
+	if err != nil || speed < 0 {
+		return 0, fmt.Errorf("invalid replay speed %q, expected a factor such as 10x", s)
+	}
+
+	return speed, nil
+}
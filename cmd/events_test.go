@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestParseReplaySpeed(t *testing.T) {
+	tests := map[string]struct {
+		speed   string
+		want    float64
+		wantErr bool
+	}{
+		"factor":          {speed: "10x", want: 10},
+		"without suffix":  {speed: "2", want: 2},
+		"slower":          {speed: "0.5x", want: 0.5},
+		"without delays":  {speed: "0", want: 0},
+		"negative":        {speed: "-1x", wantErr: true},
+		"not a number":    {speed: "fast", wantErr: true},
+		"duration format": {speed: "10s", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseReplaySpeed(tt.speed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReplaySpeed(%q) error = %v, wantErr %v", tt.speed, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("parseReplaySpeed(%q) = %v, want %v", tt.speed, got, tt.want)
+			}
+		})
+	}
+}
@@ -83,6 +83,7 @@ func execFn(_ *cobra.Command, o *Options) error {
 		)
 	}
 
+	// exec does not take the lab lock, the hooks of a deployment holding the lock may run it
 	resultCollection, err := c.Exec(ctx, o.Exec.Commands, listOptions...)
 	if err != nil {
 		return err
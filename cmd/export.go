@@ -0,0 +1,69 @@
+// Copyright 2020 Nokia
+// Licensed under the BSD 3-Clause License.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	clabcore "github.com/srl-labs/containerlab/core"
+)
+
+func exportCmd(o *Options) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "export the lab as the NetBox import data",
+		Long: "export the sites, device roles, device types, devices, interfaces, cables and " +
+			"management IP addresses of the lab in the NetBox bulk import format" +
+			"\nreference: https://containerlab.dev/cmd/export/",
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return exportFn(cobraCmd, o)
+		},
+	}
+
+	c.Flags().StringVarP(
+		&o.Export.Format,
+		"format",
+		"f",
+		o.Export.Format,
+		fmt.Sprintf("export format. One of [%s, %s]",
+			clabcore.NetBoxFormatCSV, clabcore.NetBoxFormatJSON),
+	)
+	c.Flags().StringVarP(
+		&o.Export.Output,
+		"output",
+		"o",
+		o.Export.Output,
+		"directory the export files are written to, the netbox directory of the lab directory "+
+			"when not set",
+	)
+	c.Flags().BoolVar(
+		&o.Export.Offline,
+		"offline",
+		o.Export.Offline,
+		"use only information from topo file when exporting the lab",
+	)
+
+	return c, nil
+}
+
+func exportFn(cobraCmd *cobra.Command, o *Options) error {
+	c, err := clabcore.NewContainerLab(o.ToClabOptions()...)
+	if err != nil {
+		return err
+	}
+
+	err = c.ResolveLinks()
+	if err != nil {
+		return err
+	}
+
+	gtopo, _, err := labGraph(cobraCmd.Context(), c, o.Export.Offline)
+	if err != nil {
+		return err
+	}
+
+	return c.GenerateNetBoxExport(o.Export.Format, o.Export.Output, gtopo)
+}
@@ -8,12 +8,18 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"fmt"
 	"html/template"
+	"net/http"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	clabconstants "github.com/srl-labs/containerlab/constants"
 	clabcore "github.com/srl-labs/containerlab/core"
+	clabevents "github.com/srl-labs/containerlab/core/events"
 	clabruntime "github.com/srl-labs/containerlab/runtime"
 	clabtypes "github.com/srl-labs/containerlab/types"
 )
@@ -105,7 +111,34 @@ func graphCmd(o *Options) (*cobra.Command, error) {
 		o.Filter.NodeFilter,
 		"comma separated list of nodes to include",
 	)
-	c.MarkFlagsMutuallyExclusive("dot", "mermaid", "drawio")
+	c.Flags().BoolVarP(
+		&o.Graph.Deps,
+		"deps",
+		"",
+		o.Graph.Deps,
+		"print the graph of the dependencies between the node stages to stdout",
+	)
+	c.Flags().StringVarP(
+		&o.Graph.DepsFormat,
+		"deps-format",
+		"",
+		o.Graph.DepsFormat,
+		"output format of the dependency graph. One of [mermaid, dot, json]",
+	)
+	c.Flags().StringSliceVar(
+		&o.Graph.Render,
+		"render",
+		o.Graph.Render,
+		"render the graph to image files without Graphviz or Docker. One or more of [svg, png]",
+	)
+	c.Flags().StringVar(
+		&o.Graph.Format,
+		"format",
+		o.Graph.Format,
+		"write the graph to a file in the given format. One of "+
+			fmt.Sprintf("%v", clabcore.GraphFormats()),
+	)
+	c.MarkFlagsMutuallyExclusive("dot", "mermaid", "drawio", "deps", "render", "format")
 
 	return c, nil
 }
@@ -121,6 +154,10 @@ func graphFn(ctx context.Context, o *Options) error {
 		return err
 	}
 
+	if o.Graph.Deps {
+		return graphDeps(c, o)
+	}
+
 	if o.Graph.GenerateDotFile {
 		return c.GenerateDotGraph(ctx)
 	}
@@ -133,21 +170,65 @@ func graphFn(ctx context.Context, o *Options) error {
 		return c.GenerateDrawioDiagram(o.Graph.DrawIOVersion, o.Graph.DrawIOArgs)
 	}
 
-	gtopo := clabcore.GraphTopo{
-		Nodes: make([]clabtypes.ContainerDetails, 0, len(c.Nodes)),
-		Links: make([]clabcore.Link, 0, len(c.Links)),
+	if len(o.Graph.Render) > 0 {
+		return c.GenerateDiagram(o.Graph.Render)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	var containers []clabruntime.GenericContainer
+	gtopo, containers, err := labGraph(ctx, c, o.Graph.Offline)
+	if err != nil {
+		return err
+	}
+
+	if o.Graph.Format != "" {
+		return c.GenerateGraphFile(o.Graph.Format, gtopo)
+	}
+
+	b, err := json.Marshal(gtopo)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("generating graph using data: %s", string(b))
+	topoD := clabcore.TopoData{
+		Name: c.Config.Name,
+		Data: template.JS(string(b)), // skipcq: GSC-G203
+	}
+
+	// the graph of a deployed lab follows the state of the lab nodes and links
+	var live http.Handler
+	if len(containers) > 0 {
+		live = startLiveGraph(ctx, o, c.Config.Name)
+	}
+
+	return c.ServeTopoGraph(o.Graph.Template, o.Graph.StaticDirectory, o.Graph.Server,
+		topoD, live)
+}
+
+// labGraph builds the graph of the running lab, or of the topology when the lab is not running
+// or the offline mode is enforced, and returns the containers of the running lab.
+func labGraph(
+	ctx context.Context,
+	c *clabcore.CLab,
+	offline bool,
+) (*clabcore.GraphTopo, []clabruntime.GenericContainer, error) {
+	gtopo := &clabcore.GraphTopo{
+		Nodes: make([]clabtypes.ContainerDetails, 0, len(c.Nodes)),
+		Links: make([]clabcore.Link, 0, len(c.Links)),
+	}
+
+	var (
+		containers []clabruntime.GenericContainer
+		err        error
+	)
 	// if offline mode is not enforced, list containers matching lab name
-	if !o.Graph.Offline {
+	if !offline {
 		containers, err = c.ListContainers(ctx,
 			clabcore.WithListLabName(c.Config.Name))
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		log.Debugf("found %d containers", len(containers))
@@ -155,49 +236,87 @@ func graphFn(ctx context.Context, o *Options) error {
 
 	switch {
 	case len(containers) == 0:
-		c.BuildGraphFromTopo(&gtopo)
+		c.BuildGraphFromTopo(gtopo)
 	case len(containers) > 0:
-		c.BuildGraphFromDeployedLab(&gtopo, containers)
+		c.BuildGraphFromDeployedLab(gtopo, containers)
 	}
 
 	sort.Slice(gtopo.Nodes, func(i, j int) bool {
 		return gtopo.Nodes[i].Name < gtopo.Nodes[j].Name
 	})
 
-	for _, l := range c.Links {
-		eps := l.GetEndpoints()
-		// Skip links that are not point-to-point (e.g. dummy, host, macvlan,
-		// mgmt-net links expose a single endpoint). Indexing eps[1] on such
-		// links would panic, so they are not rendered as edges.
-		if len(eps) != 2 {
-			log.Debugf(
-				"skipping non point-to-point link with %d endpoint(s) in graph topology",
-				len(eps),
-			)
-			continue
-		}
+	return gtopo, containers, nil
+}
 
-		ifaceDisplayNameA := eps[0].GetIfaceDisplayName()
-		ifaceDisplayNameB := eps[1].GetIfaceDisplayName()
+// liveGraphStatsInterval is the interval of the interface statistics
+// the link throughput of the live graph is updated with.
+const liveGraphStatsInterval = time.Second
 
-		gtopo.Links = append(gtopo.Links, clabcore.Link{
-			Source:         eps[0].GetNode().GetShortName(),
-			SourceEndpoint: ifaceDisplayNameA,
-			Target:         eps[1].GetNode().GetShortName(),
-			TargetEndpoint: ifaceDisplayNameB,
-		})
+// startLiveGraph streams the events of the lab to the live graph in the background,
+// the graph is served without the live state when the stream fails.
+func startLiveGraph(ctx context.Context, o *Options, lab string) *clabevents.LiveGraph {
+	live := clabevents.NewLiveGraph(lab)
+
+	opts := clabevents.Options{
+		Runtime:               o.Global.Runtime,
+		IncludeInitialState:   true,
+		IncludeInterfaceStats: true,
+		StatsInterval:         liveGraphStatsInterval,
+		LiveGraph:             live,
+		ClabOptions:           o.ToClabOptions(),
 	}
 
-	b, err := json.Marshal(gtopo)
+	go func() {
+		if err := clabevents.Stream(ctx, opts); err != nil {
+			log.Warnf("failed to stream the live state of the lab to the graph: %v", err)
+		}
+	}()
+
+	return live
+}
+
+// graphDeps prints the dependency graph of the node stages and warns about
+// the dependency cycles and the stages that would or may never be reached.
+func graphDeps(c *clabcore.CLab, o *Options) error {
+	g, err := c.DependencyGraph()
 	if err != nil {
 		return err
 	}
 
-	log.Debugf("generating graph using data: %s", string(b))
-	topoD := clabcore.TopoData{
-		Name: c.Config.Name,
-		Data: template.JS(string(b)), // skipcq: GSC-G203
+	switch o.Graph.DepsFormat {
+	case "mermaid":
+		m, err := g.Mermaid(o.Graph.MermaidDirection)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(m)
+	case "dot":
+		fmt.Print(g.Dot())
+	case clabconstants.FormatJSON:
+		b, err := g.JSON()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(b))
+	default:
+		return fmt.Errorf("unknown dependency graph format %q, expected one of mermaid, dot, json",
+			o.Graph.DepsFormat)
+	}
+
+	for _, cycle := range g.Cycles {
+		log.Warn("Nodes have cyclic dependencies, the deployment will be refused",
+			"nodes", strings.Join(cycle, ", "))
+	}
+
+	for _, u := range g.Unreachable {
+		log.Warn("Stage will never be reached", "node", u.Node, "stage", u.Stage, "reason", u.Reason)
+	}
+
+	for _, w := range g.Warnings {
+		log.Warn("Stage may not be reached", "node", w.Node, "stage", w.Stage, "reason", w.Reason)
 	}
 
-	return c.ServeTopoGraph(o.Graph.Template, o.Graph.StaticDirectory, o.Graph.Server, topoD)
+	return nil
 }
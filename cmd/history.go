@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tableWriter "github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcore "github.com/srl-labs/containerlab/core"
+)
+
+// diffRevisionsMax is the number of the revisions the --diff flag accepts.
+const diffRevisionsMax = 2
+
+func historyCmd(o *Options) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:   "history",
+		Short: "list and diff the recorded topology states of a lab",
+		Long: "list the topology states recorded each time the lab is deployed or a topology " +
+			"change is applied to it, and show the differences between them" +
+			"\nreference: https://containerlab.dev/cmd/history/",
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return historyFn(o)
+		},
+	}
+
+	c.Flags().StringVarP(
+		&o.History.Format,
+		"format",
+		"f",
+		o.History.Format,
+		"output format. One of [table, json]",
+	)
+
+	c.Flags().IntSliceVar(
+		&o.History.Diff,
+		"diff",
+		nil,
+		"show the topology changes of a revision (--diff N) or between two revisions "+
+			"(--diff N,M)",
+	)
+
+	return c, nil
+}
+
+func historyFn(o *Options) error {
+	if o.Global.TopologyName == "" && o.Global.TopologyFile == "" {
+		return fmt.Errorf("provide topology file path with --topo flag or lab name with --name flag")
+	}
+
+	switch o.History.Format {
+	case clabconstants.FormatTable, clabconstants.FormatJSON:
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of table, json",
+			o.History.Format)
+	}
+
+	if len(o.History.Diff) > diffRevisionsMax {
+		return fmt.Errorf("--diff accepts one or two revisions, got %d", len(o.History.Diff))
+	}
+
+	c, err := clabcore.NewContainerLab(o.ToClabOptions()...)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := c.StateHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(o.History.Diff) > 0 {
+		return printRevisionsDiff(os.Stdout, revisions, o.History.Diff)
+	}
+
+	if o.History.Format == clabconstants.FormatJSON {
+		b, err := json.MarshalIndent(revisions, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(b))
+
+		return nil
+	}
+
+	if len(revisions) == 0 {
+		fmt.Printf("No recorded topology states for lab %s\n", c.Config.Name)
+
+		return nil
+	}
+
+	printRevisions(revisions)
+
+	return nil
+}
+
+func printRevisions(revisions []*clabcore.StateRevision) {
+	table := tableWriter.NewWriter()
+	table.SetOutputMirror(os.Stdout)
+	table.SetStyle(tableWriter.StyleRounded)
+	table.Style().Format.Header = text.FormatTitle
+	table.Style().Format.HeaderAlign = text.AlignCenter
+	table.Style().Options.SeparateRows = true
+	table.AppendHeader(tableWriter.Row{"Revision", "Time", "Changes"})
+
+	for _, rev := range revisions {
+		table.AppendRow(tableWriter.Row{
+			rev.Revision,
+			rev.Timestamp.Format(time.DateTime),
+			strings.Join(revisionChanges(rev), "\n"),
+		})
+	}
+
+	table.Render()
+}
+
+// revisionChanges returns the summary of the changes that produced the revision.
+func revisionChanges(rev *clabcore.StateRevision) []string {
+	var changes []string
+
+	if rev.ToRevision > 0 {
+		changes = append(changes, fmt.Sprintf("rolled back to revision %d", rev.ToRevision))
+	}
+
+	result := rev.Result
+	if result == nil {
+		return append(changes, "-")
+	}
+
+	if result.DeployedLab {
+		return append(changes, fmt.Sprintf("deployed lab (%d nodes)", len(result.AddedNodes)))
+	}
+
+	for _, row := range []struct {
+		label  string
+		values []string
+	}{
+		{label: "added nodes", values: result.AddedNodes},
+		{label: "deleted nodes", values: result.DeletedNodes},
+		{label: "recreated nodes", values: result.RecreatedNodes},
+		{label: "started nodes", values: result.StartedNodes},
+		{label: "added links", values: result.AddedLinks},
+		{label: "deleted endpoints", values: result.DeletedEndpoints},
+		{label: "restarted nodes", values: result.RestartedNodes},
+	} {
+		if len(row.values) > 0 {
+			changes = append(changes, row.label+": "+strings.Join(row.values, ", "))
+		}
+	}
+
+	if len(changes) == 0 {
+		changes = append(changes, "no changes")
+	}
+
+	return changes
+}
+
+// printRevisionsDiff prints the unified diff of the topology definitions of two revisions.
+// With a single revision the revision is compared with the revision preceding it.
+func printRevisionsDiff(w io.Writer, revisions []*clabcore.StateRevision, diff []int) error {
+	find := func(n int) (int, error) {
+		for i, rev := range revisions {
+			if rev.Revision == n {
+				return i, nil
+			}
+		}
+
+		return 0, fmt.Errorf("revision %d is not found in the history", n)
+	}
+
+	toIdx, err := find(diff[len(diff)-1])
+	if err != nil {
+		return err
+	}
+
+	to := revisions[toIdx]
+
+	fromName, fromDefinition := "empty topology", ""
+
+	switch {
+	case len(diff) == diffRevisionsMax:
+		fromIdx, err := find(diff[0])
+		if err != nil {
+			return err
+		}
+
+		from := revisions[fromIdx]
+		fromName, fromDefinition = revisionName(from), from.Definition()
+	case toIdx > 0:
+		from := revisions[toIdx-1]
+		fromName, fromDefinition = revisionName(from), from.Definition()
+	}
+
+	out, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromDefinition),
+		B:        difflib.SplitLines(to.Definition()),
+		FromFile: fromName,
+		ToFile:   revisionName(to),
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		out = "No topology changes\n"
+	}
+
+	_, err = io.WriteString(w, out)
+
+	return err
+}
+
+func revisionName(rev *clabcore.StateRevision) string {
+	return fmt.Sprintf("revision %d (%s)", rev.Revision, rev.Timestamp.Format(time.DateTime))
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Nokia
+// Licensed under the BSD 3-Clause License.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/spf13/cobra"
+	clabcore "github.com/srl-labs/containerlab/core"
+	clabcoreimporter "github.com/srl-labs/containerlab/core/importer"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabutils "github.com/srl-labs/containerlab/utils"
+	"gopkg.in/yaml.v2"
+)
+
+func importCmd(o *Options) (*cobra.Command, error) {
+	c := &cobra.Command{
+		Use:   "import <file>",
+		Short: "import a topology from GNS3, EVE-NG, Cisco CML or NetBox",
+		Long: "convert a GNS3 project, an EVE-NG lab, a Cisco CML lab file or a NetBox export " +
+			"to a containerlab topology\nreference: https://containerlab.dev/cmd/import/",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return importFn(o, args)
+		},
+	}
+
+	c.Flags().StringVar(
+		&o.Import.From,
+		"from",
+		o.Import.From,
+		fmt.Sprintf("format of the imported file, one of %v", clabcoreimporter.Formats()),
+	)
+	c.Flags().StringVar(
+		&o.Import.MappingFile,
+		"mapping",
+		o.Import.MappingFile,
+		"path to a mapping table of the node templates to the kinds, "+
+			"its entries take precedence over the default ones",
+	)
+	c.Flags().BoolVar(
+		&o.Import.PrintMapping,
+		"print-mapping",
+		o.Import.PrintMapping,
+		"print the default mapping table to stdout",
+	)
+	c.Flags().StringVarP(
+		&o.Import.Output,
+		"output",
+		"o",
+		o.Import.Output,
+		"path to the topology file to write, the topology is printed to stdout when not set",
+	)
+
+	return c, nil
+}
+
+func importFn(o *Options, args []string) error {
+	if o.Import.PrintMapping {
+		_, err := os.Stdout.Write(clabcoreimporter.DefaultMapping)
+
+		return err
+	}
+
+	if len(args) == 0 {
+		return errors.New("provide the file to import")
+	}
+
+	if o.Import.From == "" {
+		return fmt.Errorf("provide the format of the file with --from flag, one of %v",
+			clabcoreimporter.Formats())
+	}
+
+	mapping, err := clabcoreimporter.LoadMapping(o.Import.MappingFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	clab := &clabcore.CLab{}
+	clab.Reg = clabnodes.NewNodeRegistry()
+	clab.RegisterNodes()
+
+	interfaceFormats := make(map[string]string)
+	for kind, attrs := range clab.Reg.GetGenerateNodeAttributes() {
+		interfaceFormats[kind] = attrs.GetInterfaceFormat()
+	}
+
+	lab, err := clabcoreimporter.Import(o.Import.From, data, mapping, interfaceFormats)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case o.Global.TopologyName != "":
+		lab.Name = o.Global.TopologyName
+	case lab.Name == "":
+		lab.Name = strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+	}
+
+	b, err := yaml.Marshal(lab)
+	if err != nil {
+		return err
+	}
+
+	if o.Import.Output == "" {
+		fmt.Print(string(b))
+
+		return nil
+	}
+
+	if err := clabutils.CreateFile(o.Import.Output, string(b)); err != nil {
+		return err
+	}
+
+	log.Infof("Imported %d nodes and %d links to %s",
+		len(lab.Topology.Nodes), len(lab.Topology.Links), o.Import.Output)
+
+	return nil
+}
@@ -41,6 +41,7 @@ func GetOptions() *Options {
 				LabOwner: os.Getenv("CLAB_OWNER"),
 			},
 			Destroy: &DestroyOptions{},
+			LabLock: &LabLockOptions{},
 			Save:    &SaveOptions{},
 			Exec: &ExecOptions{
 				Format: "plain",
@@ -49,15 +50,31 @@ func GetOptions() *Options {
 				Format:           "table",
 				InterfacesFormat: "table",
 			},
+			Validate: &ValidateOptions{
+				Format: clabconstants.FormatPlain,
+				FailOn: "error",
+			},
 			Graph: &GraphOptions{
 				Server:           "0.0.0.0:50080",
 				MermaidDirection: "TD",
 				DrawIOVersion:    "latest",
+				DepsFormat:       "mermaid",
 			},
 			Events: &EventsOptions{
 				Format:                "plain",
 				IncludeInterfaceStats: false,
 				StatsInterval:         time.Second,
+				ReplaySpeed:           "1x",
+			},
+			History: &HistoryOptions{
+				Format: clabconstants.FormatTable,
+			},
+			Diff: &DiffOptions{
+				Format: clabconstants.FormatPlain,
+			},
+			Import: &ImportOptions{},
+			Export: &ExportOptions{
+				Format: clabcore.NetBoxFormatCSV,
 			},
 			ToolsAPI: &ToolsApiOptions{
 				Image:          "ghcr.io/srl-labs/clab-api-server/clab-api-server:latest",
@@ -132,11 +149,17 @@ type Options struct {
 	NodeLifecycle  *NodeLifecycleOptions
 	Deploy         *DeployOptions
 	Destroy        *DestroyOptions
+	LabLock        *LabLockOptions
 	Save           *SaveOptions
 	Exec           *ExecOptions
 	Inspect        *InspectOptions
+	Validate       *ValidateOptions
 	Graph          *GraphOptions
 	Events         *EventsOptions
+	History        *HistoryOptions
+	Diff           *DiffOptions
+	Import         *ImportOptions
+	Export         *ExportOptions
 	ToolsAPI       *ToolsApiOptions
 	ToolsCert      *ToolsCertOptions
 	ToolsTxOffload *ToolsDisableTxOffloadOptions
@@ -152,6 +175,21 @@ type Options struct {
 func (o *Options) ToClabOptions() []clabcore.ClabOption {
 	var clabOptions []clabcore.ClabOption
 
+	// overrides must be set before the topology is loaded by the global options
+	if len(o.Deploy.Set) != 0 || len(o.Deploy.SetFiles) != 0 {
+		clabOptions = append(
+			clabOptions,
+			clabcore.WithTopologyOverrides(o.Deploy.Set, o.Deploy.SetFiles),
+		)
+	}
+
+	if o.Deploy.ToRevision > 0 {
+		clabOptions = append(
+			clabOptions,
+			clabcore.WithTopologyRevision(o.Deploy.ToRevision),
+		)
+	}
+
 	clabOptions = append(
 		clabOptions,
 		o.Global.toClabOptions()...,
@@ -172,6 +210,11 @@ func (o *Options) ToClabOptions() []clabcore.ClabOption {
 		o.Destroy.toClabOptions()...,
 	)
 
+	clabOptions = append(
+		clabOptions,
+		o.LabLock.toClabOptions()...,
+	)
+
 	return clabOptions
 }
 
@@ -250,6 +293,10 @@ type GlobalOptions struct {
 	// (or not) when root context is canceled
 	CleanOnCancel bool
 
+	// special channel that is only set by an atomic deploy, the context handler waits for it
+	// to be closed when root context is canceled, so that the deploy rolls back the lab
+	WaitOnCancel <-chan struct{}
+
 	// special flag that is only set on deploy,
 	// if true, the topology file backup is created at /tmp/.clab/bak
 	BackupTopologyFile bool
@@ -311,6 +358,9 @@ type DeployOptions struct {
 	ManagementIPv4Subnet     net.IPNet
 	ManagementIPv6Subnet     net.IPNet
 	Reconfigure              bool
+	Resume                   bool
+	Atomic                   bool
+	AtomicTimeout            time.Duration
 	DryRun                   bool
 	MaxWorkers               uint
 	SkipPostDeploy           bool
@@ -320,6 +370,12 @@ type DeployOptions struct {
 	RestoreAll               string
 	RestoreNodeSnapshots     []string
 	ExportRenderedTopology   string
+	ProfileTrace             string
+	// Set and SetFiles are the topology field overrides
+	Set      []string
+	SetFiles []string
+	// ToRevision is the state history revision the lab is rolled back to
+	ToRevision int
 }
 
 func (o *DeployOptions) toClabOptions() []clabcore.ClabOption {
@@ -367,6 +423,19 @@ func (o *DestroyOptions) toClabOptions() []clabcore.ClabOption {
 	return options
 }
 
+type LabLockOptions struct {
+	// Wait for the lab lock held by another process to be released
+	Wait bool
+	// ForceUnlock removes the lab lock held by another process
+	ForceUnlock bool
+}
+
+func (o *LabLockOptions) toClabOptions() []clabcore.ClabOption {
+	return []clabcore.ClabOption{
+		clabcore.WithLabLock(o.Wait, o.ForceUnlock),
+	}
+}
+
 type SaveOptions struct {
 	// Copy is the directory to copy the saved running configs to.
 	Copy string
@@ -385,6 +454,12 @@ type InspectOptions struct {
 	InterfacesNode   string
 }
 
+type ValidateOptions struct {
+	Format   string
+	Suppress []string
+	FailOn   string
+}
+
 type GraphOptions struct {
 	Server           string
 	Template         string
@@ -395,7 +470,14 @@ type GraphOptions struct {
 	GenerateDrawIO   bool
 	DrawIOVersion    string
 	DrawIOArgs       []string
-	StaticDirectory  string
+	// Render are the image formats the graph is rendered to natively
+	Render []string
+	// Format is the structured format the graph file is written in
+	Format string
+
+	StaticDirectory string
+	Deps            bool
+	DepsFormat      string
 }
 
 type EventsOptions struct {
@@ -403,6 +485,44 @@ type EventsOptions struct {
 	IncludeInitialState   bool
 	IncludeInterfaceStats bool
 	StatsInterval         time.Duration
+	MetricsListen         string
+	// SinksConfig is the path of the event sinks config file
+	SinksConfig   string
+	Webhooks      []string
+	WebhookSecret string
+	FileSink      bool
+	Socket        string
+	Filters       []string
+	// Record is the path of the file the emitted events are recorded to
+	Record string
+	// ReplaySpeed is the speed factor of the replayed recording, e.g. 10x
+	ReplaySpeed string
+}
+
+type HistoryOptions struct {
+	Format string
+	// Diff is the revision or the pair of revisions to show the topology changes of
+	Diff []int
+}
+
+type DiffOptions struct {
+	Format string
+}
+
+type ImportOptions struct {
+	// From is the format of the imported file
+	From string
+	// MappingFile is the mapping table of the node templates to the kinds
+	MappingFile  string
+	PrintMapping bool
+	Output       string
+}
+
+type ExportOptions struct {
+	Format string
+	// Output is the directory the export files are written to
+	Output  string
+	Offline bool
 }
 
 type ToolsApiOptions struct {
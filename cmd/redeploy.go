@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	clabcore "github.com/srl-labs/containerlab/core"
 	clabutils "github.com/srl-labs/containerlab/utils"
 )
 
@@ -115,10 +116,27 @@ func redeployCmd(o *Options) (*cobra.Command, error) { //nolint: funlen
 		"skip the lab directory extended ACLs provisioning",
 	)
 
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
 
 func redeployFn(cobraCmd *cobra.Command, o *Options) error {
+	// the lab lock is held across destroy and deploy,
+	// so that no other process changes the lab in between
+	if !o.Destroy.All && o.Global.TopologyFile != "" {
+		c, err := clabcore.NewContainerLab(o.ToClabOptions()...)
+		if err != nil {
+			return err
+		}
+
+		unlock, err := c.LockLab(cobraCmd.Context())
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	// First destroy the lab
 	err := destroyFn(cobraCmd, o)
 	if err != nil {
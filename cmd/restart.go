@@ -29,6 +29,8 @@ func restartCmd(o *Options) (*cobra.Command, error) {
 		"node(s) to restart (repeatable or comma-separated). If omitted, restart all nodes",
 	)
 
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
 
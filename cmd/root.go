@@ -26,13 +26,17 @@ func subcommandRegisterFuncs() []func(*Options) (*cobra.Command, error) {
 		completionCmd,
 		deployCmd,
 		destroyCmd,
+		diffCmd,
 		startCmd,
 		stopCmd,
 		restartCmd,
 		execCmd,
+		exportCmd,
 		generateCmd,
 		graphCmd,
 		eventsCmd,
+		historyCmd,
+		importCmd,
 		inspectCmd,
 		redeployCmd,
 		saveCmd,
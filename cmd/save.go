@@ -46,5 +46,7 @@ the exact command used per node's kind`,
 		"copy the saved running configs this directory. Directory created if does not exist. Supports absolute and relative paths. The lab directory is used as a subdirectory to avoid conflicts when saving configs from multiple labs to the same destination",
 	)
 
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
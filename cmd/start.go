@@ -29,6 +29,8 @@ func startCmd(o *Options) (*cobra.Command, error) {
 		"node(s) to start (repeatable or comma-separated). If omitted, start all nodes",
 	)
 
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
 
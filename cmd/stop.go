@@ -29,6 +29,8 @@ func stopCmd(o *Options) (*cobra.Command, error) {
 		"node(s) to stop (repeatable or comma-separated). If omitted, stop all nodes",
 	)
 
+	addLabLockFlags(c, o)
+
 	return c, nil
 }
 
@@ -162,6 +162,8 @@ func netemSetFn(ctx context.Context, o *Options) error {
 		)
 	}
 
+	// the impairments do not change the lab state, the lab lock is not taken for the hooks
+	// of a deployment holding the lock to be able to set them
 	node, err := clabcore.ResolveNetemNode(
 		ctx,
 		o.Global.Runtime,
@@ -1,32 +1,84 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
+	clabconstants "github.com/srl-labs/containerlab/constants"
 	clabcore "github.com/srl-labs/containerlab/core"
+	clabtypes "github.com/srl-labs/containerlab/types"
 )
 
 func validateCmd(o *Options) (*cobra.Command, error) {
 	c := &cobra.Command{
 		Use:   "validate",
 		Short: "validate a topology file",
-		Long: "parse and validate a topology definition file without deploying it" +
+		Long: "parse, validate and lint a topology definition file without deploying it" +
 			"\nreference: https://containerlab.dev/cmd/validate/",
 		Aliases:      []string{"val"},
 		SilenceUsage: true,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return validateFn(o)
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return validateFn(cobraCmd.Context(), o)
 		},
 	}
 
+	addTopologyOverrideFlags(c, o)
+
+	c.Flags().StringVarP(
+		&o.Validate.Format,
+		"format",
+		"f",
+		o.Validate.Format,
+		"output format of the lint findings. One of [plain, json, sarif]",
+	)
+
+	c.Flags().StringArrayVar(
+		&o.Validate.Suppress,
+		"suppress",
+		nil,
+		"suppress the findings of a lint rule (format: rule or rule:node). "+
+			"Can be specified multiple times.",
+	)
+
+	c.Flags().StringVar(
+		&o.Validate.FailOn,
+		"fail-on",
+		o.Validate.FailOn,
+		"minimum severity of the lint findings failing the validation. One of [error, warning, info]",
+	)
+
 	return c, nil
 }
 
-// validateFn parses the topology (NewContainerLab runs all schema/node checks)
-// and resolves links, reporting any error without touching the runtime state.
-func validateFn(o *Options) error {
+// validateFn parses the topology (NewContainerLab runs all schema/node checks),
+// resolves links and lints the topology, reporting any error without touching the runtime state.
+func validateFn(ctx context.Context, o *Options) error {
+	failOn, err := clabcore.ParseLintSeverity(o.Validate.FailOn)
+	if err != nil {
+		return err
+	}
+
+	switch o.Validate.Format {
+	case clabconstants.FormatPlain, clabconstants.FormatJSON, clabconstants.FormatSARIF:
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of plain, json, sarif",
+			o.Validate.Format)
+	}
+
+	suppressions := make([]*clabtypes.LintSuppression, 0, len(o.Validate.Suppress))
+
+	for _, s := range o.Validate.Suppress {
+		sup, err := clabcore.ParseLintSuppression(s)
+		if err != nil {
+			return err
+		}
+
+		suppressions = append(suppressions, sup)
+	}
+
 	c, err := clabcore.NewContainerLab(o.ToClabOptions()...)
 	if err != nil {
 		return err
@@ -43,8 +95,64 @@ func validateFn(o *Options) error {
 		return err
 	}
 
+	report := c.Lint(ctx, suppressions)
+
+	if err := printLintReport(report, o.Validate.Format); err != nil {
+		return err
+	}
+
+	if report.Failed(failOn) {
+		return fmt.Errorf("topology %q has lint findings with %s severity or higher",
+			c.Config.Name, failOn)
+	}
+
 	log.Info("Topology is valid", "name", c.Config.Name,
 		"nodes", len(c.Nodes), "links", len(c.Links))
 
 	return nil
 }
+
+func printLintReport(report *clabcore.LintReport, format string) error {
+	switch format {
+	case clabconstants.FormatJSON:
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(b))
+
+	case clabconstants.FormatSARIF:
+		b, err := report.SARIF(Version)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(b))
+
+	default:
+		for _, f := range report.Findings {
+			if f.Suppressed {
+				continue
+			}
+
+			logFn := log.Info
+
+			switch f.Severity {
+			case clabcore.LintSeverityError:
+				logFn = log.Error
+			case clabcore.LintSeverityWarning:
+				logFn = log.Warn
+			}
+
+			kv := []any{"rule", f.Rule}
+			if f.Node != "" {
+				kv = append(kv, "node", f.Node)
+			}
+
+			logFn(f.Message, kv...)
+		}
+	}
+
+	return nil
+}
@@ -15,4 +15,5 @@ const (
 	FormatCSV   = "csv"
 	FormatTable = "table"
 	FormatPlain = "plain"
+	FormatSARIF = "sarif"
 )
@@ -3,26 +3,29 @@ package core
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
 	clablinks "github.com/srl-labs/containerlab/links"
+	clabtypes "github.com/srl-labs/containerlab/types"
 )
 
 // ApplyResult summarizes the changes applied by an apply operation.
 type ApplyResult struct {
-	DryRun           bool     `json:"dry-run"`
-	DeployedLab      bool     `json:"deployed-lab"`
-	LabName          string   `json:"lab-name,omitempty"`
-	AddedNodes       []string `json:"added-nodes"`
-	DeletedNodes     []string `json:"deleted-nodes"`
-	RecreatedNodes   []string `json:"recreated-nodes"`
-	StartedNodes     []string `json:"started-nodes"`
-	AddedLinks       []string `json:"added-links"`
-	DeletedEndpoints []string `json:"deleted-endpoints"`
-	RestartedNodes   []string `json:"restarted-nodes"`
+	DryRun           bool     `json:"dry-run" yaml:"dry-run,omitempty"`
+	DeployedLab      bool     `json:"deployed-lab" yaml:"deployed-lab,omitempty"`
+	LabName          string   `json:"lab-name,omitempty" yaml:"lab-name,omitempty"`
+	AddedNodes       []string `json:"added-nodes" yaml:"added-nodes,omitempty"`
+	DeletedNodes     []string `json:"deleted-nodes" yaml:"deleted-nodes,omitempty"`
+	RecreatedNodes   []string `json:"recreated-nodes" yaml:"recreated-nodes,omitempty"`
+	StartedNodes     []string `json:"started-nodes" yaml:"started-nodes,omitempty"`
+	AddedLinks       []string `json:"added-links" yaml:"added-links,omitempty"`
+	DeletedEndpoints []string `json:"deleted-endpoints" yaml:"deleted-endpoints,omitempty"`
+	RestartedNodes   []string `json:"restarted-nodes" yaml:"restarted-nodes,omitempty"`
 	// NodeChangeReasons explains per node why apply restarts or recreates it,
 	// e.g. "added link" or "config drift: image".
-	NodeChangeReasons map[string]string `json:"node-change-reasons,omitempty"`
+	NodeChangeReasons map[string]string `json:"node-change-reasons,omitempty" yaml:"node-change-reasons,omitempty"`
 }
 
 func applyResultFromPlan(plan *applyPlan) *ApplyResult {
@@ -81,12 +84,67 @@ func (c *CLab) Apply(
 		)
 	}
 
+	if !options.dryRun {
+		unlock, err := c.lockLab(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
 	currentNodes, err := c.runtimeNodeGroups(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.apply(ctx, options, currentNodes)
+	return c.reconcile(ctx, options, currentNodes)
+}
+
+// reconcile applies the topology to the lab. Unless it is a dry run, the apply events
+// are published and the post-apply hooks are run.
+func (c *CLab) reconcile(
+	ctx context.Context,
+	options *ApplyOptions,
+	currentNodes map[string]*runtimeNodeGroup,
+) (*ApplyResult, error) {
+	if options.dryRun {
+		return c.apply(ctx, options, currentNodes)
+	}
+
+	c.publishEvent(clabcorebus.ActionApplyStart, nil)
+
+	result, err := c.apply(ctx, options, currentNodes)
+	if err == nil {
+		err = c.runHooks(ctx, clabtypes.HookPostApply)
+	}
+
+	if err != nil {
+		c.publishEvent(clabcorebus.ActionApplyFail, map[string]string{"error": err.Error()})
+
+		return nil, err
+	}
+
+	c.publishEvent(clabcorebus.ActionApplyDone, applyEventAttributes(result))
+
+	return result, nil
+}
+
+// applyEventAttributes summarizes the applied changes in the lifecycle event attributes.
+func applyEventAttributes(result *ApplyResult) map[string]string {
+	if result == nil {
+		return nil
+	}
+
+	return map[string]string{
+		"deployed_lab":      strconv.FormatBool(result.DeployedLab),
+		"added_nodes":       strings.Join(result.AddedNodes, ","),
+		"deleted_nodes":     strings.Join(result.DeletedNodes, ","),
+		"recreated_nodes":   strings.Join(result.RecreatedNodes, ","),
+		"restarted_nodes":   strings.Join(result.RestartedNodes, ","),
+		"started_nodes":     strings.Join(result.StartedNodes, ","),
+		"added_links":       strconv.Itoa(len(result.AddedLinks)),
+		"deleted_endpoints": strconv.Itoa(len(result.DeletedEndpoints)),
+	}
 }
 
 func (c *CLab) apply(
@@ -125,27 +183,11 @@ func (c *CLab) apply(
 		return result, nil
 	}
 
-	if err := c.checkUnsupportedApplyNodes(); err != nil {
-		return nil, err
-	}
-
-	if err := c.setMgmtBridgeFromRuntime(currentNodes); err != nil {
-		return nil, err
-	}
-
-	if err := clablinks.SetMgmtNetUnderlyingBridge(c.Config.Mgmt.Bridge); err != nil {
+	if err := c.resolveApplyTopology(ctx, currentNodes); err != nil {
 		return nil, err
 	}
 
-	if err := c.ResolveLinks(); err != nil {
-		return nil, err
-	}
-
-	if err := c.checkApplyTopologyDefinition(ctx); err != nil {
-		return nil, err
-	}
-
-	plan, err := c.planApply(ctx, currentNodes)
+	plan, err := c.planApply(ctx, currentNodes, options.resume)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +234,8 @@ func (c *CLab) apply(
 	}
 
 	if err := c.DeployNodes(ctx, deployNodeNames, options.maxWorkers); err != nil {
+		c.writeFailedState(plan.state)
+
 		return nil, err
 	}
 
@@ -212,6 +256,8 @@ func (c *CLab) apply(
 	}
 
 	if err := c.postDeployApplyNodes(ctx, deployNodeNames, options.skipPostDeploy); err != nil {
+		c.writeFailedState(plan.state)
+
 		return nil, err
 	}
 
@@ -227,7 +273,35 @@ func (c *CLab) apply(
 		return nil, err
 	}
 
-	return applyResultFromPlan(plan), nil
+	result := applyResultFromPlan(plan)
+	c.recordStateRevision(result)
+
+	return result, nil
+}
+
+// resolveApplyTopology resolves the topology links against the management network
+// of the running lab and checks that the topology changes can be applied to the lab.
+func (c *CLab) resolveApplyTopology(
+	ctx context.Context,
+	currentNodes map[string]*runtimeNodeGroup,
+) error {
+	if err := c.checkUnsupportedApplyNodes(); err != nil {
+		return err
+	}
+
+	if err := c.setMgmtBridgeFromRuntime(currentNodes); err != nil {
+		return err
+	}
+
+	if err := clablinks.SetMgmtNetUnderlyingBridge(c.Config.Mgmt.Bridge); err != nil {
+		return err
+	}
+
+	if err := c.ResolveLinks(); err != nil {
+		return err
+	}
+
+	return c.checkApplyTopologyDefinition(ctx)
 }
 
 func (c *CLab) checkApplyTopologyDefinition(ctx context.Context) error {
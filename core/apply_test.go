@@ -12,6 +12,7 @@ import (
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
 	clablinks "github.com/srl-labs/containerlab/links"
 	clabmocksmocknodes "github.com/srl-labs/containerlab/mocks/mocknodes"
 	clabmocksmockruntime "github.com/srl-labs/containerlab/mocks/mockruntime"
@@ -20,6 +21,7 @@ import (
 	clabruntime "github.com/srl-labs/containerlab/runtime"
 	clabruntimedocker "github.com/srl-labs/containerlab/runtime/docker"
 	clabtypes "github.com/srl-labs/containerlab/types"
+	clabutils "github.com/srl-labs/containerlab/utils"
 	"github.com/vishvananda/netlink"
 	"go.uber.org/mock/gomock"
 )
@@ -1087,3 +1089,122 @@ func TestRuntimeContainerSortsSrosComponentsInDeploymentOrder(t *testing.T) {
 		t.Fatalf("unexpected component order %v, want %v", got, want)
 	}
 }
+
+func TestDeployReconcilesDeployedLab(t *testing.T) {
+	if loaded, err := clabutils.IsKernelModuleLoaded("ip_tables"); err != nil || !loaded {
+		t.Skip("the lab preparation requires the ip_tables kernel module")
+	}
+
+	out := filepath.Join(t.TempDir(), "hooks.log")
+	t.Setenv("HOOKS_TEST_OUT", out)
+
+	topoFile := filepath.Join(t.TempDir(), "post-apply.clab.yml")
+	// the node without the management network keeps the hosts file untouched
+	topo := `name: post-apply
+mgmt:
+  skip-when-unused: true
+hooks:
+  pre-deploy:
+    - command: echo "$CLAB_HOOK" >> "$HOOKS_TEST_OUT"
+  post-apply:
+    - command: echo "$CLAB_HOOK" >> "$HOOKS_TEST_OUT"
+  post-deploy:
+    - command: echo "$CLAB_HOOK" >> "$HOOKS_TEST_OUT"
+topology:
+  nodes:
+    n1:
+      kind: linux
+      image: alpine:latest
+      network-mode: none
+`
+	if err := os.WriteFile(topoFile, []byte(topo), 0o644); err != nil {
+		t.Fatalf("failed to write topology file: %v", err)
+	}
+
+	c, err := NewContainerLab(WithTopoPath(topoFile, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(c.TopoPaths.SSHConfigPath()) })
+
+	ctrl := gomock.NewController(t)
+	mockRuntime := clabmocksmockruntime.NewMockContainerRuntime(ctrl)
+	mockNode := clabmocksmocknodes.NewMockNode(ctrl)
+
+	nodeCfg := c.Nodes["n1"].Config()
+	diff := &clabtypes.TopologyDiff{}
+
+	mockRuntime.EXPECT().
+		ListContainers(gomock.Any(), gomock.Any()).
+		Return([]clabruntime.GenericContainer{
+			{
+				Labels: map[string]string{
+					clabconstants.NodeName:      "n1",
+					clabconstants.NodeMgmtNetBr: "br-test",
+				},
+			},
+		}, nil).AnyTimes()
+	mockNode.EXPECT().Config().Return(nodeCfg).AnyTimes()
+	mockNode.EXPECT().CheckDeploymentConditions(gomock.Any()).Return(nil)
+	mockNode.EXPECT().ComputeDiff(gomock.Any(), gomock.Any()).Return(diff)
+	mockNode.EXPECT().
+		GetReconcilePlan(gomock.Any(), diff).
+		Return(&clabnodes.ReconcileResult{Action: clabtypes.TopologyDiffActionNone}, nil)
+	mockNode.EXPECT().GetContainerStatus(gomock.Any()).Return(clabruntime.Running).AnyTimes()
+	mockNode.EXPECT().ExecFunction(gomock.Any(), gomock.Any()).Return(nil)
+	mockNode.EXPECT().
+		Reconcile(gomock.Any(), diff).
+		Return(&clabnodes.ReconcileResult{Action: clabtypes.TopologyDiffActionNone}, nil)
+
+	mockNode.EXPECT().GetContainers(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockNode.EXPECT().GetSSHConfig().Return(&clabtypes.SSHConfig{}).AnyTimes()
+
+	c.Nodes["n1"] = mockNode
+	c.Runtimes[c.globalRuntimeName] = mockRuntime
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	busDir := t.TempDir()
+
+	subscriber, err := clabcorebus.Subscribe(ctx, busDir)
+	if err != nil {
+		t.Fatalf("failed to subscribe to the event bus: %v", err)
+	}
+
+	c.eventBus = clabcorebus.NewPublisher(busDir)
+
+	// the deploy of the deployed lab reconciles it without changes
+	if _, err := c.Deploy(ctx, &DeployOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var actions []string
+
+	for !slices.Contains(actions, clabcorebus.ActionDeployDone) {
+		select {
+		case ev := <-subscriber.Events():
+			actions = append(actions, ev.Action)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the deploy events, got %v", actions)
+		}
+	}
+
+	wantActions := []string{
+		clabcorebus.ActionDeployStart, clabcorebus.ActionApplyStart,
+		clabcorebus.ActionApplyDone, clabcorebus.ActionDeployDone,
+	}
+	if !slices.Equal(actions, wantActions) {
+		t.Errorf("events = %v, want %v", actions, wantActions)
+	}
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"pre-deploy", "post-apply", "post-deploy"}
+	if got := strings.Fields(string(b)); !slices.Equal(got, want) {
+		t.Errorf("hooks run = %v, want %v", got, want)
+	}
+}
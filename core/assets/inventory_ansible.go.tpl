@@ -49,6 +49,14 @@ all:
         {{- if .EmitAnsiblePasswordOnHost }}
           ansible_password: {{.Credentials.Password}}
         {{- end }}
+        {{- with .IPAM }}
+        {{- if .LoopbackIPv4 }}
+          loopback_ipv4: {{.LoopbackIPv4}}
+        {{- end }}
+        {{- if .LoopbackIPv6 }}
+          loopback_ipv6: {{.LoopbackIPv6}}
+        {{- end }}
+        {{- end }}
       {{- end}}
 {{- end}}
 {{- range $name, $nodes := .Groups}}
@@ -65,5 +73,13 @@ all:
         {{- if .EmitAnsiblePasswordOnHost }}
           ansible_password: {{.Credentials.Password}}
         {{- end }}
+        {{- with .IPAM }}
+        {{- if .LoopbackIPv4 }}
+          loopback_ipv4: {{.LoopbackIPv4}}
+        {{- end }}
+        {{- if .LoopbackIPv6 }}
+          loopback_ipv6: {{.LoopbackIPv6}}
+        {{- end }}
+        {{- end }}
       {{- end}}
 {{- end}}
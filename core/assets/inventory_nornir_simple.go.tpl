@@ -13,5 +13,16 @@
       - {{ $group }}
     {{- end }}
     {{- end }}
+    {{- with $node.IPAM }}
+    {{- if or .LoopbackIPv4 .LoopbackIPv6 }}
+    data:
+      {{- if .LoopbackIPv4 }}
+      loopback_ipv4: {{ .LoopbackIPv4 }}
+      {{- end }}
+      {{- if .LoopbackIPv6 }}
+      loopback_ipv6: {{ .LoopbackIPv6 }}
+      {{- end }}
+    {{- end }}
+    {{- end }}
   {{- end }}
 {{- end }}
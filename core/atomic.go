@@ -0,0 +1,242 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// deployRollbackTimeout is the time the rollback of a failed atomic deployment has to complete
+// within, the rollback does not use the deploy context as it is canceled or past its deadline.
+const deployRollbackTimeout = 5 * time.Minute
+
+// deployTransaction tracks the resources created by an atomic deployment,
+// so that they are removed when the deployment fails, times out or is interrupted.
+// The record methods are no-ops on a nil transaction, i.e. for a non-atomic deployment.
+type deployTransaction struct {
+	m sync.Mutex
+	// mgmtNetwork is set once the management network is created by the deployment
+	mgmtNetwork bool
+	nodes       []clabnodes.Node
+	links       []clablinks.Link
+	linkSet     map[clablinks.Link]struct{}
+	// hostsEntries and sshConfig are set once the lab entries are written to
+	// the hosts file and the ssh config file of the lab is written
+	hostsEntries bool
+	sshConfig    bool
+}
+
+// recordMgmtNetwork records that the management network is created by the deployment.
+func (t *deployTransaction) recordMgmtNetwork() {
+	if t == nil {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.mgmtNetwork = true
+}
+
+// recordNode records the node whose container is about to be created.
+func (t *deployTransaction) recordNode(node clabnodes.Node) {
+	if t == nil {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.nodes = append(t.nodes, node)
+}
+
+// recordNodeLinks records the links of the node endpoints that are about to be deployed.
+func (t *deployTransaction) recordNodeLinks(node clabnodes.Node) {
+	if t == nil {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if t.linkSet == nil {
+		t.linkSet = map[clablinks.Link]struct{}{}
+	}
+
+	for _, ep := range node.GetEndpoints() {
+		link := ep.GetLink()
+		if link == nil {
+			continue
+		}
+
+		if _, exists := t.linkSet[link]; exists {
+			continue
+		}
+
+		t.linkSet[link] = struct{}{}
+		t.links = append(t.links, link)
+	}
+}
+
+// recordHostsEntries records that the lab entries are about to be written to the hosts file.
+func (t *deployTransaction) recordHostsEntries() {
+	if t == nil {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.hostsEntries = true
+}
+
+// recordSSHConfig records that the ssh config file of the lab is about to be written.
+func (t *deployTransaction) recordSSHConfig() {
+	if t == nil {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.sshConfig = true
+}
+
+// empty reports whether the transaction recorded no resources.
+func (t *deployTransaction) empty() bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	return !t.mgmtNetwork && len(t.nodes) == 0 && len(t.links) == 0 &&
+		!t.hostsEntries && !t.sshConfig
+}
+
+// deployAtomic deploys the lab within the deploy timeout and rolls back the resources
+// created by the deployment when it fails, times out or is canceled.
+// A failed post-deploy hook rolls back the deployment as well.
+func (c *CLab) deployAtomic(
+	ctx context.Context,
+	options *DeployOptions,
+) (*DeployResult, error) {
+	c.transaction = &deployTransaction{}
+	defer func() { c.transaction = nil }()
+
+	deployCtx := ctx
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+
+		deployCtx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+
+	result, err := c.converge(deployCtx, options)
+	if err == nil {
+		err = c.runHooks(deployCtx, clabtypes.HookPostDeploy)
+	}
+
+	if err == nil {
+		return result, nil
+	}
+
+	if c.transaction.empty() {
+		return nil, err
+	}
+
+	if ctx.Err() == nil && errors.Is(deployCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("deployment did not complete within %s: %w", options.timeout, err)
+	}
+
+	log.Error("Atomic deployment failed, rolling back the lab", "lab", c.Config.Name, "err", err)
+
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deployRollbackTimeout)
+	defer cancel()
+
+	if rollbackErr := c.rollbackDeploy(rollbackCtx, options.maxWorkers); rollbackErr != nil {
+		return nil, errors.Join(err, fmt.Errorf("rollback failed: %w", rollbackErr))
+	}
+
+	log.Info("Rolled back the failed deployment", "lab", c.Config.Name)
+
+	return nil, err
+}
+
+// rollbackDeploy removes the resources recorded by the deploy transaction
+// in the reverse order of their creation.
+func (c *CLab) rollbackDeploy(ctx context.Context, maxWorkers uint) error {
+	t := c.transaction
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	var errs []error
+
+	if t.sshConfig {
+		log.Info("Removing SSH config", "path", c.TopoPaths.SSHConfigPath())
+
+		if err := c.RemoveSSHConfig(c.TopoPaths); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove ssh config file: %w", err))
+		}
+	}
+
+	if t.hostsEntries {
+		log.Info("Removing host entries", "path", "/etc/hosts")
+
+		if err := c.DeleteEntriesFromHostsFile(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clean up the hosts file: %w", err))
+		}
+	}
+
+	// the links are removed before the nodes to remove their host side interfaces,
+	// the interfaces in the node namespaces are removed with the containers anyway
+	for _, link := range t.links {
+		if err := link.Remove(ctx); err != nil {
+			log.Warn("Failed to remove link", "link", applyLinkName(link), "err", err)
+		}
+	}
+
+	var nodeWorkers errgroup.Group
+	if maxWorkers > 0 {
+		nodeWorkers.SetLimit(int(maxWorkers))
+	}
+
+	nodeErrs := make([]error, len(t.nodes))
+
+	for i, node := range t.nodes {
+		nodeWorkers.Go(func() error {
+			log.Info("Removing node", "node", node.GetShortName())
+
+			if err := node.Delete(ctx); err != nil {
+				nodeErrs[i] = fmt.Errorf("failed to remove node %q: %w", node.GetShortName(), err)
+
+				return nil
+			}
+
+			if err := node.DeleteNetnsSymlink(); err != nil {
+				log.Warn("Failed to remove netns symlink", "node", node.GetShortName(), "err", err)
+			}
+
+			return nil
+		})
+	}
+
+	_ = nodeWorkers.Wait()
+
+	errs = append(errs, nodeErrs...)
+
+	if t.mgmtNetwork && c.Config.Mgmt.Network != "bridge" {
+		// the network is kept when it is used by the containers of other labs
+		if err := c.globalRuntime().DeleteNet(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove the management network: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabmocksmocknodes "github.com/srl-labs/containerlab/mocks/mocknodes"
+	"go.uber.org/mock/gomock"
+)
+
+type atomicFakeLink struct {
+	clablinks.Link
+	removed int
+}
+
+func (l *atomicFakeLink) Remove(context.Context) error {
+	l.removed++
+
+	return nil
+}
+
+func TestDeployTransactionRecordNilSafe(t *testing.T) {
+	var tx *deployTransaction
+
+	tx.recordMgmtNetwork()
+	tx.recordNode(nil)
+	tx.recordNodeLinks(nil)
+	tx.recordHostsEntries()
+	tx.recordSSHConfig()
+}
+
+func TestRollbackDeploy(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	link := &atomicFakeLink{}
+	n1 := clabmocksmocknodes.NewMockNode(ctrl)
+	n2 := clabmocksmocknodes.NewMockNode(ctrl)
+
+	n1.EXPECT().GetShortName().Return("n1").AnyTimes()
+	n2.EXPECT().GetShortName().Return("n2").AnyTimes()
+
+	// both nodes are the endpoints of the same link
+	n1.EXPECT().GetEndpoints().Return([]clablinks.Endpoint{
+		clablinks.NewEndpointVeth(clablinks.NewEndpointGeneric(n1, "eth1", link)),
+	})
+	n2.EXPECT().GetEndpoints().Return([]clablinks.Endpoint{
+		clablinks.NewEndpointVeth(clablinks.NewEndpointGeneric(n2, "eth1", link)),
+	})
+
+	n1.EXPECT().Delete(ctx).Return(nil)
+	n1.EXPECT().DeleteNetnsSymlink().Return(nil)
+	n2.EXPECT().Delete(ctx).Return(errors.New("container is busy"))
+
+	c := &CLab{transaction: &deployTransaction{}}
+	c.transaction.recordNode(n1)
+	c.transaction.recordNode(n2)
+	c.transaction.recordNodeLinks(n1)
+	c.transaction.recordNodeLinks(n2)
+
+	if c.transaction.empty() {
+		t.Fatal("expected the transaction to record the nodes")
+	}
+
+	err := c.rollbackDeploy(ctx, 1)
+	if err == nil || !strings.Contains(err.Error(), `failed to remove node "n2": container is busy`) {
+		t.Errorf("expected the removal of n2 to fail, got %v", err)
+	}
+
+	if link.removed != 1 {
+		t.Errorf("link removed %d times, want 1", link.removed)
+	}
+}
@@ -0,0 +1,216 @@
+// Package bus delivers the lifecycle events of the containerlab operations to the local
+// subscribers, such as the events command. Every subscriber listens on a unix datagram socket
+// in the bus directory and the publishers send every event to all sockets found there,
+// so the events are delivered across the containerlab processes without a daemon.
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+)
+
+const (
+	// DefaultDir is the directory of the subscriber sockets.
+	DefaultDir = "/run/containerlab/events"
+
+	socketSuffix = ".sock"
+	// maxEventSize is the size of the largest event datagram a subscriber reads.
+	maxEventSize = 64 * 1024
+	// publishTimeout bounds the time a publisher waits for a subscriber
+	// with a full receive queue.
+	publishTimeout      = 100 * time.Millisecond
+	subscriberQueueSize = 128
+)
+
+// Lifecycle event actions.
+const (
+	ActionDeployStart  = "deploy_start"
+	ActionDeployDone   = "deploy_done"
+	ActionDeployFail   = "deploy_fail"
+	ActionDestroyStart = "destroy_start"
+	ActionDestroyDone  = "destroy_done"
+	ActionDestroyFail  = "destroy_fail"
+	ActionApplyStart   = "apply_start"
+	ActionApplyDone    = "apply_done"
+	ActionApplyFail    = "apply_fail"
+	ActionNodeStage    = "node_stage"
+	ActionNodeFail     = "node_fail"
+	ActionLinkDeploy   = "link_deploy"
+	ActionExec         = "exec"
+	ActionSaveDone     = "save_done"
+	ActionSaveFail     = "save_fail"
+)
+
+// Event is a lifecycle event of a containerlab operation.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	// Actor is the container name of the node or the lab name for the lab-wide events.
+	Actor string `json:"actor"`
+	// Attributes carry the lab and node names and the action details.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Publisher sends the events to the subscribers of the bus. A nil publisher
+// publishes nothing.
+type Publisher struct {
+	dir string
+}
+
+// NewPublisher returns a publisher of the bus in the directory.
+func NewPublisher(dir string) *Publisher {
+	return &Publisher{dir: dir}
+}
+
+// Publish sends the event to every subscriber. The delivery is best effort:
+// the event is dropped for the subscribers that are not able to receive it.
+func (p *Publisher) Publish(ev Event) {
+	if p == nil {
+		return
+	}
+
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		// no subscriber has ever started
+		return
+	}
+
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now()
+	}
+
+	b, err := json.Marshal(ev)
+	if err != nil {
+		log.Debugf("failed to marshal lifecycle event: %v", err)
+
+		return
+	}
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), socketSuffix) {
+			continue
+		}
+
+		path := filepath.Join(p.dir, entry.Name())
+
+		if err := send(path, b); err != nil {
+			// the socket of a subscriber that exited without removing it
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				_ = os.Remove(path)
+
+				continue
+			}
+
+			log.Debugf("failed to publish lifecycle event to %s: %v", path, err)
+		}
+	}
+}
+
+func send(path string, b []byte) error {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(publishTimeout)); err != nil {
+		return err
+	}
+
+	_, err = conn.Write(b)
+
+	return err
+}
+
+// subscriptions numbers the subscriber sockets of the process.
+var subscriptions atomic.Uint64 //nolint:gochecknoglobals
+
+// Subscriber receives the events published on the bus.
+type Subscriber struct {
+	path   string
+	conn   *net.UnixConn
+	events chan Event
+}
+
+// Subscribe starts receiving the events published on the bus in the directory,
+// the subscription ends when the context is canceled.
+func Subscribe(ctx context.Context, dir string) (*Subscriber, error) {
+	if err := os.MkdirAll(dir, clabconstants.PermissionsDirDefault); err != nil {
+		return nil, fmt.Errorf("failed to create the event bus directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%d-%d%s",
+		os.Getpid(), subscriptions.Add(1), socketSuffix))
+
+	// a socket left behind by a previous process with the same pid
+	_ = os.Remove(path)
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the event bus socket %q: %w", path, err)
+	}
+
+	s := &Subscriber{
+		path:   path,
+		conn:   conn,
+		events: make(chan Event, subscriberQueueSize),
+	}
+
+	go s.receive(ctx)
+
+	go func() {
+		<-ctx.Done()
+
+		s.conn.Close()
+	}()
+
+	return s, nil
+}
+
+// Events returns the channel of the received events, it is closed when the subscription ends.
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscriber) receive(ctx context.Context) {
+	defer close(s.events)
+	defer os.Remove(s.path)
+
+	buf := make([]byte, maxEventSize)
+
+	for {
+		n, err := s.conn.Read(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Debugf("failed to read from the event bus socket: %v", err)
+			}
+
+			return
+		}
+
+		var ev Event
+		if err := json.Unmarshal(buf[:n], &ev); err != nil {
+			log.Debugf("failed to unmarshal lifecycle event: %v", err)
+
+			continue
+		}
+
+		select {
+		case s.events <- ev:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
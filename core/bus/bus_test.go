@@ -0,0 +1,112 @@
+package bus
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func receiveEvent(t *testing.T, s *Subscriber) Event {
+	t.Helper()
+
+	select {
+	case ev := <-s.Events():
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the event")
+	}
+
+	return Event{}
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscribers := make([]*Subscriber, 0, 2)
+
+	for range 2 {
+		s, err := Subscribe(ctx, dir)
+		if err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+
+		subscribers = append(subscribers, s)
+	}
+
+	want := Event{
+		Timestamp:  time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		Action:     ActionNodeStage,
+		Actor:      "clab-lab1-n1",
+		Attributes: map[string]string{"lab": "lab1", "node": "n1", "stage": "configure"},
+	}
+
+	NewPublisher(dir).Publish(want)
+
+	for i, s := range subscribers {
+		if d := cmp.Diff(want, receiveEvent(t, s)); d != "" {
+			t.Errorf("subscriber %d event mismatch (-want +got):\n%s", i, d)
+		}
+	}
+}
+
+func TestSubscriptionEnds(t *testing.T) {
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s, err := Subscribe(ctx, dir)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	NewPublisher(dir).Publish(Event{Action: ActionDeployStart, Actor: "lab1"})
+
+	if ev := receiveEvent(t, s); ev.Timestamp.IsZero() {
+		t.Error("expected the publisher to set the event timestamp")
+	}
+
+	cancel()
+
+	// the events channel is closed and the socket is removed
+	for range s.Events() {
+	}
+
+	if _, err := os.Stat(s.path); !os.IsNotExist(err) {
+		t.Errorf("expected the subscriber socket to be removed, got %v", err)
+	}
+}
+
+func TestPublishRemovesStaleSockets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "1"+socketSuffix)
+
+	// a socket without a reader left behind by a subscriber that was killed
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("failed to create socket: %v", err)
+	}
+
+	conn.Close()
+
+	NewPublisher(dir).Publish(Event{Action: ActionDeployStart, Actor: "lab1"})
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the stale socket to be removed, got %v", err)
+	}
+}
+
+func TestPublishWithoutSubscribers(t *testing.T) {
+	// neither a nil publisher nor a missing bus directory fail the publishing
+	var p *Publisher
+	p.Publish(Event{Action: ActionDeployStart})
+
+	NewPublisher(filepath.Join(t.TempDir(), "missing")).Publish(Event{Action: ActionDeployStart})
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	clabcert "github.com/srl-labs/containerlab/cert"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
 	clabcoredependency_manager "github.com/srl-labs/containerlab/core/dependency_manager"
 	claberrors "github.com/srl-labs/containerlab/errors"
 	clabexec "github.com/srl-labs/containerlab/exec"
@@ -66,6 +67,25 @@ type CLab struct {
 	// to avoid repeated repository opens. Empty strings indicate not yet cached.
 	gitBranch string
 	gitHash   string
+	// ipamAllocations are the addresses allocated by the topology IPAM
+	ipamAllocations *clabtypes.IPAMAllocations
+	// topologyOverrides patch the topology fields after the topology file is loaded
+	topologyOverrides []*clabtypes.TopologyOverride
+	// profiler records the deployment timeline, it is only set during deploy
+	profiler *deployProfiler
+	// nodeOutcomes tracks the deployed and failed nodes to resume a failed deployment
+	nodeOutcomes nodeOutcomes
+	// transaction tracks the resources created by an atomic deployment, it is nil otherwise
+	transaction *deployTransaction
+	// renderedTopology is the rendered topology definition recorded in the state history
+	renderedTopology []byte
+	// topologyRevision is the state history revision the lab topology is rolled back to
+	topologyRevision int
+	// waitLabLock and forceUnlockLab define how the lab lock held by another process is handled
+	waitLabLock    bool
+	forceUnlockLab bool
+	// eventBus publishes the lifecycle events of the lab operations
+	eventBus *clabcorebus.Publisher
 }
 
 // NewContainerLab function defines a new container lab.
@@ -83,6 +103,7 @@ func NewContainerLab(opts ...ClabOption) (*CLab, error) {
 		Cert:              &clabcert.Cert{},
 		checkBindsPaths:   true,
 		dependencyManager: clabcoredependency_manager.NewDependencyManager(),
+		eventBus:          clabcorebus.NewPublisher(clabcorebus.DefaultDir),
 	}
 
 	c.Reg = clabnodes.NewNodeRegistry()
@@ -328,6 +349,13 @@ func (c *CLab) createWaitForDependency() error {
 				if err != nil {
 					return err
 				}
+
+				if dependee.Probe != nil {
+					err = dependerNode.AddProbe(dependerStage, dependeeNode, dependee.Probe)
+					if err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -347,6 +375,7 @@ func (c *CLab) scheduleNodeWorkerF( //nolint: funlen
 ) {
 	defer wg.Done()
 
+nodes:
 	for {
 		select {
 		case node, ok := <-input:
@@ -355,71 +384,118 @@ func (c *CLab) scheduleNodeWorkerF( //nolint: funlen
 				return
 			}
 
+			// the worker continues with the next node after a node failure wrapping
+			// ErrNodeFailed, any other failure aborts the scheduling and stops the worker
+			if ctx.Err() != nil {
+				return
+			}
+
 			log.Debugf("Worker %d received node: %+v", i, node.Config())
 
+			name := node.GetShortName()
+			c.profiler.stop(name, profilePhaseQueued)
+
 			delay := node.Config().StartupDelay
 			if delay > 0 {
 				log.Infof("node %q is being delayed for %d seconds", node.Config().ShortName, delay)
+				c.profiler.start(name, profilePhaseStartupDelay)
 				select {
 				case <-ctx.Done():
 					return
 				case <-time.After(time.Duration(delay) * time.Second):
 				}
+				c.profiler.stop(name, profilePhaseStartupDelay)
 			}
 
+			c.publishNodeStage(node, clabtypes.WaitForCreate)
+			c.profiler.start(name, string(clabtypes.WaitForCreate))
 			if err := c.deployNode(ctx, node); err != nil {
-				log.Error(err)
-				nodeFailCh <- err
-				cancelSchedule()
-				return
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
 			}
 
-			node.Done(ctx, clabtypes.WaitForCreate)
+			c.profiler.stop(name, string(clabtypes.WaitForCreate))
+			if err := node.Done(ctx, clabtypes.WaitForCreate); err != nil {
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
+			}
 
-			node.EnterStage(ctx, clabtypes.WaitForCreateLinks)
+			stopProfile := c.profiler.begin(name, waitPhase(clabtypes.WaitForCreateLinks))
+			if err := node.EnterStage(ctx, clabtypes.WaitForCreateLinks); err != nil {
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
+			}
 			if ctx.Err() != nil {
 				return
 			}
+			stopProfile()
+			c.publishNodeStage(node, clabtypes.WaitForCreateLinks)
 
 			// Deploy the Nodes link endpoints
+			c.profiler.start(name, string(clabtypes.WaitForCreateLinks))
+			c.transaction.recordNodeLinks(node)
 			err := node.DeployEndpoints(ctx)
 			if err != nil {
 				err = fmt.Errorf("node %q deploy links: %w", node.Config().ShortName, err)
-				log.Error(err)
-				nodeFailCh <- err
-				cancelSchedule()
-				return
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
 			}
 
-			node.Done(ctx, clabtypes.WaitForCreateLinks)
-			node.EnterStage(ctx, clabtypes.WaitForConfigure)
+			c.profiler.stop(name, string(clabtypes.WaitForCreateLinks))
+			c.publishNodeLinks(node)
+			if err := node.Done(ctx, clabtypes.WaitForCreateLinks); err != nil {
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
+			}
+
+			stopProfile = c.profiler.begin(name, waitPhase(clabtypes.WaitForConfigure))
+			if err := node.EnterStage(ctx, clabtypes.WaitForConfigure); err != nil {
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
+			}
 			if ctx.Err() != nil {
 				return
 			}
+			stopProfile()
+			c.publishNodeStage(node, clabtypes.WaitForConfigure)
 
+			c.profiler.start(name, string(clabtypes.WaitForConfigure))
 			if !skipPostDeploy {
 				err = node.PostDeploy(ctx, &clabnodes.PostDeployParams{Nodes: c.Nodes})
 				if err != nil {
 					err = fmt.Errorf("node %q post-deploy: %w", node.Config().ShortName, err)
-					log.Error(err)
-					nodeFailCh <- err
-					cancelSchedule()
-					return
+					c.failNode(node, err, nodeFailCh, cancelSchedule)
+					continue nodes
 				}
 			}
 
-			node.Done(ctx, clabtypes.WaitForConfigure)
+			c.profiler.stop(name, string(clabtypes.WaitForConfigure))
+			if err := node.Done(ctx, clabtypes.WaitForConfigure); err != nil {
+				c.failNode(node, err, nodeFailCh, cancelSchedule)
+				continue nodes
+			}
 
+			stopProfile = c.profiler.begin(name, profilePhaseExec)
 			err = node.RunExecFromConfig(ctx, execCollection)
 			if err != nil {
 				log.Errorf("failed to run exec commands for %s: %v", node.GetShortName(), err)
 			}
+			c.publishExecResults(node, execCollection.Results(name))
+			stopProfile()
 
 			if node.MustWait(clabtypes.WaitForHealthy) {
-				node.EnterStage(ctx, clabtypes.WaitForHealthy)
+				stopProfile = c.profiler.begin(name, waitPhase(clabtypes.WaitForHealthy))
+				if err := node.EnterStage(ctx, clabtypes.WaitForHealthy); err != nil {
+					c.failNode(node, err, nodeFailCh, cancelSchedule)
+					continue nodes
+				}
 				if ctx.Err() != nil {
 					return
 				}
+				stopProfile()
+				c.publishNodeStage(node, clabtypes.WaitForHealthy)
+
+				c.profiler.start(name, string(clabtypes.WaitForHealthy))
 				// if there is a dependecy on the healthy state of this node, enter the
 				// checking procedure
 				for {
@@ -435,7 +511,11 @@ func (c *CLab) scheduleNodeWorkerF( //nolint: funlen
 
 					if healthy {
 						log.Infof("node %q turned healthy, continuing", node.GetShortName())
-						node.Done(ctx, clabtypes.WaitForHealthy)
+						c.profiler.stop(name, string(clabtypes.WaitForHealthy))
+						if err := node.Done(ctx, clabtypes.WaitForHealthy); err != nil {
+							c.failNode(node, err, nodeFailCh, cancelSchedule)
+							continue nodes
+						}
 
 						break
 					}
@@ -449,17 +529,29 @@ func (c *CLab) scheduleNodeWorkerF( //nolint: funlen
 			}
 
 			if node.MustWait(clabtypes.WaitForExit) {
-				node.EnterStage(ctx, clabtypes.WaitForExit)
+				stopProfile = c.profiler.begin(name, waitPhase(clabtypes.WaitForExit))
+				if err := node.EnterStage(ctx, clabtypes.WaitForExit); err != nil {
+					c.failNode(node, err, nodeFailCh, cancelSchedule)
+					continue nodes
+				}
 				if ctx.Err() != nil {
 					return
 				}
+				stopProfile()
+				c.publishNodeStage(node, clabtypes.WaitForExit)
+
+				c.profiler.start(name, string(clabtypes.WaitForExit))
 				// if there is a dependency on the healthy state of this node, enter the
 				// checking procedure
 				for {
 					status := node.GetContainerStatus(ctx)
 					if status == clabruntime.Stopped {
 						log.Infof("node %q stopped", node.GetShortName())
-						node.Done(ctx, clabtypes.WaitForExit)
+						c.profiler.stop(name, string(clabtypes.WaitForExit))
+						if err := node.Done(ctx, clabtypes.WaitForExit); err != nil {
+							c.failNode(node, err, nodeFailCh, cancelSchedule)
+							continue nodes
+						}
 
 						break
 					}
@@ -472,12 +564,38 @@ func (c *CLab) scheduleNodeWorkerF( //nolint: funlen
 				}
 			}
 
+			c.recordNodeDeployed(name)
+
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// failNode reports and records the node failure and closes the open profile spans of the node
+// as failed. A failure wrapping ErrNodeFailed only fails the node and the nodes depending on it,
+// any other failure aborts the scheduling of all nodes.
+func (c *CLab) failNode(
+	node *clabcoredependency_manager.DependencyNode,
+	err error,
+	nodeFailCh chan<- error,
+	cancelSchedule context.CancelFunc,
+) {
+	log.Error(err)
+	c.profiler.fail(node.GetShortName())
+	c.recordNodeFailure(node.GetShortName(), err)
+	c.publishNodeEvent(node, clabcorebus.ActionNodeFail, map[string]string{"error": err.Error()})
+	nodeFailCh <- err
+
+	if errors.Is(err, clabcoredependency_manager.ErrNodeFailed) {
+		node.Fail()
+
+		return
+	}
+
+	cancelSchedule()
+}
+
 // skipcq: GO-R1005
 func (c *CLab) scheduleNodes(
 	ctx context.Context,
@@ -539,28 +657,35 @@ func (c *CLab) scheduleNodes(
 				// the workerChan is being closed
 				defer wfcwg.Done()
 
-				node.EnterStage(scheduleCtx, clabtypes.WaitForCreate)
+				stopProfile := c.profiler.begin(node.GetShortName(), waitPhase(clabtypes.WaitForCreate))
+				err := node.EnterStage(scheduleCtx, clabtypes.WaitForCreate)
+				stopProfile()
 				// if the deploy was cancelled while waiting for the create stage, stop
 				// here instead of enqueueing a node whose dependencies were never met
 				// (and whose worker may already have exited).
 				if scheduleCtx.Err() != nil {
 					return
 				}
+				if err != nil {
+					c.failNode(node, err, nodeFailCh, cancelSchedule)
+					return
+				}
 
 				// wait for possible external dependencies
-				err := c.waitForExternalNodeDependencies(scheduleCtx, node.Config().ShortName)
+				err = c.waitForExternalNodeDependencies(scheduleCtx, node.Config().ShortName)
 				if err != nil {
 					if scheduleCtx.Err() != nil {
 						return
 					}
-					log.Error(err)
-					nodeFailCh <- err
-					cancelSchedule()
+					c.failNode(node, err, nodeFailCh, cancelSchedule)
 					return
 				}
 				// when all nodes that this node depends on are created, push it into the
 				// channel; honor cancellation so the send cannot block forever once the
 				// workers have unwound on Ctrl-C.
+				// the queued span is closed by the worker picking up the node.
+				c.profiler.start(node.GetShortName(), profilePhaseQueued)
+
 				select {
 				case workerChan <- node:
 				case <-scheduleCtx.Done():
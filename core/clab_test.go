@@ -447,6 +447,105 @@ func Test_scheduleNodes_WaitForHealthyRunsExecBeforeDepender(t *testing.T) {
 	}
 }
 
+func Test_scheduleNodes_FailNodeKeepsWorker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// the nodes failing with the fail-node policy in their configure stage
+	failingNode := func(name string) *clabmocksmocknodes.MockNode {
+		stages := clabtypes.NewStages()
+		stages.Configure.Execs = clabtypes.Execs{
+			{Command: "false", OnFailure: clabtypes.ExecOnFailureFailNode},
+		}
+		stages.InitDefaults()
+
+		n := clabmocksmocknodes.NewMockNode(mockCtrl)
+		n.EXPECT().Config().Return(&clabtypes.NodeConfig{
+			ShortName: name,
+			Stages:    stages,
+		}).AnyTimes()
+		n.EXPECT().GetShortName().Return(name).AnyTimes()
+		n.EXPECT().PreDeploy(gomock.Any(), gomock.Any()).Return(nil)
+		n.EXPECT().Deploy(gomock.Any(), gomock.Any()).Return(nil)
+		n.EXPECT().UpdateConfigWithRuntimeInfo(gomock.Any()).Return(nil)
+		n.EXPECT().DeployEndpoints(gomock.Any()).Return(nil)
+		n.EXPECT().RunExec(gomock.Any(), gomock.Any()).Return(
+			&clabexec.ExecResult{ReturnCode: 1}, nil,
+		)
+
+		return n
+	}
+
+	failing1 := failingNode("failing1")
+	failing2 := failingNode("failing2")
+
+	independent := clabmocksmocknodes.NewMockNode(mockCtrl)
+	independent.EXPECT().Config().Return(&clabtypes.NodeConfig{
+		ShortName: "independent",
+		Stages:    clabtypes.NewStages(),
+	}).AnyTimes()
+	independent.EXPECT().GetShortName().Return("independent").AnyTimes()
+	independent.EXPECT().PreDeploy(gomock.Any(), gomock.Any()).Return(nil)
+	independent.EXPECT().Deploy(gomock.Any(), gomock.Any()).Return(nil)
+	independent.EXPECT().UpdateConfigWithRuntimeInfo(gomock.Any()).Return(nil)
+	independent.EXPECT().DeployEndpoints(gomock.Any()).Return(nil)
+	independent.EXPECT().PostDeploy(gomock.Any(), gomock.Any()).Return(nil)
+	independent.EXPECT().RunExecFromConfig(gomock.Any(), gomock.Any()).Return(nil)
+
+	dependencyManager := clabcoredependency_manager.NewDependencyManager()
+	dependencyManager.AddNode(failing1)
+	dependencyManager.AddNode(failing2)
+	dependencyManager.AddNode(independent)
+
+	c := &CLab{
+		Config: &Config{},
+		Nodes: map[string]clabnodes.Node{
+			"failing1":    failing1,
+			"failing2":    failing2,
+			"independent": independent,
+		},
+		dependencyManager: dependencyManager,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a single worker processes all nodes whatever order they are scheduled in
+	nodeFailCh := make(chan error, 3)
+	wg, _ := c.scheduleNodes(ctx, 1, false, nodeFailCh)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("workers did not finish")
+	}
+
+	close(nodeFailCh)
+
+	failures := 0
+	for err := range nodeFailCh {
+		if !errors.Is(err, clabcoredependency_manager.ErrNodeFailed) {
+			t.Fatalf("expected the failure to wrap ErrNodeFailed, got %v", err)
+		}
+
+		failures++
+	}
+
+	if failures != 2 {
+		t.Errorf("expected 2 node failures, got %d", failures)
+	}
+
+	if _, ok := c.nodeOutcomes.deployed["independent"]; !ok {
+		t.Error("independent node was not deployed after the fail-node failures")
+	}
+}
+
 // Test_scheduleNodeWorkerF_HealthyWaitHonorsCancel verifies that the
 // WaitForHealthy polling loop in scheduleNodeWorkerF returns promptly when the
 // deploy context is cancelled, instead of spinning on time.Sleep forever (issue
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -56,6 +57,8 @@ type Config struct {
 	Mgmt     *clabtypes.MgmtNet  `json:"mgmt,omitempty"`
 	Settings *clabtypes.Settings `json:"settings,omitempty"`
 	Topology *clabtypes.Topology `json:"topology,omitempty"`
+	// Hooks are the commands run on the host around the lab deploy, destroy and apply
+	Hooks *clabtypes.Hooks `json:"hooks,omitempty"`
 	// the debug flag value as passed via cli
 	// may be used by other packages to enable debug logging
 	Debug bool `json:"debug"`
@@ -82,11 +85,21 @@ func (c *CLab) parseTopology() error {
 		return err
 	}
 
+	if c.Config.Hooks != nil {
+		if err := c.Config.Hooks.Validate(); err != nil {
+			return fmt.Errorf("invalid hooks: %w", err)
+		}
+	}
+
 	if c.Config.Prefix == nil {
 		c.Config.Prefix = new(string)
 		*c.Config.Prefix = defaultPrefix
 	}
 
+	if err := c.allocateAddresses(); err != nil {
+		return err
+	}
+
 	// initialize Nodes and Links variable
 	c.Nodes = make(map[string]clabnodes.Node)
 	c.Links = make(map[int]clablinks.Link)
@@ -423,6 +436,8 @@ func (c *CLab) createNodeCfg( //nolint: funlen
 
 	nodeCfg.Config = c.Config.Topology.GetNodeConfigDispatcher(nodeCfg.ShortName)
 
+	c.processNodeIPAM(nodeCfg)
+
 	c.processNodeExecs(nodeCfg)
 
 	c.processNodeExtras(nodeCfg)
@@ -430,6 +445,50 @@ func (c *CLab) createNodeCfg( //nolint: funlen
 	return nodeCfg, nil
 }
 
+// allocateAddresses allocates the addresses from the topology IPAM pools
+// reusing the allocations saved in the lab state by the previous deployment.
+func (c *CLab) allocateAddresses() error {
+	if c.Config.Topology.IPAM == nil {
+		return nil
+	}
+
+	state, err := c.LoadState()
+	if err != nil {
+		log.Warn("Failed to load previous IPAM allocations, addresses will be re-allocated",
+			"err", err)
+	}
+
+	var prev *clabtypes.IPAMAllocations
+	if state != nil {
+		prev = state.IPAM
+	}
+
+	c.ipamAllocations, err = c.Config.Topology.AllocateAddresses(prev)
+
+	return err
+}
+
+// processNodeIPAM sets the IPAM addresses of the node in its config
+// and exposes them to the node config vars under the `ipam` key.
+func (c *CLab) processNodeIPAM(nodeCfg *clabtypes.NodeConfig) {
+	nodeCfg.IPAM = c.Config.Topology.NodeIPAMAddresses(c.ipamAllocations, nodeCfg.ShortName)
+	if nodeCfg.IPAM == nil {
+		return
+	}
+
+	if nodeCfg.Config == nil {
+		nodeCfg.Config = &clabtypes.ConfigDispatcher{}
+	}
+
+	vars := maps.Clone(nodeCfg.Config.Vars)
+	if vars == nil {
+		vars = map[string]any{}
+	}
+
+	vars["ipam"] = nodeCfg.IPAM.ToVars()
+	nodeCfg.Config.Vars = vars
+}
+
 // processStartupConfig processes the raw path of the startup-config as it is defined in the
 // topology file. It handles remote files, local files and embedded configs.
 // As a result the `nodeCfg.StartupConfig` will be set to an absPath of the startup config file.
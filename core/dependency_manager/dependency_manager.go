@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabtypes "github.com/srl-labs/containerlab/types"
 )
 
 type DependencyManager interface {
@@ -20,6 +21,18 @@ type DependencyManager interface {
 	String() string
 	// GetNodes returns the DependencyNodes registered with the DependencyManager
 	GetNodes() map[string]*DependencyNode
+	// GetDependencies returns the dependencies between the stages of the registered nodes.
+	GetDependencies() []*Dependency
+}
+
+// Dependency is a dependency of a node stage on a stage of another node.
+type Dependency struct {
+	// Dependee is the node that has to reach the DependeeStage
+	Dependee      string                 `json:"dependee"`
+	DependeeStage clabtypes.WaitForStage `json:"dependee-stage"`
+	// Depender is the node that enters the DependerStage only after the Dependee reached its stage
+	Depender      string                 `json:"depender"`
+	DependerStage clabtypes.WaitForStage `json:"depender-stage"`
 }
 
 // defaultDependencyManager is the default implementation of the DependencyManager.
@@ -44,6 +57,45 @@ func (dm *defaultDependencyManager) GetNodes() map[string]*DependencyNode {
 	return dm.nodes
 }
 
+// GetDependencies returns the dependencies between the stages of the registered nodes
+// sorted by the dependee, the depender and their stages.
+func (dm *defaultDependencyManager) GetDependencies() []*Dependency {
+	var deps []*Dependency
+
+	for nodeName, node := range dm.nodes {
+		for stage, dependers := range node.depender {
+			for _, d := range dependers {
+				deps = append(deps, &Dependency{
+					Dependee:      nodeName,
+					DependeeStage: stage,
+					Depender:      d.depender.GetShortName(),
+					DependerStage: d.stage,
+				})
+			}
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		a, b := deps[i], deps[j]
+
+		if a.Dependee != b.Dependee {
+			return a.Dependee < b.Dependee
+		}
+
+		if a.Depender != b.Depender {
+			return a.Depender < b.Depender
+		}
+
+		if a.DependeeStage != b.DependeeStage {
+			return a.DependeeStage < b.DependeeStage
+		}
+
+		return a.DependerStage < b.DependerStage
+	})
+
+	return deps
+}
+
 func (dm *defaultDependencyManager) GetNode(nodeName string) (*DependencyNode, error) {
 	// first check if the referenced node is known to the dm
 	err := dm.checkNodesExist([]string{nodeName})
@@ -6,9 +6,7 @@ import (
 	"sync"
 
 	"github.com/charmbracelet/log"
-	clabexec "github.com/srl-labs/containerlab/exec"
 	clabnodes "github.com/srl-labs/containerlab/nodes"
-	clabnodeshost "github.com/srl-labs/containerlab/nodes/host"
 	clabtypes "github.com/srl-labs/containerlab/types"
 )
 
@@ -22,6 +20,18 @@ type DependencyNode struct {
 	// mustWait determines if dependers exist for the given stages.
 	mustWait map[clabtypes.WaitForStage]bool
 	depender map[clabtypes.WaitForStage][]*dependerNodeStage
+	// probes are the readiness probes that must succeed before the node enters a stage.
+	probes map[clabtypes.WaitForStage][]*stageProbe
+	// dependees are the nodes the given stage of the node depends on.
+	dependees map[clabtypes.WaitForStage][]*DependencyNode
+	// doneStages are the stages the dependers were signalled for.
+	doneStages map[clabtypes.WaitForStage]bool
+	// failed is set when the node failed and will not reach its remaining stages.
+	failed bool
+	// execResults are the results of the stage exec commands run so far.
+	execResults []*StageExecResult
+	// execResultHandler is called with the result of every stage exec command.
+	execResultHandler func(*StageExecResult)
 
 	m sync.Mutex
 }
@@ -33,6 +43,10 @@ func NewDependencyNode(node clabnodes.Node) *DependencyNode {
 		stageWG:  map[clabtypes.WaitForStage]*sync.WaitGroup{},
 		mustWait: map[clabtypes.WaitForStage]bool{},
 		depender: map[clabtypes.WaitForStage][]*dependerNodeStage{},
+		probes:   map[clabtypes.WaitForStage][]*stageProbe{},
+
+		dependees:  map[clabtypes.WaitForStage][]*DependencyNode{},
+		doneStages: map[clabtypes.WaitForStage]bool{},
 	}
 
 	for _, p := range clabtypes.GetWaitForStages() {
@@ -100,10 +114,14 @@ func (d *DependencyNode) getExecs(stage clabtypes.WaitForStage,
 }
 
 // EnterStage is called by a node that is meant to enter the specified stage.
-// The call will be blocked until all dependencies for the node to enter the stage are met,
-// or until the context is cancelled (e.g. on a Ctrl-C), in which case it returns without
-// running the stage execs so the worker can unwind instead of hanging until SIGQUIT.
-func (d *DependencyNode) EnterStage(ctx context.Context, p clabtypes.WaitForStage) {
+// The call will be blocked until all dependencies for the node to enter the stage are met
+// and the readiness probes of the stage succeeded, or until the context is cancelled
+// (e.g. on a Ctrl-C), in which case it returns without running the stage execs so the worker
+// can unwind instead of hanging until SIGQUIT.
+// An error is returned when a readiness probe did not succeed within its timeout,
+// when a node the stage depends on failed or when an on-enter exec failed with
+// the fail-node or fail-lab policy.
+func (d *DependencyNode) EnterStage(ctx context.Context, p clabtypes.WaitForStage) error {
 	log.Debugf("Stage Change: Enter Wait -> %s - %s", d.GetShortName(), p)
 
 	wgDone := make(chan struct{})
@@ -117,74 +135,98 @@ func (d *DependencyNode) EnterStage(ctx context.Context, p clabtypes.WaitForStag
 	case <-ctx.Done():
 		log.Debugf("Stage Change: Enter Cancelled -> %s - %s", d.GetShortName(), p)
 
-		return
+		return nil
 	case <-wgDone:
 	}
 
-	log.Debugf("Stage Change: Enter Go -> %s - %s", d.GetShortName(), p)
-
-	d.runExecs(ctx, clabtypes.CommandExecutionPhaseEnter, p)
-}
-
-func (d *DependencyNode) runExecs(
-	ctx context.Context,
-	execPhase clabtypes.ExecPhase,
-	stage clabtypes.WaitForStage,
-) {
-	execs, err := d.getExecs(stage, execPhase)
-	if err != nil {
-		log.Errorf("error getting exec commands defined for %s: %v", d.GetShortName(), err)
+	if err := d.checkDependees(p); err != nil {
+		return err
 	}
 
-	if len(execs) == 0 {
-		return
-	}
+	if err := d.waitForProbes(ctx, p); err != nil {
+		if ctx.Err() != nil {
+			log.Debugf("Stage Change: Enter Cancelled -> %s - %s", d.GetShortName(), p)
 
-	// exec the commands
-	execResultCollection := clabexec.NewExecCollection()
+			return nil
+		}
 
-	var execResult *clabexec.ExecResult
+		return err
+	}
 
-	var hostname string
+	log.Debugf("Stage Change: Enter Go -> %s - %s", d.GetShortName(), p)
 
-	for _, exec := range execs {
-		execCmd, err := exec.GetExecCmd()
-		if err != nil {
-			log.Errorf(
-				"%s stage %s error parsing command: %s", d.GetShortName(), stage, exec.String(),
-			)
-		}
+	if err := d.runExecs(ctx, clabtypes.CommandExecutionPhaseEnter, p); err != nil &&
+		ctx.Err() == nil {
+		return err
+	}
 
-		switch exec.Target {
-		case clabtypes.CommandTargetContainer:
-			execResult, err = d.RunExec(ctx, execCmd)
-			hostname = d.GetShortName()
-		case clabtypes.CommandTargetHost:
-			execResult, err = clabnodeshost.RunExec(ctx, execCmd)
-			hostname = fmt.Sprintf("host via %s", d.GetShortName())
-		default:
-			continue
-		}
+	return nil
+}
 
-		if err != nil {
-			log.Errorf("error on exec in node %s for stage %s: %v", d.GetShortName(), stage, err)
-		} else {
-			execResultCollection.Add(hostname, execResult)
+// checkDependees returns an error wrapping ErrNodeFailed when a node the stage depends on failed.
+func (d *DependencyNode) checkDependees(p clabtypes.WaitForStage) error {
+	for _, dependee := range d.dependees[p] {
+		if dependee.Failed() {
+			return fmt.Errorf("%w: %q can not enter the %s stage, it depends on the failed node %q",
+				ErrNodeFailed, d.GetShortName(), p, dependee.GetShortName())
 		}
 	}
 
-	execResultCollection.Log()
+	return nil
 }
 
 // Done is called by a node that has finished all tasks for the provided stage.
 // The dependent nodes will be "notified" that an additional (if multiple exist) dependency is
 // satisfied.
-func (d *DependencyNode) Done(ctx context.Context, p clabtypes.WaitForStage) {
+// The dependers are not notified when an on-exit exec failed with the fail-node or fail-lab
+// policy, the error is returned instead.
+func (d *DependencyNode) Done(ctx context.Context, p clabtypes.WaitForStage) error {
 	// iterate through all the dependers, that wait for the specific stage
 	// and reduce the waitgroup
 	log.Debugf("StateChange: Done -> %s - %s", d.GetShortName(), p)
 
-	d.runExecs(ctx, clabtypes.CommandExecutionPhaseExit, p)
+	if err := d.runExecs(ctx, clabtypes.CommandExecutionPhaseExit, p); err != nil &&
+		ctx.Err() == nil {
+		return err
+	}
+
+	d.signalDependers(p)
+
+	return nil
+}
+
+// Fail marks the node as failed and unblocks the dependers of the stages the node
+// has not reached yet. The dependers then fail on entering their stage.
+func (d *DependencyNode) Fail() {
+	log.Debugf("StateChange: Failed -> %s", d.GetShortName())
+
+	d.m.Lock()
+	d.failed = true
+	d.m.Unlock()
+
+	for _, p := range clabtypes.GetWaitForStages() {
+		d.signalDependers(p)
+	}
+}
+
+// Failed returns true if the node failed.
+func (d *DependencyNode) Failed() bool {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	return d.failed
+}
+
+// signalDependers unblocks the dependers waiting for the stage, at most once per stage.
+func (d *DependencyNode) signalDependers(p clabtypes.WaitForStage) {
+	d.m.Lock()
+	done := d.doneStages[p]
+	d.doneStages[p] = true
+	d.m.Unlock()
+
+	if done {
+		return
+	}
 
 	for _, depender := range d.depender[p] {
 		log.Debugf("StateChange: Node %s unblocking %s", d.GetShortName(), depender.String())
@@ -203,6 +245,7 @@ func (d *DependencyNode) AddDepender(dependerStage clabtypes.WaitForStage,
 	// adding the dependerNodeState entity to the specific state of d
 	// this will result in a .Done() call when d finishes state
 	d.depender[stage] = append(d.depender[stage], dependerNS)
+	depender.dependees[dependerStage] = append(depender.dependees[dependerStage], d)
 	// for the depender to know how many dependencies to wait for,
 	// the waitgroup need to be increaded by 1 as well
 	dependerNS.IncreaseDependencyWG()
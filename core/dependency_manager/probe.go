@@ -0,0 +1,279 @@
+package dependency_manager
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabexec "github.com/srl-labs/containerlab/exec"
+	clabnetconf "github.com/srl-labs/containerlab/netconf"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"golang.org/x/crypto/ssh"
+)
+
+// stageProbe is a readiness probe checked on the target node before the node enters a stage.
+type stageProbe struct {
+	target clabnodes.Node
+	probe  *clabtypes.ReadinessProbe
+}
+
+// AddProbe adds a readiness probe that must succeed on the target node
+// before the node enters the stage.
+func (d *DependencyNode) AddProbe(
+	stage clabtypes.WaitForStage,
+	target clabnodes.Node,
+	probe *clabtypes.ReadinessProbe,
+) error {
+	if err := probe.Validate(); err != nil {
+		return fmt.Errorf("node %q %s stage probe of %q: %w",
+			d.GetShortName(), stage, target.GetShortName(), err)
+	}
+
+	d.probes[stage] = append(d.probes[stage], &stageProbe{target: target, probe: probe})
+
+	return nil
+}
+
+// waitForProbes runs the readiness probes of the stage concurrently
+// and waits for all of them to succeed.
+func (d *DependencyNode) waitForProbes(ctx context.Context, stage clabtypes.WaitForStage) error {
+	probes := d.probes[stage]
+	if len(probes) == 0 {
+		return nil
+	}
+
+	errCh := make(chan error, len(probes))
+
+	for _, sp := range probes {
+		go func() {
+			errCh <- sp.wait(ctx)
+		}()
+	}
+
+	var errs []error
+
+	for range probes {
+		if err := <-errCh; err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("node %q can not enter the %s stage: %w", d.GetShortName(), stage, err)
+	}
+
+	return nil
+}
+
+// wait runs the probe at its interval until it succeeds or times out.
+func (sp *stageProbe) wait(ctx context.Context) error {
+	name := sp.target.GetShortName()
+	probeType := sp.probe.Type()
+
+	ctx, cancel := context.WithTimeout(ctx, sp.probe.GetTimeoutDuration())
+	defer cancel()
+
+	log.Info("Waiting for probe", "node", name, "probe", probeType)
+
+	for {
+		err := sp.run(ctx)
+		if err == nil {
+			log.Info("Probe succeeded", "node", name, "probe", probeType)
+
+			return nil
+		}
+
+		log.Debug("Probe failed", "node", name, "probe", probeType, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s probe of node %q did not succeed within %s: %w",
+				probeType, name, sp.probe.GetTimeoutDuration(), err)
+		case <-time.After(sp.probe.GetIntervalDuration()):
+		}
+	}
+}
+
+// run runs a single probe attempt.
+func (sp *stageProbe) run(ctx context.Context) error {
+	p := sp.probe
+	cfg := sp.target.Config()
+
+	switch {
+	case p.TCP != nil:
+		return probeTCP(ctx, mgmtAddress(cfg), p.TCP.Port)
+
+	case p.HTTP != nil:
+		return probeHTTP(ctx, p.HTTP.URL(mgmtAddress(cfg)), p.HTTP.GetStatus())
+
+	case p.SSH != nil:
+		return probeSSH(ctx, net.JoinHostPort(mgmtAddress(cfg), strconv.Itoa(p.SSH.GetPort())),
+			&cfg.Credentials)
+
+	case p.NETCONF != nil:
+		return clabnetconf.Hello(ctx, mgmtAddress(cfg), cfg.Credentials.Username,
+			cfg.Credentials.Password, p.NETCONF.GetPort())
+
+	case p.Log != nil:
+		return sp.probeLog(ctx)
+
+	case p.Exec != nil:
+		return sp.probeExec(ctx)
+	}
+
+	return fmt.Errorf("probe type is missing")
+}
+
+// mgmtAddress returns the management address of the node, the IPv4 one is preferred.
+func mgmtAddress(cfg *clabtypes.NodeConfig) string {
+	if cfg.MgmtIPv4Address != "" {
+		return cfg.MgmtIPv4Address
+	}
+
+	if cfg.MgmtIPv6Address != "" {
+		return cfg.MgmtIPv6Address
+	}
+
+	return cfg.LongName
+}
+
+func probeTCP(ctx context.Context, addr string, port int) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addr, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func probeHTTP(ctx context.Context, url string, status int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			// lab nodes use self-signed certificates
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint: gosec
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != status {
+		return fmt.Errorf("%s returned status %d, expected %d", url, resp.StatusCode, status)
+	}
+
+	return nil
+}
+
+// probeSSH logs in to the node with the password and, when set, the identity file of the
+// node credentials. The connection is bound to the context, so that the ssh handshake
+// stalled by a booting node does not outlive the probe timeout.
+func probeSSH(ctx context.Context, addr string, creds *clabtypes.NodeCredentials) error {
+	auth := []ssh.AuthMethod{ssh.Password(creds.Password)}
+
+	if creds.IdentityFile != "" {
+		key, err := os.ReadFile(creds.IdentityFile)
+		if err != nil {
+			return err
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return fmt.Errorf("parsing identity file %q: %w", creds.IdentityFile, err)
+		}
+
+		auth = append([]ssh.AuthMethod{ssh.PublicKeys(signer)}, auth...)
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	// the connection is closed when the probe is cancelled before its deadline
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User:            creds.Username,
+		Auth:            auth,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint: gosec
+	})
+	if err != nil {
+		return err
+	}
+
+	return ssh.NewClient(c, chans, reqs).Close()
+}
+
+// probeLog follows the node's log from the container start until a line matches the pattern,
+// so that the lines logged before the probe started are matched as well.
+func (sp *stageProbe) probeLog(ctx context.Context) error {
+	re := regexp.MustCompile(sp.probe.Log.Pattern)
+
+	logs, err := sp.target.GetRuntime().StreamAllLogs(ctx, sp.target.Config().LongName)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		if re.Match(scanner.Bytes()) {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("no log line matched %q", sp.probe.Log.Pattern)
+}
+
+func (sp *stageProbe) probeExec(ctx context.Context) error {
+	execCmd, err := clabexec.NewExecCmdFromString(sp.probe.Exec.Command)
+	if err != nil {
+		return err
+	}
+
+	res, err := sp.target.RunExec(ctx, execCmd)
+	if err != nil {
+		return err
+	}
+
+	if res.ReturnCode != sp.probe.Exec.ExitCode {
+		return fmt.Errorf("command %q exited with code %d, expected %d",
+			sp.probe.Exec.Command, res.ReturnCode, sp.probe.Exec.ExitCode)
+	}
+
+	return nil
+}
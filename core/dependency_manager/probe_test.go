@@ -0,0 +1,160 @@
+package dependency_manager
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	clabexec "github.com/srl-labs/containerlab/exec"
+	clabmocksmocknodes "github.com/srl-labs/containerlab/mocks/mocknodes"
+	clabmocksmockruntime "github.com/srl-labs/containerlab/mocks/mockruntime"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestNode returns a dependency node backed by a mock node running the execs
+// in its configure stage.
+func newTestNode(
+	ctrl *gomock.Controller,
+	name string,
+	configureExecs clabtypes.Execs,
+) (*DependencyNode, *clabmocksmocknodes.MockNode) {
+	stages := clabtypes.NewStages()
+	stages.Configure.Execs = configureExecs
+	stages.InitDefaults()
+
+	mn := clabmocksmocknodes.NewMockNode(ctrl)
+	mn.EXPECT().Config().Return(&clabtypes.NodeConfig{
+		ShortName:       name,
+		LongName:        "clab-test-" + name,
+		MgmtIPv4Address: "127.0.0.1",
+		Stages:          stages,
+	}).AnyTimes()
+	mn.EXPECT().GetShortName().Return(name).AnyTimes()
+
+	return NewDependencyNode(mn), mn
+}
+
+func TestEnterStageProbes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	port := l.Addr().(*net.TCPAddr).Port
+
+	waiter, _ := newTestNode(ctrl, "waiter", nil)
+	dependee, dependeeMock := newTestNode(ctrl, "dependee", nil)
+
+	dependeeMock.EXPECT().RunExec(gomock.Any(), gomock.Any()).Return(
+		&clabexec.ExecResult{ReturnCode: 1}, nil,
+	).AnyTimes()
+
+	if err := waiter.AddProbe(clabtypes.WaitForCreate, dependee, &clabtypes.ReadinessProbe{
+		TCP: &clabtypes.TCPProbe{Port: port},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := waiter.EnterStage(context.Background(), clabtypes.WaitForCreate); err != nil {
+		t.Errorf("tcp probe of an open port failed: %v", err)
+	}
+
+	if err := waiter.AddProbe(clabtypes.WaitForConfigure, dependee, &clabtypes.ReadinessProbe{
+		Exec:    &clabtypes.ExecProbe{Command: "vtysh -c 'show bgp summary'"},
+		Timeout: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = waiter.EnterStage(context.Background(), clabtypes.WaitForConfigure)
+	if err == nil || !strings.Contains(err.Error(), "exited with code 1, expected 0") {
+		t.Errorf("expected the exec probe to fail with the exit code mismatch, got %v", err)
+	}
+
+	// probes are validated when added
+	err = waiter.AddProbe(clabtypes.WaitForHealthy, dependee, &clabtypes.ReadinessProbe{
+		TCP:  &clabtypes.TCPProbe{Port: port},
+		Exec: &clabtypes.ExecProbe{Command: "true"},
+	})
+	if err == nil {
+		t.Errorf("expected an error for a probe with multiple types")
+	}
+}
+
+func TestLogProbeMatchesLinesLoggedBeforeProbe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	waiter, _ := newTestNode(ctrl, "waiter", nil)
+	dependee, dependeeMock := newTestNode(ctrl, "dependee", nil)
+
+	// the dependee logged the line before the waiter entered the stage
+	rt := clabmocksmockruntime.NewMockContainerRuntime(ctrl)
+	rt.EXPECT().StreamAllLogs(gomock.Any(), "clab-test-dependee").Return(
+		io.NopCloser(strings.NewReader("starting bgp\nBGP established\n")), nil,
+	)
+	dependeeMock.EXPECT().GetRuntime().Return(rt).AnyTimes()
+
+	if err := waiter.AddProbe(clabtypes.WaitForConfigure, dependee, &clabtypes.ReadinessProbe{
+		Log:     &clabtypes.LogProbe{Pattern: "BGP established"},
+		Timeout: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := waiter.EnterStage(context.Background(), clabtypes.WaitForConfigure); err != nil {
+		t.Errorf("log probe of a line logged before the probe failed: %v", err)
+	}
+}
+
+func TestSSHProbeTimesOutOnStalledHandshake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// the server accepts the connections but never starts the ssh handshake
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	waiter, _ := newTestNode(ctrl, "waiter", nil)
+	dependee, _ := newTestNode(ctrl, "dependee", nil)
+
+	if err := waiter.AddProbe(clabtypes.WaitForConfigure, dependee, &clabtypes.ReadinessProbe{
+		SSH:     &clabtypes.SSHProbe{Port: l.Addr().(*net.TCPAddr).Port},
+		Timeout: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- waiter.EnterStage(context.Background(), clabtypes.WaitForConfigure)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == nil || !strings.Contains(err.Error(), "did not succeed within") {
+			t.Errorf("expected the ssh probe to time out, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ssh probe blocked on the stalled handshake")
+	}
+}
@@ -0,0 +1,238 @@
+package dependency_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabexec "github.com/srl-labs/containerlab/exec"
+	clabnodeshost "github.com/srl-labs/containerlab/nodes/host"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+// ErrNodeFailed is wrapped by the errors that fail a single node and the nodes depending on it,
+// while the rest of the lab continues to be deployed.
+var ErrNodeFailed = errors.New("node failed")
+
+// StageExecResult is the result of a stage exec command.
+type StageExecResult struct {
+	Node    string                 `json:"node"`
+	Stage   clabtypes.WaitForStage `json:"stage"`
+	Phase   clabtypes.ExecPhase    `json:"phase"`
+	Target  clabtypes.ExecTarget   `json:"target"`
+	Command string                 `json:"command"`
+	// Attempts is the number of times the command was run
+	Attempts int `json:"attempts"`
+	// ExitCode is the exit code of the last attempt, -1 when the command did not complete
+	ExitCode        int                     `json:"exit-code"`
+	DurationSeconds float64                 `json:"duration-seconds"`
+	OnFailure       clabtypes.ExecOnFailure `json:"on-failure,omitempty"`
+	// Error is set when the last attempt failed
+	Error string `json:"error,omitempty"`
+}
+
+// ExecResults returns the results of the stage exec commands run by the node.
+func (d *DependencyNode) ExecResults() []*StageExecResult {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	return append([]*StageExecResult(nil), d.execResults...)
+}
+
+// OnExecResult sets the function called with the result of every stage exec command
+// once the command completes.
+func (d *DependencyNode) OnExecResult(f func(*StageExecResult)) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	d.execResultHandler = f
+}
+
+// runExecs runs the exec commands of the stage phase in order.
+// A failed command stops the remaining commands of the phase and returns an error when its
+// on-failure policy is fail-node or fail-lab. The fail-node error wraps ErrNodeFailed.
+func (d *DependencyNode) runExecs(
+	ctx context.Context,
+	execPhase clabtypes.ExecPhase,
+	stage clabtypes.WaitForStage,
+) error {
+	execs, err := d.getExecs(stage, execPhase)
+	if err != nil {
+		log.Errorf("error getting exec commands defined for %s: %v", d.GetShortName(), err)
+	}
+
+	if len(execs) == 0 {
+		return nil
+	}
+
+	// exec the commands
+	execResultCollection := clabexec.NewExecCollection()
+	defer execResultCollection.Log()
+
+	for _, exec := range execs {
+		var hostname string
+
+		switch exec.Target {
+		case clabtypes.CommandTargetContainer:
+			hostname = d.GetShortName()
+		case clabtypes.CommandTargetHost:
+			hostname = fmt.Sprintf("host via %s", d.GetShortName())
+		default:
+			continue
+		}
+
+		execResult, res := d.runStageExec(ctx, stage, exec)
+
+		d.m.Lock()
+		d.execResults = append(d.execResults, res)
+		handler := d.execResultHandler
+		d.m.Unlock()
+
+		if handler != nil {
+			handler(res)
+		}
+
+		if execResult != nil {
+			execResultCollection.Add(hostname, execResult)
+		}
+
+		duration := time.Duration(res.DurationSeconds * float64(time.Second))
+
+		logFields := []any{
+			"node", d.GetShortName(), "stage", stage, "phase", exec.Phase,
+			"command", exec.Command, "attempts", res.Attempts, "exit-code", res.ExitCode,
+			"duration", duration.Round(time.Millisecond),
+		}
+
+		if res.Error == "" {
+			log.Debug("Stage exec succeeded", logFields...)
+
+			continue
+		}
+
+		log.Error("Stage exec failed", append(logFields, "err", res.Error)...)
+
+		failErr := fmt.Errorf("%s stage exec %q of node %q failed after %d attempt(s): %s",
+			stage, exec.Command, d.GetShortName(), res.Attempts, res.Error)
+
+		switch exec.OnFailure {
+		case clabtypes.ExecOnFailureFailNode:
+			return fmt.Errorf("%w: %w", ErrNodeFailed, failErr)
+		case clabtypes.ExecOnFailureFailLab:
+			return failErr
+		}
+	}
+
+	return nil
+}
+
+// runStageExec runs the exec command until it produces the expected result
+// or its retries are exhausted.
+func (d *DependencyNode) runStageExec(
+	ctx context.Context,
+	stage clabtypes.WaitForStage,
+	exec *clabtypes.Exec,
+) (*clabexec.ExecResult, *StageExecResult) {
+	res := &StageExecResult{
+		Node:      d.GetShortName(),
+		Stage:     stage,
+		Phase:     exec.Phase,
+		Target:    exec.Target,
+		Command:   exec.Command,
+		ExitCode:  -1,
+		OnFailure: exec.OnFailure,
+	}
+
+	execCmd, err := exec.GetExecCmd()
+	if err != nil {
+		res.Error = fmt.Sprintf("error parsing command: %v", err)
+
+		return nil, res
+	}
+
+	start := time.Now()
+
+	var execResult *clabexec.ExecResult
+
+attempts:
+	for {
+		res.Attempts++
+
+		execResult, err = d.runStageExecAttempt(ctx, exec, execCmd)
+		if err == nil {
+			res.ExitCode = execResult.GetReturnCode()
+			err = exec.CheckResult(execResult)
+		}
+
+		if err == nil || res.Attempts > exec.Retries {
+			break
+		}
+
+		log.Warn("Stage exec failed, retrying", "node", d.GetShortName(), "stage", stage,
+			"command", exec.Command, "attempt", res.Attempts, "err", err)
+
+		select {
+		case <-ctx.Done():
+			break attempts
+		case <-time.After(exec.GetRetryIntervalDuration()):
+		}
+	}
+
+	res.DurationSeconds = time.Since(start).Seconds()
+
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	return execResult, res
+}
+
+// runStageExecAttempt runs the exec command once, bounded by the exec timeout.
+func (d *DependencyNode) runStageExecAttempt(
+	ctx context.Context,
+	exec *clabtypes.Exec,
+	execCmd *clabexec.ExecCmd,
+) (*clabexec.ExecResult, error) {
+	timeout := exec.GetTimeoutDuration()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		execResult *clabexec.ExecResult
+		err        error
+	}
+
+	// the runtime exec is not guaranteed to return on the context cancellation,
+	// so the attempt is abandoned when the context is done
+	resCh := make(chan result, 1)
+
+	go func() {
+		var r result
+
+		switch exec.Target {
+		case clabtypes.CommandTargetHost:
+			r.execResult, r.err = clabnodeshost.RunExec(ctx, execCmd)
+		default:
+			r.execResult, r.err = d.RunExec(ctx, execCmd)
+		}
+
+		resCh <- r
+	}()
+
+	select {
+	case r := <-resCh:
+		return r.execResult, r.err
+	case <-ctx.Done():
+		if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package dependency_manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	clabexec "github.com/srl-labs/containerlab/exec"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStageExecRetryAndTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	n, mn := newTestNode(ctrl, "n1", clabtypes.Execs{
+		{
+			Command: "show bgp",
+			Retries: 1,
+			Timeout: 1,
+			Expect:  &clabtypes.ExecExpect{Output: "Established"},
+		},
+	})
+
+	gomock.InOrder(
+		// the first attempt hangs and times out
+		mn.EXPECT().RunExec(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ *clabexec.ExecCmd) (*clabexec.ExecResult, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			}),
+		mn.EXPECT().RunExec(gomock.Any(), gomock.Any()).Return(
+			&clabexec.ExecResult{Stdout: "Established"}, nil,
+		),
+	)
+
+	if err := n.EnterStage(context.Background(), clabtypes.WaitForConfigure); err != nil {
+		t.Fatalf("expected the exec to succeed on retry, got %v", err)
+	}
+
+	results := n.ExecResults()
+	if len(results) != 1 {
+		t.Fatalf("expected 1 exec result, got %d", len(results))
+	}
+
+	if r := results[0]; r.Attempts != 2 || r.ExitCode != 0 || r.Error != "" {
+		t.Errorf("unexpected exec result %+v", r)
+	}
+}
+
+func TestStageExecFailNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	n1, mn1 := newTestNode(ctrl, "n1", clabtypes.Execs{
+		{Command: "false", OnFailure: clabtypes.ExecOnFailureFailNode},
+		// not run, as the previous command failed the node
+		{Command: "true"},
+	})
+	n2, _ := newTestNode(ctrl, "n2", nil)
+
+	mn1.EXPECT().RunExec(gomock.Any(), gomock.Any()).Return(
+		&clabexec.ExecResult{ReturnCode: 1}, nil,
+	).Times(1)
+
+	if err := n1.AddDepender(clabtypes.WaitForCreate, n2, clabtypes.WaitForConfigure); err != nil {
+		t.Fatal(err)
+	}
+
+	err := n1.EnterStage(context.Background(), clabtypes.WaitForConfigure)
+	if !errors.Is(err, ErrNodeFailed) {
+		t.Fatalf("expected the error to wrap ErrNodeFailed, got %v", err)
+	}
+
+	n1.Fail()
+
+	// the depender is unblocked by the failed node and fails as well
+	err = n2.EnterStage(context.Background(), clabtypes.WaitForCreate)
+	if !errors.Is(err, ErrNodeFailed) {
+		t.Errorf("expected the depender to fail with ErrNodeFailed, got %v", err)
+	}
+
+	if r := n1.ExecResults(); len(r) != 1 || r[0].ExitCode != 1 || r[0].Error == "" {
+		t.Errorf("unexpected exec results %+v", r)
+	}
+}
@@ -2,10 +2,12 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,10 +15,13 @@ import (
 	"github.com/charmbracelet/log"
 	clabcert "github.com/srl-labs/containerlab/cert"
 	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
+	clabcoredependency_manager "github.com/srl-labs/containerlab/core/dependency_manager"
 	clabexec "github.com/srl-labs/containerlab/exec"
 	clablinks "github.com/srl-labs/containerlab/links"
 	clabnodes "github.com/srl-labs/containerlab/nodes"
 	clabruntime "github.com/srl-labs/containerlab/runtime"
+	clabtypes "github.com/srl-labs/containerlab/types"
 	clabutils "github.com/srl-labs/containerlab/utils"
 	"golang.org/x/sync/errgroup"
 )
@@ -31,19 +36,69 @@ type DeployResult struct {
 
 // Deploy converges the lab to the requested topology. A lab without runtime state is
 // deployed from scratch, an already deployed lab is reconciled in place.
+// The pre-deploy and post-deploy lab hooks run around the deployment, unless it is a dry-run.
+// An atomic deployment is rolled back when it fails, times out or is canceled.
+// The start and the outcome of the deployment are published on the event bus.
 func (c *CLab) Deploy(
 	ctx context.Context,
 	options *DeployOptions,
-) (*DeployResult, error) {
+) (result *DeployResult, err error) {
 	if options == nil {
-		var err error
 		options, err = NewDeployOptions(0)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if options.dryRun {
+		return c.converge(ctx, options)
+	}
+
+	unlock, err := c.lockLab(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	c.publishEvent(clabcorebus.ActionDeployStart, nil)
+
+	defer func() {
+		c.publishOutcome(clabcorebus.ActionDeployDone, clabcorebus.ActionDeployFail, err)
+	}()
+
+	if err := c.runHooks(ctx, clabtypes.HookPreDeploy); err != nil {
+		return nil, err
+	}
+
+	if options.atomic {
+		return c.deployAtomic(ctx, options)
+	}
+
+	result, err = c.converge(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.runHooks(ctx, clabtypes.HookPostDeploy); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// converge deploys the lab from scratch or reconciles the already deployed lab.
+func (c *CLab) converge(
+	ctx context.Context,
+	options *DeployOptions,
+) (*DeployResult, error) {
 	if options.reconfigure {
+		if options.resume {
+			return nil, fmt.Errorf(
+				"resume cannot be combined with reconfigure: " +
+					"reconfigure always destroys and redeploys the full lab",
+			)
+		}
+
 		if options.dryRun {
 			return nil, fmt.Errorf(
 				"dry-run cannot be combined with reconfigure: " +
@@ -68,6 +123,10 @@ func (c *CLab) Deploy(
 		return nil, err
 	}
 	if initialDeploy {
+		if options.resume {
+			log.Info("Lab has no deployed nodes to resume, deploying the lab", "lab", c.Config.Name)
+		}
+
 		if options.dryRun {
 			return &DeployResult{
 				Apply: &ApplyResult{
@@ -90,7 +149,12 @@ func (c *CLab) Deploy(
 		return nil, err
 	}
 
+	if !options.resume {
+		c.warnFailedDeployment()
+	}
+
 	applyOptions := &ApplyOptions{
+		resume:             options.resume,
 		dryRun:             options.dryRun,
 		skipPostDeploy:     options.skipPostDeploy,
 		skipLabDirFileACLs: options.skipLabDirFileACLs,
@@ -99,7 +163,7 @@ func (c *CLab) Deploy(
 		maxWorkers:         options.maxWorkers,
 		exportTemplate:     options.exportTemplate,
 	}
-	applyResult, err := c.apply(ctx, applyOptions, currentNodes)
+	applyResult, err := c.reconcile(ctx, applyOptions, currentNodes)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +199,14 @@ func (c *CLab) checkReconcileDeployOptions(options *DeployOptions) error {
 		)
 	}
 
+	if options.atomic {
+		return fmt.Errorf(
+			"atomic deployment requires a fresh deployment, but lab %q is already deployed; "+
+				"use --reconfigure to destroy and redeploy it",
+			c.Config.Name,
+		)
+	}
+
 	if len(c.nodeFilter) > 0 {
 		return fmt.Errorf(
 			"node filter is not supported when reconciling deployed lab %q: "+
@@ -162,33 +234,52 @@ func (c *CLab) deploy( //nolint: funlen
 
 	log.Debugf("lab Conf: %+v", c.Config)
 
+	c.profiler = newDeployProfiler()
+
 	if options.reconfigure {
 		_ = c.destroy(ctx, uint(len(c.Nodes)), true)
 		log.Info("Removing directory", "path", c.TopoPaths.TopologyLabDir())
 
-		if err := os.RemoveAll(c.TopoPaths.TopologyLabDir()); err != nil {
+		if err := c.removeLabDirContents(); err != nil {
 			return nil, err
 		}
 	}
 
+	stopProfile := c.profiler.begin("", profilePhaseMgmtNetwork)
+
 	_, err = c.prepareLabManagementNetwork(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	stopProfile()
+
+	stopProfile = c.profiler.begin("", profilePhaseChecks)
+
 	if err := c.checkTopologyDefinition(ctx); err != nil {
 		return nil, err
 	}
 
+	stopProfile()
+
+	stopProfile = c.profiler.begin("", profilePhaseArtifacts)
+
 	if err := c.prepareDeployArtifacts(ctx, options.skipLabDirFileACLs); err != nil {
 		return nil, err
 	}
 
+	stopProfile()
+
+	// the profile is written to the lab directory, so it exists from this point on
+	defer c.writeDeployProfile(options.profileTrace)
+
 	// Apply snapshot restore configuration to nodes
 	if err := c.configureSnapshotRestore(options); err != nil {
 		return nil, err
 	}
 
+	stopProfile = c.profiler.begin("", profilePhaseNodes)
+
 	nodesWg, execCollection, nodeFailCh, err := c.createNodes(
 		ctx,
 		options.maxWorkers,
@@ -198,10 +289,23 @@ func (c *CLab) deploy( //nolint: funlen
 		return nil, err
 	}
 
-	if err := waitForNodeDeploy(ctx, nodesWg, nodeFailCh); err != nil {
+	err = waitForNodeDeploy(ctx, nodesWg, nodeFailCh)
+
+	// the exec results are written on failed deployments as well, as they may explain the failure
+	c.writeStageExecResults()
+
+	if err != nil {
+		// a failed atomic deployment is rolled back, so there is nothing to resume
+		if c.transaction == nil {
+			c.recordIncompleteNodes()
+			c.writeFailedState(nil)
+		}
+
 		return nil, err
 	}
 
+	stopProfile()
+
 	// also call deploy on the special nodes endpoints (only host is required for the
 	// vxlan stitched endpoints).
 	// this must happen after all node workers have finished so that veth pairs created
@@ -217,6 +321,8 @@ func (c *CLab) deploy( //nolint: funlen
 	// Stitch links after node workers and host endpoints have finished.
 	// The veth pair is already created by node workers and the VxLAN interface is created
 	// by host endpoint deploy; Stitch applies the TC redirect rules to bridge them.
+	stopProfile = c.profiler.begin("", profilePhaseLinksPostDeploy)
+
 	var linkPostDeployWorkers errgroup.Group
 	linkPostDeployWorkers.SetLimit(int(options.maxWorkers))
 	for _, link := range c.Links {
@@ -229,9 +335,52 @@ func (c *CLab) deploy( //nolint: funlen
 	}
 	_ = linkPostDeployWorkers.Wait()
 
+	stopProfile()
+
 	execCollection.Log()
 
-	return c.finalize(ctx, options.exportTemplate, options.graph)
+	defer c.profiler.begin("", profilePhaseFinalize)()
+
+	containers, err := c.finalize(ctx, options.exportTemplate, options.graph)
+	if err != nil {
+		return nil, err
+	}
+
+	c.recordStateRevision(&ApplyResult{
+		DeployedLab: true,
+		LabName:     c.Config.Name,
+		AddedNodes:  sortedNodeNames(c.Nodes),
+	})
+
+	return containers, nil
+}
+
+// writeStageExecResults writes the results of the stage exec commands of all nodes
+// to the lab directory.
+func (c *CLab) writeStageExecResults() {
+	results := []*clabcoredependency_manager.StageExecResult{}
+
+	for _, dn := range c.dependencyManager.GetNodes() {
+		results = append(results, dn.ExecResults()...)
+	}
+
+	if len(results) == 0 {
+		return
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Node < results[j].Node
+	})
+
+	b, err := json.MarshalIndent(results, "", "  ")
+	if err == nil {
+		err = os.WriteFile(c.TopoPaths.StageExecResultsFile(), b,
+			clabconstants.PermissionsFileDefault)
+	}
+
+	if err != nil {
+		log.Warnf("failed to write stage exec results: %v", err)
+	}
 }
 
 func waitForNodeDeploy(
@@ -271,6 +420,11 @@ func (c *CLab) prepareLabManagementNetwork(ctx context.Context) (bool, error) {
 		if err := c.CreateNetwork(ctx); err != nil {
 			return skipMgmt, err
 		}
+
+		// only the network created by the deployment is removed by its rollback
+		if rt, ok := c.globalRuntime().(clabruntime.MgmtNetCreator); ok && rt.MgmtNetCreated() {
+			c.transaction.recordMgmtNetwork()
+		}
 	}
 
 	if err := clablinks.SetMgmtNetUnderlyingBridge(c.Config.Mgmt.Bridge); err != nil {
@@ -300,6 +454,32 @@ func (c *CLab) prepareDeployArtifacts(ctx context.Context, skipLabDirFileACLs bo
 	return nil
 }
 
+// removeLabDirContents removes the contents of the lab directory except the lab lock file
+// held by the deployment.
+func (c *CLab) removeLabDirContents() error {
+	entries, err := os.ReadDir(c.TopoPaths.TopologyLabDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(c.TopoPaths.TopologyLabDir(), entry.Name())
+		if path == c.TopoPaths.LabLockFile() {
+			continue
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *CLab) prepareLabDirectory(skipFileACLs bool) {
 	log.Info("Creating lab directory", "path", c.TopoPaths.TopologyLabDir())
 	clabutils.CreateDirectory(c.TopoPaths.TopologyLabDir(), clabconstants.PermissionsDirDefault)
@@ -450,20 +630,48 @@ func (c *CLab) createNodes(
 	maxWorkers uint,
 	skipPostDeploy bool,
 ) (*sync.WaitGroup, *clabexec.ExecCollection, chan error, error) {
+	err := c.createDependencies()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// make sure that there are no unresolvable dependencies, which would deadlock.
+	err = c.dependencyManager.CheckAcyclicity()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Buffered so workers never block reporting a failure (at most one failure path per node).
+	// With zero nodes, nothing sends on this channel; an empty buffer is fine.
+	nodeFailCh := make(chan error, len(c.Nodes))
+
+	// start scheduling
+	NodesWg, execCollection := c.scheduleNodes(ctx, int(maxWorkers), skipPostDeploy, nodeFailCh)
+
+	return NodesWg, execCollection, nodeFailCh, nil
+}
+
+// createDependencies registers the nodes with the dependency manager and creates
+// the dependencies between their stages.
+func (c *CLab) createDependencies() error {
 	for _, node := range c.Nodes {
 		c.dependencyManager.AddNode(node)
 	}
 
+	for _, dn := range c.dependencyManager.GetNodes() {
+		dn.OnExecResult(c.publishStageExecResult)
+	}
+
 	// nodes with static mgmt IP should be scheduled before the dynamic ones
 	err := c.createStaticDynamicDependency()
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
 
 	// create user-defined node dependencies done with `wait-for` property of the deployment stage
 	err = c.createWaitForDependency()
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
 
 	// make network namespace shared containers start in the right order
@@ -471,20 +679,7 @@ func (c *CLab) createNodes(
 
 	// Add possible additional dependencies here
 
-	// make sure that there are no unresolvable dependencies, which would deadlock.
-	err = c.dependencyManager.CheckAcyclicity()
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
-	// Buffered so workers never block reporting a failure (at most one failure path per node).
-	// With zero nodes, nothing sends on this channel; an empty buffer is fine.
-	nodeFailCh := make(chan error, len(c.Nodes))
-
-	// start scheduling
-	NodesWg, execCollection := c.scheduleNodes(ctx, int(maxWorkers), skipPostDeploy, nodeFailCh)
-
-	return NodesWg, execCollection, nodeFailCh, nil
+	return nil
 }
 
 // DeployNodes runs the node creation phase for the selected nodes.
@@ -513,7 +708,14 @@ func (c *CLab) DeployNodes(
 		go func() {
 			for nodeName := range input {
 				log.Info("Creating node", "node", nodeName)
-				errCh <- c.deployNode(ctx, c.Nodes[nodeName])
+				c.publishNodeStage(c.Nodes[nodeName], clabtypes.WaitForCreate)
+				err := c.deployNode(ctx, c.Nodes[nodeName])
+				if err != nil {
+					c.recordNodeFailure(nodeName, err)
+					c.publishNodeEvent(c.Nodes[nodeName], clabcorebus.ActionNodeFail,
+						map[string]string{"error": err.Error()})
+				}
+				errCh <- err
 			}
 		}()
 	}
@@ -548,6 +750,8 @@ func (c *CLab) deployNode(ctx context.Context, node clabnodes.Node) error {
 		return fmt.Errorf("node %q pre-deploy: %w", nodeName, err)
 	}
 
+	c.transaction.recordNode(node)
+
 	if err := node.Deploy(ctx, &clabnodes.DeployParams{Nodes: c.Nodes}); err != nil {
 		return fmt.Errorf("node %q deploy: %w", nodeName, err)
 	}
@@ -586,6 +790,8 @@ func (c *CLab) deployLinks(
 		if err := link.PostDeploy(ctx); err != nil {
 			return fmt.Errorf("failed post-deploying link %s: %w", applyLinkName(link), err)
 		}
+
+		c.publishEvent(clabcorebus.ActionLinkDeploy, linkEventAttributes(link))
 	}
 
 	for _, nodeName := range sortedStringSet(touchedNodes) {
@@ -616,13 +822,18 @@ func (c *CLab) postDeployApplyNodes(
 				ctx,
 				&clabnodes.PostDeployParams{Nodes: c.Nodes},
 			); err != nil {
-				return fmt.Errorf("node %q post-deploy: %w", nodeName, err)
+				err = fmt.Errorf("node %q post-deploy: %w", nodeName, err)
+				c.recordNodeFailure(nodeName, err)
+
+				return err
 			}
 		}
 
 		if err := node.RunExecFromConfig(ctx, execCollection); err != nil {
 			log.Errorf("failed to run exec commands for %s: %v", nodeName, err)
 		}
+
+		c.publishExecResults(node, execCollection.Results(nodeName))
 	}
 
 	execCollection.Log()
@@ -662,12 +873,14 @@ func (c *CLab) finalize(
 
 	if !c.skipMgmtNetwork() {
 		log.Info("Adding host entries", "path", "/etc/hosts")
+		c.transaction.recordHostsEntries()
 		if err := c.appendHostsFileEntries(ctx); err != nil {
 			log.Errorf("failed to create hosts file: %v", err)
 		}
 	}
 
 	log.Info("Adding SSH config for nodes", "path", c.TopoPaths.SSHConfigPath())
+	c.transaction.recordSSHConfig()
 	if err := c.addSSHConfig(); err != nil {
 		log.Errorf("failed to create ssh config file: %v", err)
 	}
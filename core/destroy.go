@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
 	clablinks "github.com/srl-labs/containerlab/links"
 	clabnodes "github.com/srl-labs/containerlab/nodes"
 	clabruntime "github.com/srl-labs/containerlab/runtime"
@@ -65,8 +66,20 @@ func (c *CLab) Destroy(ctx context.Context, options ...DestroyOption) (err error
 		topos[topoFile] = filepath.Dir(containers[idx].Labels[clabconstants.NodeLabDir])
 	}
 
+	// the labs are unlocked once their lab directories are removed by the cleanup
+	var unlocks []func()
+
 	defer func() {
-		if opts.cleanup {
+		for _, unlock := range unlocks {
+			unlock()
+		}
+	}()
+
+	// locked is set when the lab is locked by another process, its lab directory is kept
+	locked := false
+
+	defer func() {
+		if opts.cleanup && !locked {
 			err = c.destroyLabDirs(topos, opts.all)
 		}
 	}()
@@ -95,11 +108,51 @@ func (c *CLab) Destroy(ctx context.Context, options ...DestroyOption) (err error
 			return err
 		}
 
+		unlock, err := cc.lockLab(ctx, false)
+		if err != nil {
+			if !opts.all {
+				locked = true
+
+				return err
+			}
+
+			log.Errorf("Lab %s is not destroyed: %v", cc.Config.Name, err)
+			errs = append(errs, err)
+
+			// the lab directory of the locked lab is not removed by the cleanup
+			delete(topos, topo)
+
+			continue
+		}
+
+		unlocks = append(unlocks, unlock)
+
+		cc.publishEvent(clabcorebus.ActionDestroyStart, nil)
+
+		if err := cc.runHooks(ctx, clabtypes.HookPreDestroy); err != nil {
+			log.Errorf("Lab %s is not destroyed: %v", cc.Config.Name, err)
+			errs = append(errs, err)
+			cc.publishOutcome(clabcorebus.ActionDestroyDone, clabcorebus.ActionDestroyFail, err)
+
+			continue
+		}
+
 		err = cc.destroy(ctx, opts.maxWorkers, opts.keepMgmtNet)
 		if err != nil {
 			log.Errorf("Error occurred during the %s lab deletion: %v", cc.Config.Name, err)
 			errs = append(errs, err)
+			cc.publishOutcome(clabcorebus.ActionDestroyDone, clabcorebus.ActionDestroyFail, err)
+
+			continue
 		}
+
+		hookErr := cc.runHooks(ctx, clabtypes.HookPostDestroy)
+		if hookErr != nil {
+			log.Error(hookErr)
+			errs = append(errs, hookErr)
+		}
+
+		cc.publishOutcome(clabcorebus.ActionDestroyDone, clabcorebus.ActionDestroyFail, hookErr)
 	}
 
 	if len(errs) != 0 {
@@ -119,6 +172,7 @@ func (c *CLab) makeCopyForDestroy(
 ) (*CLab, error) {
 	newOpts := []ClabOption{
 		WithTimeout(c.timeout),
+		WithLabLock(c.waitLabLock, c.forceUnlockLab),
 	}
 
 	// Try to load topology file if it exists, otherwise use lab name only.
@@ -0,0 +1,65 @@
+// Package diagram lays out the topology graph and renders it to SVG and PNG without
+// external tools. The nodes are placed in layers ordered by their level or group, the order of
+// the nodes in a layer reduces the crossings of the links, and the nodes with a position are
+// placed at it.
+package diagram
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Graph is the topology graph to lay out.
+type Graph struct {
+	Name  string
+	Nodes []Node
+	Links []Link
+}
+
+// Node is a node of the graph.
+type Node struct {
+	Name  string
+	Kind  string
+	Group string
+	// Level is the layer of the node counted from the top starting at 1,
+	// zero places the node by its group
+	Level int
+	// Position is the center of the node, the node is laid out when it is nil
+	Position *Point
+}
+
+// Link is a point-to-point link between the endpoints of two nodes.
+type Link struct {
+	A         string
+	AEndpoint string
+	B         string
+	BEndpoint string
+}
+
+// Point is a point of the diagram, the y axis points down.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// ParsePosition parses the node position given as the x and y coordinates
+// separated by a comma, e.g. 100,200.
+func ParsePosition(s string) (*Point, error) {
+	xs, ys, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid position %q, expected x,y", s)
+	}
+
+	x, err := strconv.ParseFloat(strings.TrimSpace(xs), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate of the position %q: %w", s, err)
+	}
+
+	y, err := strconv.ParseFloat(strings.TrimSpace(ys), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate of the position %q: %w", s, err)
+	}
+
+	return &Point{X: x, Y: y}, nil
+}
@@ -0,0 +1,448 @@
+package diagram
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	nodeMinWidth   = 120.0
+	nodeHeight     = 48.0
+	nodePadding    = 16.0
+	nodeGap        = 60.0
+	layerGap       = 110.0
+	margin         = 40.0
+	nameFontSize   = 14.0
+	labelFontSize  = 10.0
+	linkSpacing    = 10.0
+	labelDistance  = 14.0
+	orderingSweeps = 4
+
+	// rank bases of the node layers, the levels come first, then the groups
+	// of the sort order and the other groups
+	sortOrderRankBase  = 1000
+	otherGroupRankBase = 2000
+)
+
+// groupSortOrder is the order of the node groups from the top to the bottom,
+// it matches the sort order of the HTML graph.
+var groupSortOrder = []string{ //nolint:gochecknoglobals
+	"10", "9", "superspine", "8", "dc-gw", "7", "6", "spine",
+	"5", "4", "leaf", "border-leaf", "3", "server", "2", "1",
+}
+
+// Layout is the graph with the nodes and the links placed on the diagram.
+type Layout struct {
+	Name   string
+	Width  float64
+	Height float64
+	Nodes  []PlacedNode
+	Links  []PlacedLink
+}
+
+// PlacedNode is a node with its center and size.
+type PlacedNode struct {
+	Node
+	Center Point
+	Width  float64
+	Height float64
+}
+
+// PlacedLink is a link with its ends on the node borders and the positions
+// of the endpoint labels.
+type PlacedLink struct {
+	Link
+	From      Point
+	To        Point
+	FromLabel Point
+	ToLabel   Point
+}
+
+// textWidth returns the approximate width of the text rendered with the font size.
+func textWidth(s string, size float64) float64 {
+	return float64(len(s)) * size * 0.6
+}
+
+// NewLayout places the nodes and the links of the graph.
+func NewLayout(g *Graph) *Layout {
+	l := &Layout{Name: g.Name}
+
+	index := make(map[string]int, len(g.Nodes))
+	for _, n := range g.Nodes {
+		if _, ok := index[n.Name]; ok {
+			continue
+		}
+
+		index[n.Name] = len(l.Nodes)
+
+		width := math.Max(nodeMinWidth, math.Max(
+			textWidth(n.Name, nameFontSize), textWidth(n.Kind, labelFontSize))+2*nodePadding)
+
+		l.Nodes = append(l.Nodes, PlacedNode{Node: n, Width: width, Height: nodeHeight})
+	}
+
+	// the links to the nodes not in the graph and the loops are not drawn
+	var links []Link
+
+	neighbors := make([][]int, len(l.Nodes))
+
+	for _, link := range g.Links {
+		a, okA := index[link.A]
+		b, okB := index[link.B]
+
+		if !okA || !okB || a == b {
+			continue
+		}
+
+		links = append(links, link)
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+
+	layers := l.order(assignLayers(l.Nodes, neighbors), neighbors)
+	l.place(layers)
+	l.placeLinks(links, index)
+
+	return l
+}
+
+// assignLayers returns the layer of every node. The nodes with a level or a group are layered
+// by it, the other nodes are placed a layer below their layered neighbors.
+func assignLayers(nodes []PlacedNode, neighbors [][]int) []int {
+	ranks := make([]int, len(nodes))
+
+	var otherGroups []string
+
+	seen := make(map[string]bool)
+
+	for _, n := range nodes {
+		if n.Level > 0 || n.Group == "" || seen[n.Group] || groupRank(n.Group) >= 0 {
+			continue
+		}
+
+		seen[n.Group] = true
+		otherGroups = append(otherGroups, n.Group)
+	}
+
+	sort.Strings(otherGroups)
+
+	for i, n := range nodes {
+		switch {
+		case n.Level > 0:
+			ranks[i] = n.Level
+		case groupRank(n.Group) >= 0:
+			ranks[i] = sortOrderRankBase + groupRank(n.Group)
+		case n.Group != "":
+			ranks[i] = otherGroupRankBase + sort.SearchStrings(otherGroups, n.Group)
+		default:
+			ranks[i] = -1
+		}
+	}
+
+	layers := compress(ranks)
+
+	// the ungrouped nodes are placed below their layered neighbors
+	for changed := true; changed; {
+		changed = false
+
+		for i := range nodes {
+			if layers[i] >= 0 {
+				continue
+			}
+
+			for _, nb := range neighbors[i] {
+				if layers[nb] >= 0 && (layers[i] < 0 || layers[nb]+1 < layers[i]) {
+					layers[i] = layers[nb] + 1
+					changed = true
+				}
+			}
+		}
+	}
+
+	// the components without the layered nodes are layered from their most connected node
+	for {
+		root := -1
+
+		for i := range nodes {
+			if layers[i] < 0 && (root < 0 || len(neighbors[i]) > len(neighbors[root])) {
+				root = i
+			}
+		}
+
+		if root < 0 {
+			break
+		}
+
+		layers[root] = 0
+
+		queue := []int{root}
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+
+			for _, nb := range neighbors[cur] {
+				if layers[nb] < 0 {
+					layers[nb] = layers[cur] + 1
+					queue = append(queue, nb)
+				}
+			}
+		}
+	}
+
+	return compress(layers)
+}
+
+func groupRank(group string) int {
+	for i, g := range groupSortOrder {
+		if g == group {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// compress maps the non-negative ranks to the consecutive layers keeping their order,
+// the negative ranks are kept.
+func compress(ranks []int) []int {
+	var distinct []int
+
+	seen := make(map[int]bool)
+
+	for _, r := range ranks {
+		if r >= 0 && !seen[r] {
+			seen[r] = true
+			distinct = append(distinct, r)
+		}
+	}
+
+	sort.Ints(distinct)
+
+	layers := make([]int, len(ranks))
+	for i, r := range ranks {
+		if r < 0 {
+			layers[i] = -1
+
+			continue
+		}
+
+		layers[i] = sort.SearchInts(distinct, r)
+	}
+
+	return layers
+}
+
+// order returns the nodes of every layer ordered to reduce the link crossings,
+// the nodes are moved to the barycenter of their neighbors in the adjacent layers.
+func (l *Layout) order(layerOf []int, neighbors [][]int) [][]int {
+	var layers [][]int
+
+	for i, layer := range layerOf {
+		for len(layers) <= layer {
+			layers = append(layers, nil)
+		}
+
+		layers[layer] = append(layers[layer], i)
+	}
+
+	position := make([]float64, len(layerOf))
+
+	for _, layer := range layers {
+		sort.Slice(layer, func(i, j int) bool {
+			return l.Nodes[layer[i]].Name < l.Nodes[layer[j]].Name
+		})
+
+		for pos, n := range layer {
+			position[n] = float64(pos)
+		}
+	}
+
+	sortLayer := func(layer []int, adjacent int) {
+		barycenter := make(map[int]float64, len(layer))
+
+		for _, n := range layer {
+			sum, count := 0.0, 0
+
+			for _, nb := range neighbors[n] {
+				if layerOf[nb] == adjacent {
+					sum += position[nb]
+					count++
+				}
+			}
+
+			barycenter[n] = position[n]
+			if count > 0 {
+				barycenter[n] = sum / float64(count)
+			}
+		}
+
+		sort.SliceStable(layer, func(i, j int) bool {
+			return barycenter[layer[i]] < barycenter[layer[j]]
+		})
+
+		for pos, n := range layer {
+			position[n] = float64(pos)
+		}
+	}
+
+	for sweep := 0; sweep < orderingSweeps; sweep++ {
+		for i := 1; i < len(layers); i++ {
+			sortLayer(layers[i], i-1)
+		}
+
+		for i := len(layers) - 2; i >= 0; i-- {
+			sortLayer(layers[i], i+1)
+		}
+	}
+
+	return layers
+}
+
+// place centers the layers horizontally and stacks them from the top, the nodes with
+// a position are moved to it and the diagram is fitted to the nodes.
+func (l *Layout) place(layers [][]int) {
+	widths := make([]float64, len(layers))
+	maxWidth := 0.0
+
+	for i, layer := range layers {
+		for j, n := range layer {
+			if j > 0 {
+				widths[i] += nodeGap
+			}
+
+			widths[i] += l.Nodes[n].Width
+		}
+
+		maxWidth = math.Max(maxWidth, widths[i])
+	}
+
+	for i, layer := range layers {
+		x := (maxWidth - widths[i]) / 2
+
+		for _, n := range layer {
+			node := &l.Nodes[n]
+			node.Center = Point{
+				X: x + node.Width/2,
+				Y: float64(i)*(nodeHeight+layerGap) + nodeHeight/2,
+			}
+
+			x += node.Width + nodeGap
+		}
+	}
+
+	for i := range l.Nodes {
+		if p := l.Nodes[i].Position; p != nil {
+			l.Nodes[i].Center = *p
+		}
+	}
+
+	if len(l.Nodes) == 0 {
+		l.Width, l.Height = 2*margin, 2*margin
+
+		return
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+
+	for _, n := range l.Nodes {
+		minX = math.Min(minX, n.Center.X-n.Width/2)
+		minY = math.Min(minY, n.Center.Y-n.Height/2)
+		maxX = math.Max(maxX, n.Center.X+n.Width/2)
+		maxY = math.Max(maxY, n.Center.Y+n.Height/2)
+	}
+
+	for i := range l.Nodes {
+		l.Nodes[i].Center.X += margin - minX
+		l.Nodes[i].Center.Y += margin - minY
+	}
+
+	l.Width = maxX - minX + 2*margin
+	l.Height = maxY - minY + 2*margin
+}
+
+// placeLinks draws the links between the node borders, the parallel links
+// between the same nodes are spread apart.
+func (l *Layout) placeLinks(links []Link, index map[string]int) {
+	type pair struct{ a, b int }
+
+	parallel := make(map[pair]int)
+
+	for _, link := range links {
+		a, b := index[link.A], index[link.B]
+		if a > b {
+			a, b = b, a
+		}
+
+		parallel[pair{a, b}]++
+	}
+
+	placed := make(map[pair]int)
+
+	for _, link := range links {
+		a, b := index[link.A], index[link.B]
+
+		key := pair{a, b}
+		if a > b {
+			key = pair{b, a}
+		}
+
+		// the offset is taken relative to the direction from the lower to the higher index,
+		// so the offsets of the parallel links do not depend on the link direction
+		offset := (float64(placed[key]) - float64(parallel[key]-1)/2) * linkSpacing
+		// the labels of the parallel links are staggered along the links not to overlap
+		labelAt := labelDistance + float64(placed[key])*(labelFontSize+4)
+		placed[key]++
+
+		na, nb := l.Nodes[a], l.Nodes[b]
+
+		dx, dy := nb.Center.X-na.Center.X, nb.Center.Y-na.Center.Y
+		length := math.Hypot(dx, dy)
+
+		if length == 0 {
+			continue
+		}
+
+		ux, uy := dx/length, dy/length
+
+		nx, ny := -uy*offset, ux*offset
+		if a > b {
+			nx, ny = -nx, -ny
+		}
+
+		from := Point{X: na.Center.X + nx, Y: na.Center.Y + ny}
+		to := Point{X: nb.Center.X + nx, Y: nb.Center.Y + ny}
+
+		tFrom := borderDistance(na, ux, uy)
+		tTo := borderDistance(nb, -ux, -uy)
+
+		l.Links = append(l.Links, PlacedLink{
+			Link: link,
+			From: Point{X: from.X + ux*tFrom, Y: from.Y + uy*tFrom},
+			To:   Point{X: to.X - ux*tTo, Y: to.Y - uy*tTo},
+			FromLabel: Point{
+				X: from.X + ux*(tFrom+labelAt),
+				Y: from.Y + uy*(tFrom+labelAt),
+			},
+			ToLabel: Point{
+				X: to.X - ux*(tTo+labelAt),
+				Y: to.Y - uy*(tTo+labelAt),
+			},
+		})
+	}
+}
+
+// borderDistance returns the distance from the node center to its border in the direction.
+func borderDistance(n PlacedNode, ux, uy float64) float64 {
+	tx, ty := math.Inf(1), math.Inf(1)
+
+	if ux != 0 {
+		tx = n.Width / 2 / math.Abs(ux)
+	}
+
+	if uy != 0 {
+		ty = n.Height / 2 / math.Abs(uy)
+	}
+
+	return math.Min(tx, ty)
+}
@@ -0,0 +1,215 @@
+package diagram
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// layers returns the node names of every layer from the top, ordered from the left.
+func layers(l *Layout) [][]string {
+	var ys []float64
+
+	rows := make(map[float64][]PlacedNode)
+
+	for _, n := range l.Nodes {
+		if _, ok := rows[n.Center.Y]; !ok {
+			ys = append(ys, n.Center.Y)
+		}
+
+		rows[n.Center.Y] = append(rows[n.Center.Y], n)
+	}
+
+	sort.Float64s(ys)
+
+	var result [][]string
+
+	for _, y := range ys {
+		row := rows[y]
+		sort.Slice(row, func(i, j int) bool { return row[i].Center.X < row[j].Center.X })
+
+		var names []string
+		for _, n := range row {
+			names = append(names, n.Name)
+		}
+
+		result = append(result, names)
+	}
+
+	return result
+}
+
+func TestLayoutLayers(t *testing.T) {
+	tests := map[string]struct {
+		graph Graph
+		want  [][]string
+	}{
+		"groups": {
+			graph: Graph{
+				Nodes: []Node{
+					{Name: "leaf1", Group: "leaf"},
+					{Name: "spine1", Group: "spine"},
+					{Name: "leaf2", Group: "leaf"},
+					{Name: "dc-gw1", Group: "dc-gw"},
+				},
+				Links: []Link{
+					{A: "spine1", B: "leaf1"},
+					{A: "spine1", B: "leaf2"},
+					{A: "dc-gw1", B: "spine1"},
+				},
+			},
+			want: [][]string{{"dc-gw1"}, {"spine1"}, {"leaf1", "leaf2"}},
+		},
+		"ungrouped nodes below their neighbors": {
+			graph: Graph{
+				Nodes: []Node{
+					{Name: "client1"},
+					{Name: "leaf1", Group: "leaf"},
+					{Name: "client2"},
+				},
+				Links: []Link{
+					{A: "client1", B: "leaf1"},
+					{A: "leaf1", B: "client2"},
+				},
+			},
+			want: [][]string{{"leaf1"}, {"client1", "client2"}},
+		},
+		"other groups after the sort order": {
+			graph: Graph{
+				Nodes: []Node{
+					{Name: "b", Group: "zeta"},
+					{Name: "a", Group: "alpha"},
+					{Name: "c", Group: "spine"},
+				},
+			},
+			want: [][]string{{"c"}, {"a"}, {"b"}},
+		},
+		"levels before groups": {
+			graph: Graph{
+				Nodes: []Node{
+					{Name: "spine1", Group: "spine"},
+					{Name: "rr1", Level: 1},
+					{Name: "leaf1", Group: "leaf", Level: 3},
+				},
+			},
+			want: [][]string{{"rr1"}, {"leaf1"}, {"spine1"}},
+		},
+		"ungrouped graph from the most connected node": {
+			graph: Graph{
+				Nodes: []Node{
+					{Name: "a"},
+					{Name: "b"},
+					{Name: "hub"},
+					{Name: "c"},
+					{Name: "d"},
+				},
+				Links: []Link{
+					{A: "a", B: "hub"},
+					{A: "b", B: "hub"},
+					{A: "d", B: "hub"},
+					{A: "c", B: "a"},
+				},
+			},
+			want: [][]string{{"hub"}, {"a", "b", "d"}, {"c"}},
+		},
+		"crossings reduced": {
+			graph: Graph{
+				Nodes: []Node{
+					{Name: "spine1", Group: "spine"},
+					{Name: "spine2", Group: "spine"},
+					{Name: "leaf1", Group: "leaf"},
+					{Name: "leaf2", Group: "leaf"},
+				},
+				Links: []Link{
+					{A: "spine1", B: "leaf2"},
+					{A: "spine2", B: "leaf1"},
+				},
+			},
+			want: [][]string{{"spine1", "spine2"}, {"leaf2", "leaf1"}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, layers(NewLayout(&tt.graph))); diff != "" {
+				t.Errorf("unexpected layers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLayoutPosition(t *testing.T) {
+	l := NewLayout(&Graph{
+		Nodes: []Node{
+			{Name: "n1", Position: &Point{X: 100, Y: 100}},
+			{Name: "n2", Position: &Point{X: 400, Y: 300}},
+		},
+	})
+
+	// the diagram is fitted to the nodes, keeping the distances between the positions
+	n1, n2 := l.Nodes[0].Center, l.Nodes[1].Center
+
+	if got := (Point{X: n2.X - n1.X, Y: n2.Y - n1.Y}); got != (Point{X: 300, Y: 200}) {
+		t.Errorf("unexpected distance between the positioned nodes %+v", got)
+	}
+
+	if n1.X != margin+nodeMinWidth/2 || n1.Y != margin+nodeHeight/2 {
+		t.Errorf("unexpected position of the first node %+v", n1)
+	}
+}
+
+func TestLayoutLinks(t *testing.T) {
+	l := NewLayout(&Graph{
+		Nodes: []Node{
+			{Name: "spine1", Group: "spine"},
+			{Name: "leaf1", Group: "leaf"},
+		},
+		Links: []Link{
+			{A: "spine1", AEndpoint: "e1-1", B: "leaf1", BEndpoint: "e1-49"},
+			{A: "leaf1", AEndpoint: "e1-50", B: "spine1", BEndpoint: "e1-2"},
+			{A: "leaf1", AEndpoint: "e1-1", B: "client1", BEndpoint: "eth1"},
+			{A: "leaf1", AEndpoint: "e1-2", B: "leaf1", BEndpoint: "e1-3"},
+		},
+	})
+
+	// the links to the nodes not in the graph and the loops are not drawn
+	if len(l.Links) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(l.Links))
+	}
+
+	spine, leaf := l.Nodes[0], l.Nodes[1]
+
+	for _, link := range l.Links {
+		top, bottom := link.From, link.To
+		if link.A == "leaf1" {
+			top, bottom = bottom, top
+		}
+
+		if top.Y != spine.Center.Y+nodeHeight/2 || bottom.Y != leaf.Center.Y-nodeHeight/2 {
+			t.Errorf("link %+v does not end on the node borders", link)
+		}
+	}
+
+	// the parallel links are spread apart
+	if l.Links[0].From.X == l.Links[1].To.X {
+		t.Errorf("parallel links overlap at x=%v", l.Links[0].From.X)
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	p, err := ParsePosition("10, -20.5")
+	if err != nil {
+		t.Fatalf("failed to parse position: %v", err)
+	}
+
+	if *p != (Point{X: 10, Y: -20.5}) {
+		t.Errorf("unexpected position %+v", *p)
+	}
+
+	for _, s := range []string{"10", "a,1", "1,b"} {
+		if _, err := ParsePosition(s); err == nil {
+			t.Errorf("expected an error for the position %q", s)
+		}
+	}
+}
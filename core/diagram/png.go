@@ -0,0 +1,149 @@
+package diagram
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+)
+
+// pngScale is the number of the PNG pixels per diagram unit.
+const pngScale = 2
+
+// RenderPNG writes the laid out graph as a PNG image.
+func RenderPNG(w io.Writer, l *Layout) error {
+	img := image.NewRGBA(image.Rect(0, 0,
+		int(math.Ceil(l.Width*pngScale)), int(math.Ceil(l.Height*pngScale))))
+
+	draw.Draw(img, img.Bounds(), image.NewUniform(hexColor(colorCanvas)), image.Point{}, draw.Src)
+
+	for _, link := range l.Links {
+		drawLine(img, link.From, link.To, linkWidth, hexColor(colorLink))
+	}
+
+	for _, link := range l.Links {
+		drawPNGLabel(img, link.FromLabel, link.AEndpoint)
+		drawPNGLabel(img, link.ToLabel, link.BEndpoint)
+	}
+
+	for _, n := range l.Nodes {
+		fillRect(img, n.Center.X-n.Width/2, n.Center.Y-n.Height/2, n.Width, n.Height, nodeRadius,
+			hexColor(colorNode))
+
+		nameY := n.Center.Y
+		if n.Kind != "" {
+			nameY -= labelFontSize / 2
+		}
+
+		drawText(img, n.Name, Point{X: n.Center.X, Y: nameY}, nameFontSize, hexColor(colorText))
+
+		if n.Kind != "" {
+			drawText(img, n.Kind, Point{X: n.Center.X, Y: n.Center.Y + nameFontSize/2 + 1},
+				labelFontSize, hexColor(colorText))
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+func drawPNGLabel(img *image.RGBA, p Point, text string) {
+	if text == "" {
+		return
+	}
+
+	width := textWidth(text, labelFontSize) + 4
+
+	fillRect(img, p.X-width/2, p.Y-labelFontSize/2-1, width, labelFontSize+2, 0,
+		hexColor(colorCanvas))
+	drawText(img, text, p, labelFontSize, hexColor(colorLabel))
+}
+
+// fillRect fills the rectangle given in the diagram units with the corners
+// rounded with the radius.
+func fillRect(img *image.RGBA, x, y, width, height, radius float64, c color.RGBA) {
+	x0, y0 := int(math.Round(x*pngScale)), int(math.Round(y*pngScale))
+	x1, y1 := int(math.Round((x+width)*pngScale)), int(math.Round((y+height)*pngScale))
+	r := radius * pngScale
+
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			// the distance to the nearest corner center, the pixels outside the corner
+			// circles are not filled
+			cx := math.Max(float64(x0)+r-float64(px)-0.5, float64(px)+0.5-(float64(x1)-r))
+			cy := math.Max(float64(y0)+r-float64(py)-0.5, float64(py)+0.5-(float64(y1)-r))
+
+			if cx > 0 && cy > 0 && cx*cx+cy*cy > r*r {
+				continue
+			}
+
+			img.SetRGBA(px, py, c)
+		}
+	}
+}
+
+// drawLine draws the line of the width given in the diagram units.
+func drawLine(img *image.RGBA, from, to Point, width float64, c color.RGBA) {
+	x0, y0 := from.X*pngScale, from.Y*pngScale
+	x1, y1 := to.X*pngScale, to.Y*pngScale
+	half := width * pngScale / 2
+
+	steps := int(math.Ceil(math.Hypot(x1-x0, y1-y0)))
+
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+
+		px, py := x0+(x1-x0)*t, y0+(y1-y0)*t
+
+		for dy := int(math.Floor(py - half)); dy < int(math.Ceil(py+half)); dy++ {
+			for dx := int(math.Floor(px - half)); dx < int(math.Ceil(px+half)); dx++ {
+				img.SetRGBA(dx, dy, c)
+			}
+		}
+	}
+}
+
+// drawText draws the text centered at the point with the bitmap font
+// scaled to the font size.
+func drawText(img *image.RGBA, text string, center Point, size float64, c color.RGBA) {
+	scale := max(1, int(math.Round(size*pngScale/10)))
+
+	width := len(text)*glyphAdvance*scale - scale
+	x := int(math.Round(center.X*pngScale)) - width/2
+	y := int(math.Round(center.Y*pngScale)) - glyphHeight*scale/2
+
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch < firstGlyph || int(ch-firstGlyph) >= len(glyphs) {
+			ch = '?'
+		}
+
+		glyph := glyphs[ch-firstGlyph]
+
+		for row, bits := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+
+				draw.Draw(img, image.Rect(
+					x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale),
+					image.NewUniform(c), image.Point{}, draw.Src)
+			}
+		}
+
+		x += glyphAdvance * scale
+	}
+}
+
+// hexColor parses the #rrggbb color.
+func hexColor(s string) color.RGBA {
+	v, _ := strconv.ParseUint(s[1:], 16, 32)
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
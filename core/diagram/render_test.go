@@ -0,0 +1,71 @@
+package diagram
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+)
+
+func renderTestLayout() *Layout {
+	return NewLayout(&Graph{
+		Name: "lab<1>",
+		Nodes: []Node{
+			{Name: "spine1", Kind: "nokia_srlinux", Group: "spine"},
+			{Name: "leaf1", Kind: "nokia_srlinux", Group: "leaf"},
+		},
+		Links: []Link{
+			{A: "spine1", AEndpoint: "e1-1", B: "leaf1", BEndpoint: "ethernet-1/49"},
+		},
+	})
+}
+
+func TestRenderSVG(t *testing.T) {
+	var b bytes.Buffer
+
+	if err := RenderSVG(&b, renderTestLayout()); err != nil {
+		t.Fatalf("failed to render SVG: %v", err)
+	}
+
+	out := b.String()
+
+	for _, want := range []string{
+		"<svg ", "<title>lab&lt;1&gt;</title>", ">spine1</text>", ">leaf1</text>",
+		">nokia_srlinux</text>", ">e1-1</text>", ">ethernet-1/49</text>", "<line ", "</svg>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	l := renderTestLayout()
+
+	var b bytes.Buffer
+
+	if err := RenderPNG(&b, l); err != nil {
+		t.Fatalf("failed to render PNG: %v", err)
+	}
+
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("failed to decode PNG: %v", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() != int(math.Ceil(l.Width*pngScale)) ||
+		bounds.Dy() != int(math.Ceil(l.Height*pngScale)) {
+		t.Errorf("unexpected image size %v for the layout %vx%v", bounds, l.Width, l.Height)
+	}
+
+	// the node is filled at its center
+	center := l.Nodes[0].Center
+
+	r, g, bl, _ := img.At(int(center.X*pngScale), int(center.Y*pngScale-nodeHeight/2)).RGBA()
+	if want := hexColor(colorNode); uint8(r>>8) != want.R || uint8(g>>8) != want.G ||
+		uint8(bl>>8) != want.B {
+		t.Errorf("unexpected node color %x%x%x", r>>8, g>>8, bl>>8)
+	}
+}
@@ -0,0 +1,93 @@
+package diagram
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+)
+
+const (
+	fontFamily  = "Helvetica, Arial, sans-serif"
+	colorNode   = "#0386d2"
+	colorText   = "#ffffff"
+	colorLink   = "#6b7280"
+	colorLabel  = "#374151"
+	colorCanvas = "#ffffff"
+	linkWidth   = 2.0
+	nodeRadius  = 6.0
+)
+
+// RenderSVG writes the laid out graph as an SVG image.
+func RenderSVG(w io.Writer, l *Layout) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" `+
+		`viewBox="0 0 %s %s" font-family="%s">`+"\n",
+		num(l.Width), num(l.Height), num(l.Width), num(l.Height), fontFamily)
+
+	if l.Name != "" {
+		fmt.Fprintf(bw, "  <title>%s</title>\n", html.EscapeString(l.Name))
+	}
+
+	fmt.Fprintf(bw, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", colorCanvas)
+
+	for _, link := range l.Links {
+		fmt.Fprintf(bw, `  <line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+			num(link.From.X), num(link.From.Y), num(link.To.X), num(link.To.Y),
+			colorLink, num(linkWidth))
+	}
+
+	for _, link := range l.Links {
+		writeSVGLabel(bw, link.FromLabel, link.AEndpoint)
+		writeSVGLabel(bw, link.ToLabel, link.BEndpoint)
+	}
+
+	for _, n := range l.Nodes {
+		fmt.Fprintf(bw, `  <rect x="%s" y="%s" width="%s" height="%s" rx="%s" fill="%s"/>`+"\n",
+			num(n.Center.X-n.Width/2), num(n.Center.Y-n.Height/2), num(n.Width), num(n.Height),
+			num(nodeRadius), colorNode)
+
+		nameY := n.Center.Y
+		if n.Kind != "" {
+			nameY -= labelFontSize / 2
+		}
+
+		fmt.Fprintf(bw, `  <text x="%s" y="%s" font-size="%s" fill="%s" text-anchor="middle" `+
+			`dominant-baseline="central" font-weight="bold">%s</text>`+"\n",
+			num(n.Center.X), num(nameY), num(nameFontSize), colorText, html.EscapeString(n.Name))
+
+		if n.Kind != "" {
+			fmt.Fprintf(bw, `  <text x="%s" y="%s" font-size="%s" fill="%s" text-anchor="middle" `+
+				`dominant-baseline="central">%s</text>`+"\n",
+				num(n.Center.X), num(n.Center.Y+nameFontSize/2+1), num(labelFontSize), colorText,
+				html.EscapeString(n.Kind))
+		}
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
+
+func writeSVGLabel(w io.Writer, p Point, text string) {
+	if text == "" {
+		return
+	}
+
+	width := textWidth(text, labelFontSize) + 4
+
+	fmt.Fprintf(w, `  <rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+		num(p.X-width/2), num(p.Y-labelFontSize/2-1), num(width), num(labelFontSize+2),
+		colorCanvas)
+	fmt.Fprintf(w, `  <text x="%s" y="%s" font-size="%s" fill="%s" text-anchor="middle" `+
+		`dominant-baseline="central">%s</text>`+"\n",
+		num(p.X), num(p.Y), num(labelFontSize), colorLabel, html.EscapeString(text))
+}
+
+// num formats the coordinate with up to two decimals.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
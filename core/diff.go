@@ -0,0 +1,445 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/containernetworking/plugins/pkg/ns"
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabnetem "github.com/srl-labs/containerlab/netem"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+// LabDiff is the difference between the topology file and the deployed lab.
+type LabDiff struct {
+	LabName string `json:"lab-name"`
+	// State lists the changes of the topology file since the lab state was recorded,
+	// nil when the lab has no state file
+	State *TopologyChanges `json:"state"`
+	// Runtime lists the differences between the running lab and the topology file,
+	// nil when the lab is not deployed
+	Runtime *TopologyChanges `json:"runtime"`
+}
+
+// Empty reports whether the topology file matches the deployed lab.
+func (d *LabDiff) Empty() bool {
+	return d.State.Empty() && d.Runtime.Empty()
+}
+
+// TopologyChanges lists the changes that turn the deployed lab into the lab
+// the topology file defines, the added entries are defined by the topology file only.
+type TopologyChanges struct {
+	AddedNodes   []string      `json:"added-nodes"`
+	DeletedNodes []string      `json:"deleted-nodes"`
+	ChangedNodes []*NodeChange `json:"changed-nodes"`
+	AddedLinks   []string      `json:"added-links"`
+	DeletedLinks []string      `json:"deleted-links"`
+	// DeletedEndpoints are the link endpoints of the running lab not defined by the topology
+	DeletedEndpoints []string `json:"deleted-endpoints,omitempty"`
+	// Impairments are the netem impairments set on the link endpoints of the running lab,
+	// the topology does not define impairments
+	Impairments []clabtypes.ImpairmentData `json:"impairments,omitempty"`
+}
+
+// Empty reports whether there are no changes.
+func (t *TopologyChanges) Empty() bool {
+	return t == nil ||
+		len(t.AddedNodes) == 0 &&
+			len(t.DeletedNodes) == 0 &&
+			len(t.ChangedNodes) == 0 &&
+			len(t.AddedLinks) == 0 &&
+			len(t.DeletedLinks) == 0 &&
+			len(t.DeletedEndpoints) == 0 &&
+			len(t.Impairments) == 0
+}
+
+// NodeChange lists the changed configuration fields of a node.
+type NodeChange struct {
+	Node   string         `json:"node"`
+	Fields []*FieldChange `json:"fields"`
+}
+
+// FieldChange is the deployed and the defined value of a node configuration field.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+func newTopologyChanges() *TopologyChanges {
+	return &TopologyChanges{
+		AddedNodes:   []string{},
+		DeletedNodes: []string{},
+		ChangedNodes: []*NodeChange{},
+		AddedLinks:   []string{},
+		DeletedLinks: []string{},
+	}
+}
+
+// Diff compares the topology file with the topology recorded in the lab state
+// and with the running lab. Diff does not change the lab.
+func (c *CLab) Diff(ctx context.Context) (*LabDiff, error) {
+	diff := &LabDiff{LabName: c.Config.Name}
+
+	state, err := c.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	if state != nil && state.Topology != nil {
+		diff.State = c.stateDiff(state.Topology)
+	}
+
+	currentNodes, err := c.runtimeNodeGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := false
+	for _, group := range currentNodes {
+		if !group.external && !group.rootNamespaceBased {
+			deployed = true
+
+			break
+		}
+	}
+
+	if !deployed {
+		return diff, nil
+	}
+
+	diff.Runtime, err = c.runtimeDiff(ctx, currentNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// stateDiff compares the topology file with the topology the lab was deployed with.
+func (c *CLab) stateDiff(stateTopo *clabtypes.Topology) *TopologyChanges {
+	changes := newTopologyChanges()
+
+	for _, nodeName := range sortedNodeNames(c.Nodes) {
+		if _, exists := stateTopo.Nodes[nodeName]; !exists {
+			changes.AddedNodes = append(changes.AddedNodes, nodeName)
+
+			continue
+		}
+
+		oldCfg := c.resolveNodeConfigFromTopology(stateTopo, nodeName)
+		newCfg := c.resolveNodeConfigFromTopology(c.Config.Topology, nodeName)
+
+		if change := nodeConfigChange(c.Nodes[nodeName], nodeName, oldCfg, newCfg); change != nil {
+			changes.ChangedNodes = append(changes.ChangedNodes, change)
+		}
+	}
+
+	for nodeName := range stateTopo.Nodes {
+		if _, exists := c.Nodes[nodeName]; !exists {
+			changes.DeletedNodes = append(changes.DeletedNodes, nodeName)
+		}
+	}
+
+	sort.Strings(changes.DeletedNodes)
+
+	oldLinks := linkDefinitionNames(stateTopo.Links)
+	newLinks := linkDefinitionNames(c.Config.Topology.Links)
+
+	changes.AddedLinks = stringSetDifference(newLinks, oldLinks)
+	changes.DeletedLinks = stringSetDifference(oldLinks, newLinks)
+
+	return changes
+}
+
+// runtimeDiff compares the topology file with the running lab. The links are compared
+// with the interfaces discovered by the apply planner, the node configuration is compared
+// with the configuration of the node containers.
+func (c *CLab) runtimeDiff(
+	ctx context.Context,
+	currentNodes map[string]*runtimeNodeGroup,
+) (*TopologyChanges, error) {
+	if err := c.resolveApplyTopology(ctx, currentNodes); err != nil {
+		return nil, err
+	}
+
+	// without the state the plan holds the runtime changes only
+	plan, err := c.planApplyWithState(ctx, currentNodes, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := newTopologyChanges()
+	changes.AddedNodes = sortedStringSet(plan.addedNodeSet)
+	changes.DeletedNodes = sortedStringSet(plan.deletedNodeSet)
+
+	for _, nodeName := range sortedRuntimeNodeGroupNames(currentNodes) {
+		node, exists := c.Nodes[nodeName]
+		if !exists || plan.isNonContainerNode(nodeName) {
+			continue
+		}
+
+		group := currentNodes[nodeName]
+		if len(group.containers) == 0 {
+			continue
+		}
+
+		change := containerConfigChange(node, nodeName, &group.containers[0])
+
+		if _, stopped := plan.startNodeSet[nodeName]; stopped {
+			if change == nil {
+				change = &NodeChange{Node: nodeName}
+			}
+
+			change.Fields = append(change.Fields, &FieldChange{
+				Field: "State",
+				Old:   group.containers[0].State,
+				New:   "running",
+			})
+		}
+
+		if change != nil {
+			changes.ChangedNodes = append(changes.ChangedNodes, change)
+		}
+	}
+
+	for _, linkIdx := range sortedLinkIndexes(c.Links) {
+		if link := c.Links[linkIdx]; plan.linkNeedsDeploy(link) {
+			changes.AddedLinks = append(changes.AddedLinks, applyLinkName(link))
+		}
+	}
+
+	for _, ref := range plan.staleEndpoints {
+		// the derived host endpoints are only removed when they exist
+		if ref.bestEffort {
+			continue
+		}
+
+		changes.DeletedEndpoints = append(changes.DeletedEndpoints, ref.key.String())
+	}
+
+	changes.Impairments = c.endpointImpairments(ctx, plan)
+
+	return changes, nil
+}
+
+// containerConfigChange compares the node configuration with the configuration of its
+// container. The environment variables and the binds of the container are compared only
+// when the runtime reports them and only those the node defines, the container has the
+// variables and the binds set by the image and containerlab as well.
+func containerConfigChange(
+	node clabnodes.Node,
+	nodeName string,
+	ctr *clabruntime.GenericContainer,
+) *NodeChange {
+	cfg := node.Config()
+
+	desired := &clabtypes.NodeConfig{Image: cfg.Image}
+	live := &clabtypes.NodeConfig{Image: ctr.Image}
+
+	if ctr.Config != nil {
+		// the listed image is the image id when the image tag is moved to another image
+		live.Image = ctr.Config.Image
+
+		desired.Env = cfg.Env
+		desired.Binds = cfg.Binds
+
+		liveEnv := map[string]string{}
+		for _, kv := range ctr.Config.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			liveEnv[k] = v
+		}
+
+		live.Env = map[string]string{}
+		for k := range cfg.Env {
+			if v, exists := liveEnv[k]; exists {
+				live.Env[k] = v
+			}
+		}
+
+		liveBinds := map[string]struct{}{}
+		for _, bind := range ctr.Config.Binds {
+			liveBinds[bind] = struct{}{}
+		}
+
+		for _, bind := range cfg.Binds {
+			if _, exists := liveBinds[bind]; exists {
+				live.Binds = append(live.Binds, bind)
+			}
+		}
+	}
+
+	return nodeConfigChange(node, nodeName, live, desired)
+}
+
+// nodeConfigChange returns the fields of the node configuration changed between the old
+// and the new configuration, nil when the configuration is not changed.
+func nodeConfigChange(
+	node clabnodes.Node,
+	nodeName string,
+	oldCfg, newCfg *clabtypes.NodeConfig,
+) *NodeChange {
+	diff := node.ComputeDiff(oldCfg, newCfg)
+	if !diff.HasDiff() {
+		return nil
+	}
+
+	change := &NodeChange{Node: nodeName}
+	seen := map[string]struct{}{}
+
+	for _, field := range diff.Fields {
+		if _, exists := seen[field]; exists {
+			continue
+		}
+
+		seen[field] = struct{}{}
+
+		change.Fields = append(change.Fields, &FieldChange{
+			Field: field,
+			Old:   nodeConfigField(oldCfg, field),
+			New:   nodeConfigField(newCfg, field),
+		})
+	}
+
+	return change
+}
+
+// nodeConfigField returns the value of the node configuration field
+// named as the fields of the topology diff.
+func nodeConfigField(cfg *clabtypes.NodeConfig, field string) any {
+	switch field {
+	case "Type":
+		return cfg.NodeType
+	case "Hostname":
+		return cfg.GetHostname()
+	case "Ports":
+		ports := make([]string, 0, len(cfg.PortSet))
+		for port := range cfg.PortSet {
+			ports = append(ports, string(port))
+		}
+
+		sort.Strings(ports)
+
+		return ports
+	}
+
+	v := reflect.ValueOf(cfg).Elem().FieldByName(field)
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+// endpointImpairments returns the netem impairments set on the link endpoints
+// in the network namespaces of the lab nodes.
+func (c *CLab) endpointImpairments(
+	ctx context.Context,
+	plan *applyPlan,
+) []clabtypes.ImpairmentData {
+	endpoints := map[string]map[int]string{}
+
+	for key, iface := range plan.liveEndpointInfo {
+		if _, exists := c.Nodes[key.node]; !exists {
+			continue
+		}
+
+		if endpoints[key.node] == nil {
+			endpoints[key.node] = map[int]string{}
+		}
+
+		endpoints[key.node][iface.Index] = key.String()
+	}
+
+	var impairments []clabtypes.ImpairmentData
+
+	for nodeName, ifaces := range endpoints {
+		node, exists := plan.endpointNode(nodeName)
+		if !exists {
+			continue
+		}
+
+		err := node.ExecFunction(ctx, func(netns ns.NetNS) error {
+			tcnl, err := clabnetem.NewTC(int(netns.Fd()))
+			if err != nil {
+				return err
+			}
+
+			defer tcnl.Close()
+
+			qdiscs, err := clabnetem.Impairments(tcnl)
+			if err != nil {
+				return err
+			}
+
+			for idx := range qdiscs {
+				endpoint, exists := ifaces[int(qdiscs[idx].Ifindex)]
+				if !exists || qdiscs[idx].Attribute.Kind != "netem" {
+					continue
+				}
+
+				impairment := clabnetem.ImpairmentData(&qdiscs[idx])
+				impairment.Interface = endpoint
+				impairments = append(impairments, impairment)
+			}
+
+			return nil
+		})
+		if err != nil {
+			log.Warn("Failed to read link impairments", "node", nodeName, "err", err)
+		}
+	}
+
+	sort.Slice(impairments, func(i, j int) bool {
+		return impairments[i].Interface < impairments[j].Interface
+	})
+
+	return impairments
+}
+
+// linkDefinitionNames returns the names of the links defined in the topology
+// in the format of the apply link names.
+func linkDefinitionNames(links []*clablinks.LinkDefinition) map[string]struct{} {
+	names := map[string]struct{}{}
+
+	for _, ld := range links {
+		var endpoints []string
+
+		for _, ep := range ld.RawEndpoints() {
+			if ep == nil || ep.Node == "" || ep.Iface == "" {
+				continue
+			}
+
+			endpoints = append(endpoints, applyEndpointKey{node: ep.Node, iface: ep.Iface}.String())
+		}
+
+		if len(endpoints) == 0 {
+			continue
+		}
+
+		sort.Strings(endpoints)
+		names[strings.Join(endpoints, " -- ")] = struct{}{}
+	}
+
+	return names
+}
+
+// stringSetDifference returns the sorted values of a that are not in b.
+func stringSetDifference(a, b map[string]struct{}) []string {
+	result := []string{}
+
+	for value := range a {
+		if _, exists := b[value]; !exists {
+			result = append(result, value)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabmocksmocknodes "github.com/srl-labs/containerlab/mocks/mocknodes"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/yaml.v2"
+)
+
+func diffTestTopology(t *testing.T, topo string) *clabtypes.Topology {
+	t.Helper()
+
+	result := clabtypes.NewTopology()
+	if err := yaml.Unmarshal([]byte(topo), result); err != nil {
+		t.Fatalf("failed to unmarshal topology: %v", err)
+	}
+
+	return result
+}
+
+func diffTestNode(ctrl *gomock.Controller, cfg *clabtypes.NodeConfig) clabnodes.Node {
+	node := clabmocksmocknodes.NewMockNode(ctrl)
+	node.EXPECT().Config().Return(cfg).AnyTimes()
+	node.EXPECT().ComputeDiff(gomock.Any(), gomock.Any()).
+		DoAndReturn((&clabnodes.DefaultNode{}).ComputeDiff).AnyTimes()
+
+	return node
+}
+
+func TestStateDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	stateTopo := diffTestTopology(t, `
+kinds:
+  linux:
+    image: alpine:3
+nodes:
+  n1:
+    kind: linux
+  n2:
+    kind: linux
+  n3:
+    kind: linux
+links:
+  - endpoints: ["n1:eth1", "n2:eth1"]
+  - endpoints: ["n2:eth2", "n3:eth1"]
+`)
+
+	topo := diffTestTopology(t, `
+kinds:
+  linux:
+    image: alpine:3
+nodes:
+  n1:
+    kind: linux
+    image: alpine:4
+    env:
+      DEBUG: "1"
+  n2:
+    kind: linux
+  n4:
+    kind: linux
+links:
+  - endpoints: ["n2:eth1", "n1:eth1"]
+  - endpoints: ["n2:eth2", "n4:eth1"]
+`)
+
+	c := &CLab{
+		Config: &Config{Topology: topo},
+		Nodes: map[string]clabnodes.Node{
+			"n1": diffTestNode(ctrl, nil),
+			"n2": diffTestNode(ctrl, nil),
+			"n4": diffTestNode(ctrl, nil),
+		},
+	}
+
+	want := &TopologyChanges{
+		AddedNodes:   []string{"n4"},
+		DeletedNodes: []string{"n3"},
+		ChangedNodes: []*NodeChange{
+			{
+				Node: "n1",
+				Fields: []*FieldChange{
+					{Field: "Image", Old: "alpine:3", New: "alpine:4"},
+					{Field: "Env", Old: map[string]string{}, New: map[string]string{"DEBUG": "1"}},
+				},
+			},
+		},
+		AddedLinks:   []string{"n2:eth2 -- n4:eth1"},
+		DeletedLinks: []string{"n2:eth2 -- n3:eth1"},
+	}
+
+	if d := cmp.Diff(want, c.stateDiff(stateTopo)); d != "" {
+		t.Errorf("state diff mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestContainerConfigChange(t *testing.T) {
+	cfg := &clabtypes.NodeConfig{
+		Image: "alpine:3",
+		Env:   map[string]string{"A": "1", "B": "2"},
+		Binds: []string{"/tmp/a:/a", "/tmp/b:/b:ro"},
+	}
+
+	tests := map[string]struct {
+		ctr  *clabruntime.GenericContainer
+		want *NodeChange
+	}{
+		"in-sync": {
+			ctr: &clabruntime.GenericContainer{
+				Image: "sha256:0123",
+				Config: &clabruntime.ContainerConfig{
+					Image: "alpine:3",
+					Env:   []string{"PATH=/bin", "A=1", "B=2", "CLAB_LABEL=x"},
+					Binds: []string{"/tmp/b:/b:ro", "/tmp/a:/a", "/etc/hosts:/etc/hosts"},
+				},
+			},
+		},
+		"drift": {
+			ctr: &clabruntime.GenericContainer{
+				Config: &clabruntime.ContainerConfig{
+					Image: "alpine:2",
+					Env:   []string{"A=1", "B=3"},
+					Binds: []string{"/tmp/a:/a"},
+				},
+			},
+			want: &NodeChange{
+				Node: "n1",
+				Fields: []*FieldChange{
+					{Field: "Image", Old: "alpine:2", New: "alpine:3"},
+					{
+						Field: "Env",
+						Old:   map[string]string{"A": "1", "B": "3"},
+						New:   map[string]string{"A": "1", "B": "2"},
+					},
+					{
+						Field: "Binds",
+						Old:   []string{"/tmp/a:/a"},
+						New:   []string{"/tmp/a:/a", "/tmp/b:/b:ro"},
+					},
+				},
+			},
+		},
+		"config-not-reported": {
+			ctr: &clabruntime.GenericContainer{Image: "alpine:3"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			node := diffTestNode(gomock.NewController(t), cfg)
+
+			got := containerConfigChange(node, "n1", tt.ctr)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("node change mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
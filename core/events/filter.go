@@ -0,0 +1,216 @@
+package events
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcore "github.com/srl-labs/containerlab/core"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+const filterAttributePrefix = "attr."
+
+// filterAttributeAliases map the attribute names accepted in the filters
+// to the names of the event attributes.
+var filterAttributeAliases = map[string]string{ //nolint:gochecknoglobals
+	"oper_state": "state",
+}
+
+// eventFilter matches the events against a set of filter expressions. An event matches the
+// filter if it matches any of the expressions, and it matches an expression if it matches all
+// of its conditions. An empty filter matches all events.
+type eventFilter struct {
+	expressions [][]filterCondition
+	// nodes are the node identities keyed by the container ID, the interface events
+	// only carry the lab and the container name
+	nodes map[string]nodeIdentity
+}
+
+type nodeIdentity struct {
+	lab  string
+	node string
+	kind string
+}
+
+type filterCondition struct {
+	key     string
+	pattern string
+	negated bool
+}
+
+// parseEventFilter parses the filter expressions, every expression is a comma separated list
+// of key=value or key!=value conditions, e.g. type=interface,action=update,attr.state=down.
+// The keys are lab, node, kind, type, action and attr.<attribute>, the values are matched
+// as shell patterns, e.g. node=leaf*.
+func parseEventFilter(expressions []string) (*eventFilter, error) {
+	filter := &eventFilter{nodes: make(map[string]nodeIdentity)}
+
+	for _, expression := range expressions {
+		var conditions []filterCondition
+
+		for _, cond := range strings.Split(expression, ",") {
+			cond = strings.TrimSpace(cond)
+			if cond == "" {
+				continue
+			}
+
+			key, pattern, ok := strings.Cut(cond, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid event filter condition %q, expected key=value",
+					cond)
+			}
+
+			c := filterCondition{key: strings.TrimSpace(key), pattern: strings.TrimSpace(pattern)}
+
+			if strings.HasSuffix(c.key, "!") {
+				c.key = strings.TrimSpace(strings.TrimSuffix(c.key, "!"))
+				c.negated = true
+			}
+
+			if err := validateFilterKey(c.key); err != nil {
+				return nil, err
+			}
+
+			if _, err := path.Match(c.pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid event filter pattern %q: %w", c.pattern, err)
+			}
+
+			if attr, ok := strings.CutPrefix(c.key, filterAttributePrefix); ok {
+				attr = strings.ReplaceAll(attr, "-", "_")
+				if alias, ok := filterAttributeAliases[attr]; ok {
+					attr = alias
+				}
+
+				c.key = filterAttributePrefix + attr
+			}
+
+			conditions = append(conditions, c)
+		}
+
+		if len(conditions) > 0 {
+			filter.expressions = append(filter.expressions, conditions)
+		}
+	}
+
+	return filter, nil
+}
+
+func validateFilterKey(key string) error {
+	switch key {
+	case "lab", "node", "kind", "type", "action":
+		return nil
+	}
+
+	if attr, ok := strings.CutPrefix(key, filterAttributePrefix); ok && attr != "" {
+		return nil
+	}
+
+	return fmt.Errorf("unknown event filter key %q, expected one of lab, node, kind, "+
+		"type, action or attr.<name>", key)
+}
+
+// observeContainers records the node identities of the containers present
+// when the stream starts.
+func (f *eventFilter) observeContainers(containers []clabruntime.GenericContainer) {
+	for idx := range containers {
+		f.observeNode(containers[idx].ID, containers[idx].Labels)
+	}
+}
+
+func (f *eventFilter) observeNode(id string, labels map[string]string) {
+	if id == "" {
+		return
+	}
+
+	lab, node := clabcore.TopoIdentity(labels)
+	if lab == "" && node == "" {
+		return
+	}
+
+	f.nodes[id] = nodeIdentity{lab: lab, node: node, kind: labels[clabconstants.NodeKind]}
+}
+
+// match reports whether the event matches the filter, it is only called from the stream
+// loop, so the filter is not guarded with a mutex.
+func (f *eventFilter) match(ev aggregatedEvent) bool {
+	if len(f.expressions) == 0 {
+		return true
+	}
+
+	identity := f.identity(ev)
+
+	if ev.Type == clabruntime.EventTypeContainer && ev.Action == clabruntime.EventActionDestroy {
+		delete(f.nodes, ev.ActorFullID)
+	}
+
+	for _, conditions := range f.expressions {
+		if matchConditions(conditions, ev, identity) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// identity returns the node identity of the event actor.
+func (f *eventFilter) identity(ev aggregatedEvent) nodeIdentity {
+	if ev.Type == clabruntime.EventTypeContainer {
+		f.observeNode(ev.ActorFullID, ev.Attributes)
+	}
+
+	identity, ok := f.nodes[ev.ActorFullID]
+	if !ok {
+		// the lifecycle events carry the node identity in the attributes, the events of
+		// the containers not yet observed fall back to the lab and the container name
+		identity = nodeIdentity{
+			lab:  ev.Attributes["lab"],
+			node: ev.Attributes["node"],
+			kind: ev.Attributes["kind"],
+		}
+
+		// the actor of the lab-wide lifecycle events is the lab
+		if identity.node == "" && ev.Type != lifecycleEventType {
+			identity.node = ev.ActorName
+		}
+	}
+
+	return identity
+}
+
+func matchConditions(
+	conditions []filterCondition,
+	ev aggregatedEvent,
+	identity nodeIdentity,
+) bool {
+	for _, c := range conditions {
+		// the patterns are validated when the filter is parsed
+		matched, _ := path.Match(c.pattern, eventFilterValue(c.key, ev, identity))
+		if matched == c.negated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// eventFilterValue returns the value of the event the filter key refers to.
+func eventFilterValue(key string, ev aggregatedEvent, identity nodeIdentity) string {
+	switch key {
+	case "type":
+		return ev.Type
+	case "action":
+		return ev.Action
+	case "lab":
+		return identity.lab
+	case "node":
+		return identity.node
+	case "kind":
+		return identity.kind
+	}
+
+	attr := strings.TrimPrefix(key, filterAttributePrefix)
+
+	return mergedEventAttributes(ev)[attr]
+}
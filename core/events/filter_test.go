@@ -0,0 +1,202 @@
+package events
+
+import (
+	"testing"
+
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+func TestEventFilter(t *testing.T) {
+	containers := []clabruntime.GenericContainer{{
+		ID: "c1",
+		Labels: map[string]string{
+			clabconstants.Containerlab: "lab1",
+			clabconstants.NodeName:     "leaf1",
+			clabconstants.NodeKind:     "nokia_srlinux",
+		},
+	}}
+
+	linkDown := aggregatedEvent{
+		Type:        "interface",
+		Action:      "update",
+		ActorName:   "clab-lab1-leaf1",
+		ActorFullID: "c1",
+		Attributes:  map[string]string{"ifname": "e1-1", "lab": "lab1", "state": "down"},
+	}
+
+	// the interface events of the containers not yet observed resolve
+	// to the container name
+	unknownLinkUp := aggregatedEvent{
+		Type:        "interface",
+		Action:      "update",
+		ActorName:   "clab-lab2-spine1",
+		ActorFullID: "c2",
+		Attributes:  map[string]string{"ifname": "e1-1", "lab": "lab2", "state": "up"},
+	}
+
+	containerStart := aggregatedEvent{
+		Type:        clabruntime.EventTypeContainer,
+		Action:      clabruntime.EventActionStart,
+		ActorName:   "clab-lab2-spine1",
+		ActorFullID: "c2",
+		Attributes: map[string]string{
+			clabconstants.Containerlab: "lab2",
+			clabconstants.NodeName:     "spine1",
+			clabconstants.NodeKind:     "linux",
+		},
+	}
+
+	nodeStage := aggregatedEvent{
+		Type:      lifecycleEventType,
+		Action:    "node_stage",
+		ActorName: "clab-lab3-r1",
+		Attributes: map[string]string{
+			"lab": "lab3", "node": "r1", "kind": "linux", "stage": "configure",
+		},
+	}
+
+	deployStart := aggregatedEvent{
+		Type:       lifecycleEventType,
+		Action:     "deploy_start",
+		ActorName:  "lab3",
+		Attributes: map[string]string{"lab": "lab3"},
+	}
+
+	tests := map[string]struct {
+		filters []string
+		ev      aggregatedEvent
+		want    bool
+	}{
+		"no filter": {
+			ev:   linkDown,
+			want: true,
+		},
+		"interface down": {
+			filters: []string{"type=interface,action=update,attr.oper-state=down"},
+			ev:      linkDown,
+			want:    true,
+		},
+		"interface up": {
+			filters: []string{"type=interface,action=update,attr.oper-state=down"},
+			ev:      unknownLinkUp,
+			want:    false,
+		},
+		"node and kind of interface event": {
+			filters: []string{"lab=lab1,node=leaf*,kind=nokia_srlinux"},
+			ev:      linkDown,
+			want:    true,
+		},
+		"node of unknown container": {
+			filters: []string{"node=clab-lab2-spine1"},
+			ev:      unknownLinkUp,
+			want:    true,
+		},
+		"node of container event": {
+			filters: []string{"lab=lab2,node=spine1,kind=linux"},
+			ev:      containerStart,
+			want:    true,
+		},
+		"negated condition": {
+			filters: []string{"type!=interface"},
+			ev:      linkDown,
+			want:    false,
+		},
+		"any of the expressions": {
+			filters: []string{"lab=lab2", "attr.ifname=e1-1"},
+			ev:      linkDown,
+			want:    true,
+		},
+		"lifecycle event of node": {
+			filters: []string{"type=clab,lab=lab3,node=r1,kind=linux,attr.stage=configure"},
+			ev:      nodeStage,
+			want:    true,
+		},
+		"lab-wide lifecycle event": {
+			filters: []string{"node=lab3"},
+			ev:      deployStart,
+			want:    false,
+		},
+		"merged name attribute": {
+			filters: []string{"attr.name=clab-lab1-leaf1"},
+			ev:      linkDown,
+			want:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := parseEventFilter(tt.filters)
+			if err != nil {
+				t.Fatalf("failed to parse filter: %v", err)
+			}
+
+			f.observeContainers(containers)
+
+			if got := f.match(tt.ev); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventFilterTracksContainers(t *testing.T) {
+	f, err := parseEventFilter([]string{"node=spine1"})
+	if err != nil {
+		t.Fatalf("failed to parse filter: %v", err)
+	}
+
+	start := aggregatedEvent{
+		Type:        clabruntime.EventTypeContainer,
+		Action:      clabruntime.EventActionStart,
+		ActorFullID: "c2",
+		Attributes: map[string]string{
+			clabconstants.Containerlab: "lab2",
+			clabconstants.NodeName:     "spine1",
+		},
+	}
+
+	linkUp := aggregatedEvent{
+		Type:        "interface",
+		Action:      "create",
+		ActorName:   "clab-lab2-spine1",
+		ActorFullID: "c2",
+		Attributes:  map[string]string{"ifname": "e1-1", "lab": "lab2"},
+	}
+
+	if !f.match(start) {
+		t.Error("expected the container start event to match")
+	}
+
+	if !f.match(linkUp) {
+		t.Error("expected the interface event of the started container to match")
+	}
+
+	destroy := start
+	destroy.Action = clabruntime.EventActionDestroy
+
+	if !f.match(destroy) {
+		t.Error("expected the container destroy event to match")
+	}
+
+	if f.match(linkUp) {
+		t.Error("expected the interface event of the destroyed container not to match")
+	}
+}
+
+func TestParseEventFilterErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing value":   "type",
+		"unknown key":     "image=srlinux",
+		"empty attribute": "attr.=up",
+		"invalid pattern": "node=[leaf",
+	}
+
+	for name, filter := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseEventFilter([]string{filter}); err == nil {
+				t.Errorf("expected an error for the filter %q", filter)
+			}
+		})
+	}
+}
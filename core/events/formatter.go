@@ -79,13 +79,19 @@ func jsonFormatter(w io.Writer) formatter {
 	encoder.SetEscapeHTML(false)
 
 	return func(ev aggregatedEvent) error {
-		copy := ev
-		copy.Attributes = mergedEventAttributes(ev)
-
-		return encoder.Encode(copy)
+		return encoder.Encode(withMergedAttributes(ev))
 	}
 }
 
+// withMergedAttributes returns the event with the actor name and ID merged into the
+// attributes, the way the event is encoded as JSON.
+func withMergedAttributes(ev aggregatedEvent) aggregatedEvent {
+	merged := ev
+	merged.Attributes = mergedEventAttributes(ev)
+
+	return merged
+}
+
 func mergedEventAttributes(ev aggregatedEvent) map[string]string {
 	if len(ev.Attributes) == 0 && ev.ActorName == "" && ev.ActorFullID == "" {
 		return nil
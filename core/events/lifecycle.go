@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
+)
+
+// lifecycleEventType is the type of the lifecycle events published by the containerlab
+// operations on the event bus.
+const lifecycleEventType = "clab"
+
+// forwardLifecycleEvents forwards the lifecycle events received from the event bus
+// to the stream.
+func forwardLifecycleEvents(
+	ctx context.Context,
+	events <-chan clabcorebus.Event,
+	out chan<- aggregatedEvent,
+) {
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+
+			select {
+			case out <- lifecycleEvent(ev):
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func lifecycleEvent(ev clabcorebus.Event) aggregatedEvent {
+	return aggregatedEvent{
+		Timestamp:  ev.Timestamp,
+		Type:       lifecycleEventType,
+		Action:     ev.Action,
+		ActorName:  ev.Actor,
+		Attributes: ev.Attributes,
+	}
+}
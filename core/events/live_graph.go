@@ -0,0 +1,367 @@
+package events
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+	clabcore "github.com/srl-labs/containerlab/core"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+	"golang.org/x/net/websocket"
+)
+
+// liveGraphClientQueueSize is the number of the updates queued for a slow graph client,
+// the updates are dropped for the client once the queue is full.
+const liveGraphClientQueueSize = 256
+
+// Node states sent to the live graph.
+const (
+	liveNodeRunning   = "running"
+	liveNodeStopped   = "stopped"
+	liveNodeDestroyed = "destroyed"
+)
+
+const netemAttributePrefix = "netem_"
+
+// LiveGraph keeps the state of the lab nodes and interfaces observed in the events and
+// streams it over WebSocket to the topology graph. A client receives the current state
+// when it connects and the state updates after it.
+type LiveGraph struct {
+	lab string
+
+	mu sync.Mutex
+	// nodes are the names of the lab nodes keyed by the container ID
+	nodes map[string]string
+	// states are the latest node and interface updates
+	states  map[liveGraphKey]liveGraphUpdate
+	clients map[*liveGraphClient]struct{}
+	closed  bool
+}
+
+// liveGraphKey identifies the node, or the node interface when the interface is set.
+type liveGraphKey struct {
+	node  string
+	iface string
+}
+
+// liveGraphUpdate is the state of a node or of a node interface sent to the graph.
+type liveGraphUpdate struct {
+	// Type is either node or interface
+	Type string `json:"type"`
+	Node string `json:"node"`
+	// Interface is the interface name as it appears in the topology links
+	Interface string `json:"interface,omitempty"`
+	// State is the node state or the interface operational state
+	State string `json:"state,omitempty"`
+	// Health is the container health status of the node
+	Health string  `json:"health,omitempty"`
+	RxBps  float64 `json:"rx_bps,omitempty"`
+	TxBps  float64 `json:"tx_bps,omitempty"`
+	// Netem are the link impairments set on the interface, e.g. delay or loss
+	Netem map[string]string `json:"netem,omitempty"`
+}
+
+type liveGraphClient struct {
+	queue chan liveGraphUpdate
+}
+
+// NewLiveGraph returns the live graph of the lab, it is fed by the event stream when
+// it is set in the stream options.
+func NewLiveGraph(lab string) *LiveGraph {
+	return &LiveGraph{
+		lab:     lab,
+		nodes:   make(map[string]string),
+		states:  make(map[liveGraphKey]liveGraphUpdate),
+		clients: make(map[*liveGraphClient]struct{}),
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection the state updates
+// are sent on as JSON messages.
+func (g *LiveGraph) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	websocket.Handler(g.serve).ServeHTTP(w, r)
+}
+
+func (g *LiveGraph) serve(ws *websocket.Conn) {
+	client := &liveGraphClient{queue: make(chan liveGraphUpdate, liveGraphClientQueueSize)}
+
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+
+		return
+	}
+
+	// the client is registered with the snapshot of the state taken,
+	// so the updates queued while the snapshot is sent follow it
+	snapshot := g.snapshot()
+	g.clients[client] = struct{}{}
+	g.mu.Unlock()
+
+	// the client does not send anything, the read returns when the client disconnects
+	go func() {
+		_, _ = io.Copy(io.Discard, ws)
+
+		g.remove(client)
+	}()
+
+	for _, update := range snapshot {
+		if err := websocket.JSON.Send(ws, update); err != nil {
+			g.remove(client)
+
+			return
+		}
+	}
+
+	for update := range client.queue {
+		if err := websocket.JSON.Send(ws, update); err != nil {
+			g.remove(client)
+
+			return
+		}
+	}
+}
+
+// snapshot returns the latest updates of the nodes followed by their interfaces.
+func (g *LiveGraph) snapshot() []liveGraphUpdate {
+	keys := make([]liveGraphKey, 0, len(g.states))
+	for key := range g.states {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i].iface == "") != (keys[j].iface == "") {
+			return keys[i].iface == ""
+		}
+
+		if keys[i].node != keys[j].node {
+			return keys[i].node < keys[j].node
+		}
+
+		return keys[i].iface < keys[j].iface
+	})
+
+	updates := make([]liveGraphUpdate, 0, len(keys))
+	for _, key := range keys {
+		updates = append(updates, g.states[key])
+	}
+
+	return updates
+}
+
+func (g *LiveGraph) remove(client *liveGraphClient) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.clients[client]; ok {
+		delete(g.clients, client)
+		close(client.queue)
+	}
+}
+
+// observeContainers records the state of the lab containers present when the stream starts.
+func (g *LiveGraph) observeContainers(containers []clabruntime.GenericContainer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for idx := range containers {
+		container := &containers[idx]
+
+		node := g.observeNode(container.ID, container.Labels)
+		if node == "" {
+			continue
+		}
+
+		state := liveNodeStopped
+		if isRunningContainer(container) {
+			state = liveNodeRunning
+		}
+
+		g.update(liveGraphUpdate{
+			Type:   "node",
+			Node:   node,
+			State:  state,
+			Health: healthFromStatus(container.Status),
+		})
+	}
+}
+
+// observeNode records the node name of the lab container and returns it,
+// the name is empty for the containers of the other labs.
+func (g *LiveGraph) observeNode(id string, labels map[string]string) string {
+	lab, node := clabcore.TopoIdentity(labels)
+	if id == "" || lab != g.lab || node == "" {
+		return ""
+	}
+
+	g.nodes[id] = node
+
+	return node
+}
+
+func (g *LiveGraph) Send(ev aggregatedEvent) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch ev.Type {
+	case clabruntime.EventTypeContainer:
+		g.observeContainerEvent(ev)
+	case "interface":
+		g.observeInterfaceEvent(ev)
+	}
+}
+
+func (g *LiveGraph) observeContainerEvent(ev aggregatedEvent) {
+	node := g.observeNode(ev.ActorFullID, ev.Attributes)
+	if node == "" {
+		return
+	}
+
+	current := g.states[liveGraphKey{node: node}]
+	current.Type = "node"
+	current.Node = node
+
+	switch {
+	case ev.Action == clabruntime.EventActionStart, ev.Action == "running":
+		current.State = liveNodeRunning
+	case ev.Action == clabruntime.EventActionDie,
+		ev.Action == clabruntime.EventActionStop,
+		ev.Action == clabruntime.EventActionKill,
+		ev.Action == "exited":
+		current.State = liveNodeStopped
+	case ev.Action == clabruntime.EventActionDestroy:
+		current.State = liveNodeDestroyed
+
+		delete(g.nodes, ev.ActorFullID)
+	case strings.HasPrefix(ev.Action, healthStatusActionPrefix):
+		current.Health = strings.TrimSpace(
+			strings.TrimPrefix(ev.Action, healthStatusActionPrefix))
+	default:
+		return
+	}
+
+	// the container snapshots emitted with the initial state carry the health in the status
+	if health := healthFromStatus(ev.Attributes["status"]); health != "" {
+		current.Health = health
+	}
+
+	g.update(current)
+
+	if current.State != liveNodeRunning {
+		g.downInterfaces(node)
+	}
+}
+
+// downInterfaces reports the interfaces of the node that is not running as down.
+func (g *LiveGraph) downInterfaces(node string) {
+	for key, iface := range g.states {
+		if key.node != node || key.iface == "" || iface.State == "down" {
+			continue
+		}
+
+		iface.State = "down"
+		iface.RxBps = 0
+		iface.TxBps = 0
+
+		g.update(iface)
+	}
+}
+
+func (g *LiveGraph) observeInterfaceEvent(ev aggregatedEvent) {
+	// the interface events are emitted for the running containers, which are observed
+	// from the start event or from the containers present when the stream starts
+	node, ok := g.nodes[ev.ActorFullID]
+	if !ok {
+		return
+	}
+
+	// the links of the topology refer to the interfaces by their alias when it is set
+	iface := ev.Attributes["alias"]
+	if iface == "" {
+		iface = ev.Attributes["ifname"]
+	}
+
+	if iface == "" {
+		return
+	}
+
+	key := liveGraphKey{node: node, iface: iface}
+
+	if ev.Action == "delete" {
+		delete(g.states, key)
+
+		g.broadcast(liveGraphUpdate{Type: "interface", Node: node, Interface: iface, State: "down"})
+
+		return
+	}
+
+	current := g.states[key]
+	current.Type = "interface"
+	current.Node = node
+	current.Interface = iface
+	current.State = strings.ToLower(ev.Attributes["state"])
+	current.Netem = netemFromAttributes(ev.Attributes)
+
+	if ev.Action == "stats" {
+		current.RxBps, _ = strconv.ParseFloat(ev.Attributes["rx_bps"], 64)
+		current.TxBps, _ = strconv.ParseFloat(ev.Attributes["tx_bps"], 64)
+	}
+
+	g.update(current)
+}
+
+// netemFromAttributes returns the netem attributes of the interface event
+// without the netem_ prefix.
+func netemFromAttributes(attributes map[string]string) map[string]string {
+	var netem map[string]string
+
+	for key, value := range attributes {
+		name, ok := strings.CutPrefix(key, netemAttributePrefix)
+		if !ok {
+			continue
+		}
+
+		if netem == nil {
+			netem = make(map[string]string)
+		}
+
+		netem[name] = value
+	}
+
+	return netem
+}
+
+// update stores the update and sends it to the clients, it is called with the mutex held.
+func (g *LiveGraph) update(u liveGraphUpdate) {
+	g.states[liveGraphKey{node: u.Node, iface: u.Interface}] = u
+
+	g.broadcast(u)
+}
+
+func (g *LiveGraph) broadcast(u liveGraphUpdate) {
+	for client := range g.clients {
+		select {
+		case client.queue <- u:
+		default:
+			log.Debug("Dropping live graph update for a slow client")
+		}
+	}
+}
+
+func (g *LiveGraph) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.closed = true
+
+	for client := range g.clients {
+		delete(g.clients, client)
+		close(client.queue)
+	}
+
+	return nil
+}
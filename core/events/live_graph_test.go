@@ -0,0 +1,132 @@
+package events
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+	"golang.org/x/net/websocket"
+)
+
+func TestLiveGraph(t *testing.T) {
+	g := NewLiveGraph("lab1")
+	defer g.Close()
+
+	g.observeContainers([]clabruntime.GenericContainer{
+		{
+			ID:     "c1",
+			State:  "running",
+			Status: "Up 5 minutes (unhealthy)",
+			Labels: map[string]string{
+				clabconstants.Containerlab: "lab1",
+				clabconstants.NodeName:     "leaf1",
+			},
+		},
+		{
+			ID:    "c2",
+			State: "running",
+			Labels: map[string]string{
+				clabconstants.Containerlab: "lab2",
+				clabconstants.NodeName:     "leaf1",
+			},
+		},
+	})
+
+	g.Send(aggregatedEvent{
+		Type:        "interface",
+		Action:      "stats",
+		ActorFullID: "c1",
+		Attributes: map[string]string{
+			"ifname":      "e1-1",
+			"alias":       "ethernet-1/1",
+			"state":       "up",
+			"rx_bps":      "800",
+			"tx_bps":      "1600",
+			"netem_delay": "10ms",
+		},
+	})
+
+	// the interfaces of the other labs are not part of the graph
+	g.Send(aggregatedEvent{
+		Type:        "interface",
+		Action:      "update",
+		ActorFullID: "c2",
+		Attributes:  map[string]string{"ifname": "e1-1", "state": "up"},
+	})
+
+	srv := httptest.NewServer(g)
+	defer srv.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	ws, err := websocket.Dial(wsURL, "", srv.URL)
+	if err != nil {
+		t.Fatalf("failed to connect to the live graph: %v", err)
+	}
+	defer ws.Close()
+
+	receive := func() liveGraphUpdate {
+		t.Helper()
+
+		if err := ws.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatalf("failed to set the read deadline: %v", err)
+		}
+
+		var u liveGraphUpdate
+		if err := websocket.JSON.Receive(ws, &u); err != nil {
+			t.Fatalf("failed to receive the live graph update: %v", err)
+		}
+
+		return u
+	}
+
+	want := []liveGraphUpdate{
+		{Type: "node", Node: "leaf1", State: "running", Health: "unhealthy"},
+		{
+			Type:      "interface",
+			Node:      "leaf1",
+			Interface: "ethernet-1/1",
+			State:     "up",
+			RxBps:     800,
+			TxBps:     1600,
+			Netem:     map[string]string{"delay": "10ms"},
+		},
+	}
+
+	for _, w := range want {
+		if diff := cmp.Diff(w, receive()); diff != "" {
+			t.Errorf("unexpected initial state (-want +got):\n%s", diff)
+		}
+	}
+
+	g.Send(aggregatedEvent{
+		Type:        clabruntime.EventTypeContainer,
+		Action:      clabruntime.EventActionDie,
+		ActorFullID: "c1",
+		Attributes: map[string]string{
+			clabconstants.Containerlab: "lab1",
+			clabconstants.NodeName:     "leaf1",
+		},
+	})
+
+	want = []liveGraphUpdate{
+		{Type: "node", Node: "leaf1", State: "stopped", Health: "unhealthy"},
+		{
+			Type:      "interface",
+			Node:      "leaf1",
+			Interface: "ethernet-1/1",
+			State:     "down",
+			Netem:     map[string]string{"delay": "10ms"},
+		},
+	}
+
+	for _, w := range want {
+		if diff := cmp.Diff(w, receive()); diff != "" {
+			t.Errorf("unexpected update (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,480 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcore "github.com/srl-labs/containerlab/core"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+const (
+	metricsPath              = "/metrics"
+	metricsReadHeaderTimeout = 10 * time.Second
+	metricsShutdownTimeout   = 5 * time.Second
+
+	healthStatusActionPrefix = "health_status:"
+)
+
+// metricsCollector keeps the latest node and interface state observed in the events
+// and exposes it in the Prometheus text format.
+type metricsCollector struct {
+	mu sync.Mutex
+	// nodes are keyed by the container ID
+	nodes map[string]*nodeMetrics
+}
+
+type nodeMetrics struct {
+	lab     string
+	node    string
+	kind    string
+	running bool
+	// health is the container health status, empty when the container has no health check
+	health   string
+	started  bool
+	restarts uint64
+	// interfaces are keyed by the interface name
+	interfaces map[string]*interfaceMetrics
+}
+
+type interfaceMetrics struct {
+	alias    string
+	operUp   bool
+	hasStats bool
+	// counters are keyed by the event attribute name, e.g. rx_bytes
+	counters map[string]uint64
+	netem    netemMetrics
+}
+
+type netemMetrics struct {
+	delay      float64
+	jitter     float64
+	loss       float64
+	corruption float64
+	rate       float64
+}
+
+// interfaceCounters are the interface statistics exported as counters,
+// keyed by the event attribute name.
+var interfaceCounters = []struct { //nolint:gochecknoglobals
+	attribute string
+	help      string
+}{
+	{attribute: "rx_bytes", help: "Bytes received by the interface."},
+	{attribute: "tx_bytes", help: "Bytes transmitted by the interface."},
+	{attribute: "rx_packets", help: "Packets received by the interface."},
+	{attribute: "tx_packets", help: "Packets transmitted by the interface."},
+	{attribute: "rx_errors", help: "Receive errors of the interface."},
+	{attribute: "tx_errors", help: "Transmit errors of the interface."},
+	{attribute: "rx_dropped", help: "Received packets dropped by the interface."},
+	{attribute: "tx_dropped", help: "Transmitted packets dropped by the interface."},
+}
+
+func newMetricsCollector() *metricsCollector {
+	return &metricsCollector{
+		nodes: make(map[string]*nodeMetrics),
+	}
+}
+
+// observeContainers records the state of the containers present when the stream starts.
+func (m *metricsCollector) observeContainers(containers []clabruntime.GenericContainer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for idx := range containers {
+		container := &containers[idx]
+		if container.ID == "" {
+			continue
+		}
+
+		n := m.node(container.ID, container.Labels)
+		n.running = isRunningContainer(container)
+		n.started = n.running
+		n.health = healthFromStatus(container.Status)
+	}
+}
+
+// observe updates the metrics with the event.
+func (m *metricsCollector) observe(ev aggregatedEvent) {
+	id := ev.ActorFullID
+	if id == "" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch ev.Type {
+	case clabruntime.EventTypeContainer:
+		m.observeContainerEvent(id, ev)
+	case "interface":
+		m.observeInterfaceEvent(id, ev)
+	}
+}
+
+func (m *metricsCollector) observeContainerEvent(id string, ev aggregatedEvent) {
+	if ev.Action == clabruntime.EventActionDestroy {
+		delete(m.nodes, id)
+
+		return
+	}
+
+	n := m.node(id, ev.Attributes)
+
+	switch {
+	case ev.Action == clabruntime.EventActionStart:
+		// a container started again after it was running is counted as restarted,
+		// the restart action is not counted as it follows the start of the container
+		if n.started {
+			n.restarts++
+		}
+
+		n.started = true
+		n.running = true
+	case ev.Action == "running":
+		// container snapshot emitted with the initial state
+		n.started = true
+		n.running = true
+	case ev.Action == clabruntime.EventActionDie,
+		ev.Action == clabruntime.EventActionStop,
+		ev.Action == clabruntime.EventActionKill,
+		ev.Action == "exited":
+		n.running = false
+	case strings.HasPrefix(ev.Action, healthStatusActionPrefix):
+		n.health = strings.TrimSpace(strings.TrimPrefix(ev.Action, healthStatusActionPrefix))
+	}
+}
+
+func (m *metricsCollector) observeInterfaceEvent(id string, ev aggregatedEvent) {
+	name := ev.Attributes["ifname"]
+	if name == "" {
+		return
+	}
+
+	n, ok := m.nodes[id]
+	if !ok {
+		// the interface events are only emitted for the running containers and carry the lab
+		// and the container name, they are replaced with the node labels once the container
+		// is observed
+		n = m.node(id, map[string]string{
+			clabconstants.Containerlab: ev.Attributes["lab"],
+			clabconstants.NodeName:     ev.ActorName,
+		})
+		n.running = true
+	}
+
+	if ev.Action == "delete" {
+		delete(n.interfaces, name)
+
+		return
+	}
+
+	iface, ok := n.interfaces[name]
+	if !ok {
+		iface = &interfaceMetrics{counters: make(map[string]uint64)}
+		n.interfaces[name] = iface
+	}
+
+	iface.alias = ev.Attributes["alias"]
+	iface.operUp = strings.EqualFold(ev.Attributes["state"], "up")
+	iface.netem = netemMetricsFromAttributes(ev.Attributes)
+
+	if ev.Action != "stats" {
+		return
+	}
+
+	iface.hasStats = true
+
+	for _, counter := range interfaceCounters {
+		if v, err := strconv.ParseUint(ev.Attributes[counter.attribute], 10, 64); err == nil {
+			iface.counters[counter.attribute] = v
+		}
+	}
+}
+
+// node returns the metrics of the node running in the container,
+// the node identity is updated from the container labels.
+func (m *metricsCollector) node(id string, labels map[string]string) *nodeMetrics {
+	n, ok := m.nodes[id]
+	if !ok {
+		n = &nodeMetrics{interfaces: make(map[string]*interfaceMetrics)}
+		m.nodes[id] = n
+	}
+
+	lab, node := clabcore.TopoIdentity(labels)
+	if lab != "" {
+		n.lab = lab
+	}
+
+	if node != "" {
+		n.node = node
+	}
+
+	if kind := labels[clabconstants.NodeKind]; kind != "" {
+		n.kind = kind
+	}
+
+	return n
+}
+
+// healthFromStatus returns the health status from the container status,
+// e.g. "Up 5 minutes (healthy)".
+func healthFromStatus(status string) string {
+	switch {
+	case strings.Contains(status, "(healthy)"):
+		return "healthy"
+	case strings.Contains(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(status, "(health: starting)"):
+		return "starting"
+	default:
+		return ""
+	}
+}
+
+func netemMetricsFromAttributes(attributes map[string]string) netemMetrics {
+	var netem netemMetrics
+
+	if d, err := time.ParseDuration(attributes["netem_delay"]); err == nil {
+		netem.delay = d.Seconds()
+	}
+
+	if d, err := time.ParseDuration(attributes["netem_jitter"]); err == nil {
+		netem.jitter = d.Seconds()
+	}
+
+	if v, err := strconv.ParseFloat(strings.TrimSuffix(attributes["netem_loss"], "%"),
+		64); err == nil {
+		netem.loss = v / 100
+	}
+
+	if v, err := strconv.ParseFloat(strings.TrimSuffix(attributes["netem_corruption"], "%"),
+		64); err == nil {
+		netem.corruption = v / 100
+	}
+
+	if v, err := strconv.ParseFloat(strings.TrimSuffix(attributes["netem_rate"], "kbit"),
+		64); err == nil {
+		netem.rate = v * 1000
+	}
+
+	return netem
+}
+
+// metricFamily is a metric with its samples in the Prometheus text format.
+type metricFamily struct {
+	name    string
+	help    string
+	kind    string
+	samples []string
+}
+
+func (f *metricFamily) add(labels string, value float64) {
+	f.samples = append(f.samples,
+		f.name+"{"+labels+"} "+strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// write writes the metrics in the Prometheus text exposition format.
+func (m *metricsCollector) write(w io.Writer) error {
+	m.mu.Lock()
+
+	ids := make([]string, 0, len(m.nodes))
+	for id := range m.nodes {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		left, right := m.nodes[ids[i]], m.nodes[ids[j]]
+		if left.lab != right.lab {
+			return left.lab < right.lab
+		}
+
+		if left.node != right.node {
+			return left.node < right.node
+		}
+
+		return ids[i] < ids[j]
+	})
+
+	nodeUp := &metricFamily{
+		name: "clab_node_up", kind: "gauge",
+		help: "Whether the node container is running.",
+	}
+	nodeHealthy := &metricFamily{
+		name: "clab_node_healthy", kind: "gauge",
+		help: "Whether the node container is healthy, only set for containers with a health check.",
+	}
+	nodeRestarts := &metricFamily{
+		name: "clab_node_restarts_total", kind: "counter",
+		help: "Restarts of the node container observed by the exporter.",
+	}
+	ifaceUp := &metricFamily{
+		name: "clab_interface_up", kind: "gauge",
+		help: "Whether the operational state of the interface is up.",
+	}
+	counters := make([]*metricFamily, len(interfaceCounters))
+	for i, counter := range interfaceCounters {
+		counters[i] = &metricFamily{
+			name: "clab_interface_" + counter.attribute + "_total", kind: "counter",
+			help: counter.help,
+		}
+	}
+	netemDelay := &metricFamily{
+		name: "clab_interface_netem_delay_seconds", kind: "gauge",
+		help: "Delay of the interface set with netem.",
+	}
+	netemJitter := &metricFamily{
+		name: "clab_interface_netem_jitter_seconds", kind: "gauge",
+		help: "Jitter of the interface set with netem.",
+	}
+	netemLoss := &metricFamily{
+		name: "clab_interface_netem_loss_ratio", kind: "gauge",
+		help: "Packet loss ratio of the interface set with netem.",
+	}
+	netemCorruption := &metricFamily{
+		name: "clab_interface_netem_corruption_ratio", kind: "gauge",
+		help: "Packet corruption ratio of the interface set with netem.",
+	}
+	netemRate := &metricFamily{
+		name: "clab_interface_netem_rate_bits_per_second", kind: "gauge",
+		help: "Rate limit of the interface set with netem, 0 when the rate is not limited.",
+	}
+
+	for _, id := range ids {
+		n := m.nodes[id]
+		labels := metricLabels("lab", n.lab, "node", n.node, "kind", n.kind)
+
+		nodeUp.add(labels, boolValue(n.running))
+		nodeRestarts.add(labels, float64(n.restarts))
+
+		if n.health != "" {
+			nodeHealthy.add(labels, boolValue(n.health == "healthy"))
+		}
+
+		names := make([]string, 0, len(n.interfaces))
+		for name := range n.interfaces {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			iface := n.interfaces[name]
+			ifaceLabels := labels + "," + metricLabels("interface", name, "alias", iface.alias)
+
+			ifaceUp.add(ifaceLabels, boolValue(iface.operUp))
+
+			if iface.hasStats {
+				for i, counter := range interfaceCounters {
+					counters[i].add(ifaceLabels, float64(iface.counters[counter.attribute]))
+				}
+			}
+
+			netemDelay.add(ifaceLabels, iface.netem.delay)
+			netemJitter.add(ifaceLabels, iface.netem.jitter)
+			netemLoss.add(ifaceLabels, iface.netem.loss)
+			netemCorruption.add(ifaceLabels, iface.netem.corruption)
+			netemRate.add(ifaceLabels, iface.netem.rate)
+		}
+	}
+
+	m.mu.Unlock()
+
+	families := []*metricFamily{nodeUp, nodeHealthy, nodeRestarts, ifaceUp}
+	families = append(families, counters...)
+	families = append(families, netemDelay, netemJitter, netemLoss, netemCorruption, netemRate)
+
+	var b strings.Builder
+
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+
+		for _, sample := range f.samples {
+			b.WriteString(sample)
+			b.WriteByte('\n')
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// metricLabels formats the label name and value pairs.
+func metricLabels(pairs ...string) string {
+	labels := make([]string, 0, len(pairs)/2) //nolint:mnd
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, pairs[i]+"=\""+labelValueReplacer.Replace(pairs[i+1])+"\"")
+	}
+
+	return strings.Join(labels, ",")
+}
+
+var labelValueReplacer = strings.NewReplacer( //nolint:gochecknoglobals
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+)
+
+func boolValue(v bool) float64 {
+	if v {
+		return 1
+	}
+
+	return 0
+}
+
+func (m *metricsCollector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	if err := m.write(w); err != nil {
+		log.Debugf("failed to write metrics: %v", err)
+	}
+}
+
+// serveMetrics serves the metrics on the listen address until the context is canceled.
+func serveMetrics(ctx context.Context, listen string, m *metricsCollector) error {
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %q: %w", listen, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, m)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Info("Serving lab metrics", "address", "http://"+listener.Addr().String()+metricsPath)
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("metrics server failed: %v", err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package events
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	labels := map[string]string{
+		clabconstants.Containerlab: "lab1",
+		clabconstants.NodeName:     "srl1",
+		clabconstants.NodeKind:     "nokia_srlinux",
+	}
+
+	m := newMetricsCollector()
+	m.observeContainers([]clabruntime.GenericContainer{
+		{ID: "c1", State: "running", Status: "Up 5 minutes (healthy)", Labels: labels},
+		{ID: "c2", State: "exited", Labels: map[string]string{
+			clabconstants.Containerlab: "lab1",
+			clabconstants.NodeName:     "client",
+			clabconstants.NodeKind:     "linux",
+		}},
+	})
+
+	for _, ev := range []aggregatedEvent{
+		{Type: "container", Action: "die", ActorFullID: "c1", Attributes: labels},
+		{Type: "container", Action: "start", ActorFullID: "c1", Attributes: labels},
+		{Type: "container", Action: "restart", ActorFullID: "c1", Attributes: labels},
+		{Type: "container", Action: "health_status: unhealthy", ActorFullID: "c1"},
+		{
+			Type: "interface", Action: "stats", ActorFullID: "c1", ActorName: "clab-lab1-srl1",
+			Attributes: map[string]string{
+				"ifname": "e1-1", "state": "up", "lab": "lab1",
+				"rx_bytes": "1000", "tx_bytes": "2000", "rx_packets": "10", "tx_packets": "20",
+				"rx_errors": "1", "tx_errors": "0", "rx_dropped": "2", "tx_dropped": "0",
+				"netem_delay": "10ms", "netem_loss": "5.00%", "netem_rate": "1000kbit",
+			},
+		},
+		{
+			Type: "interface", Action: "update", ActorFullID: "c1",
+			Attributes: map[string]string{"ifname": "mgmt0", "state": "down"},
+		},
+		{
+			Type: "interface", Action: "create", ActorFullID: "c1",
+			Attributes: map[string]string{"ifname": "e1-2", "state": "up"},
+		},
+		{
+			Type: "interface", Action: "delete", ActorFullID: "c1",
+			Attributes: map[string]string{"ifname": "e1-2"},
+		},
+		// the interfaces of the containers that are not observed yet are labeled with
+		// the container name
+		{
+			Type: "interface", Action: "create", ActorFullID: "c3", ActorName: "clab-lab2-n1",
+			Attributes: map[string]string{"ifname": "eth1", "alias": "e1", "state": "up",
+				"lab": "lab2"},
+		},
+		{Type: "container", Action: "destroy", ActorFullID: "c2"},
+	} {
+		m.observe(ev)
+	}
+
+	var b strings.Builder
+	if err := m.write(&b); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+
+	srl := `lab="lab1",node="srl1",kind="nokia_srlinux"`
+	e11 := srl + `,interface="e1-1",alias=""`
+	mgmt := srl + `,interface="mgmt0",alias=""`
+	n1 := `lab="lab2",node="clab-lab2-n1",kind=""`
+	eth1 := n1 + `,interface="eth1",alias="e1"`
+
+	want := map[string][]string{
+		"clab_node_up":             {"{" + srl + "} 1", "{" + n1 + "} 1"},
+		"clab_node_healthy":        {"{" + srl + "} 0"},
+		"clab_node_restarts_total": {"{" + srl + "} 1", "{" + n1 + "} 0"},
+		"clab_interface_up": {
+			"{" + e11 + "} 1", "{" + mgmt + "} 0", "{" + eth1 + "} 1",
+		},
+		"clab_interface_rx_bytes_total":   {"{" + e11 + "} 1000"},
+		"clab_interface_tx_packets_total": {"{" + e11 + "} 20"},
+		"clab_interface_rx_errors_total":  {"{" + e11 + "} 1"},
+		"clab_interface_rx_dropped_total": {"{" + e11 + "} 2"},
+		"clab_interface_netem_delay_seconds": {
+			"{" + e11 + "} 0.01", "{" + mgmt + "} 0", "{" + eth1 + "} 0",
+		},
+		"clab_interface_netem_loss_ratio": {
+			"{" + e11 + "} 0.05", "{" + mgmt + "} 0", "{" + eth1 + "} 0",
+		},
+		"clab_interface_netem_rate_bits_per_second": {
+			"{" + e11 + "} 1e+06", "{" + mgmt + "} 0", "{" + eth1 + "} 0",
+		},
+	}
+
+	got := map[string][]string{}
+
+	for _, line := range strings.Split(b.String(), "\n") {
+		name, sample, ok := strings.Cut(line, "{")
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if _, wanted := want[name]; wanted {
+			got[name] = append(got[name], "{"+sample)
+		}
+	}
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("metrics mismatch (-want +got):\n%s", d)
+	}
+
+	if !strings.Contains(b.String(), "# TYPE clab_interface_rx_bytes_total counter\n") {
+		t.Errorf("missing the counter type of clab_interface_rx_bytes_total:\n%s", b.String())
+	}
+}
+
+func TestMetricLabels(t *testing.T) {
+	got := metricLabels("lab", `a"b`, "node", `c\d`+"\n")
+	want := `lab="a\"b",node="c\\d\n"`
+
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
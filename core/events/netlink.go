@@ -653,6 +653,10 @@ func (r *netlinkRegistry) emitInterfaceStatsEvent(
 	attributes["tx_bytes"] = strconv.FormatUint(metrics.TxBytes, 10)
 	attributes["rx_packets"] = strconv.FormatUint(metrics.RxPackets, 10)
 	attributes["tx_packets"] = strconv.FormatUint(metrics.TxPackets, 10)
+	attributes["rx_errors"] = strconv.FormatUint(snapshot.RxErrors, 10)
+	attributes["tx_errors"] = strconv.FormatUint(snapshot.TxErrors, 10)
+	attributes["rx_dropped"] = strconv.FormatUint(snapshot.RxDropped, 10)
+	attributes["tx_dropped"] = strconv.FormatUint(snapshot.TxDropped, 10)
 	attributes["rx_bps"] = strconv.FormatFloat(metrics.RxBps, 'f', -1, 64)
 	attributes["tx_bps"] = strconv.FormatFloat(metrics.TxBps, 'f', -1, 64)
 	attributes["rx_pps"] = strconv.FormatFloat(metrics.RxPps, 'f', -1, 64)
@@ -731,6 +735,10 @@ func snapshotFromLink(link netlink.Link) ifaceSnapshot {
 			snapshot.TxBytes = stats.TxBytes
 			snapshot.RxPackets = stats.RxPackets
 			snapshot.TxPackets = stats.TxPackets
+			snapshot.RxErrors = stats.RxErrors
+			snapshot.TxErrors = stats.TxErrors
+			snapshot.RxDropped = stats.RxDropped
+			snapshot.TxDropped = stats.TxDropped
 		}
 	}
 
@@ -750,6 +758,10 @@ type ifaceSnapshot struct {
 	TxBytes   uint64
 	RxPackets uint64
 	TxPackets uint64
+	RxErrors  uint64
+	TxErrors  uint64
+	RxDropped uint64
+	TxDropped uint64
 	// Netem fields
 	HasNetem   bool
 	Delay      string
@@ -851,6 +863,10 @@ func (w *netlinkWatcher) collectAndEmitStats(
 		state.TxBytes = current.TxBytes
 		state.RxPackets = current.RxPackets
 		state.TxPackets = current.TxPackets
+		state.RxErrors = current.RxErrors
+		state.TxErrors = current.TxErrors
+		state.RxDropped = current.RxDropped
+		state.TxDropped = current.TxDropped
 		states[idx] = state
 
 		if !state.HasStats {
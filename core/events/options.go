@@ -14,8 +14,19 @@ type Options struct {
 	IncludeInitialState   bool
 	IncludeInterfaceStats bool
 	StatsInterval         time.Duration
-	ClabOptions           []clabcore.ClabOption
-	Writer                io.Writer
+	// MetricsListen is the address the Prometheus metrics are served on, empty disables them
+	MetricsListen string
+	// Sinks are the sinks the events are delivered to in addition to the writer
+	Sinks *SinkConfig
+	// Filters are the filter expressions the events are matched against before they are
+	// written and delivered to the sinks, an event is kept if it matches any of them
+	Filters []string
+	// Record is the path of the file the emitted events are recorded to for a later replay
+	Record string
+	// LiveGraph receives the events to stream the lab state to the topology graph
+	LiveGraph   *LiveGraph
+	ClabOptions []clabcore.ClabOption
+	Writer      io.Writer
 }
 
 func (o Options) writer() io.Writer {
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"context"
+
+	"github.com/charmbracelet/log"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+// pipeline delivers the events to the metrics, the writer, the recording, the live graph
+// and the sinks.
+type pipeline struct {
+	printer formatter
+	filter  *eventFilter
+	metrics *metricsCollector
+	sinks   []sink
+	// includeStats emits the interface statistics events, the metrics are updated
+	// from the statistics regardless
+	includeStats bool
+}
+
+// newPipeline starts the metrics server and the sinks, the containers present when
+// the stream starts are passed to the filter, the metrics and the sinks.
+func newPipeline(
+	ctx context.Context,
+	opts Options,
+	containers []clabruntime.GenericContainer,
+) (*pipeline, error) {
+	printer, err := newFormatter(opts.Format, opts.writer())
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseEventFilter(opts.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.observeContainers(containers)
+
+	p := &pipeline{
+		printer:      printer,
+		filter:       filter,
+		includeStats: opts.IncludeInterfaceStats,
+	}
+
+	if opts.MetricsListen != "" {
+		p.metrics = newMetricsCollector()
+		p.metrics.observeContainers(containers)
+
+		if err := serveMetrics(ctx, opts.MetricsListen, p.metrics); err != nil {
+			return nil, err
+		}
+	}
+
+	p.sinks, err = newSinks(ctx, opts.Sinks, containers)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Record != "" {
+		recorder, err := newRecordSink(opts.Record)
+		if err != nil {
+			closeSinks(p.sinks)
+
+			return nil, err
+		}
+
+		p.sinks = append(p.sinks, recorder)
+	}
+
+	if opts.LiveGraph != nil {
+		opts.LiveGraph.observeContainers(containers)
+
+		p.sinks = append(p.sinks, opts.LiveGraph)
+	}
+
+	return p, nil
+}
+
+func (p *pipeline) emit(ev aggregatedEvent) {
+	if p.metrics != nil {
+		p.metrics.observe(ev)
+	}
+
+	if ev.Action == "stats" && !p.includeStats {
+		return
+	}
+
+	if !p.filter.match(ev) {
+		return
+	}
+
+	if err := p.printer(ev); err != nil {
+		log.Debugf("failed to write event: %v", err)
+	}
+
+	for _, s := range p.sinks {
+		s.Send(ev)
+	}
+}
+
+func (p *pipeline) close() {
+	closeSinks(p.sinks)
+}
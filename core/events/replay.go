@@ -0,0 +1,86 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// maxRecordedEventSize is the size of the largest recorded event line.
+const maxRecordedEventSize = 1024 * 1024
+
+// Replay emits the events recorded with Options.Record through the same formatters, metrics
+// and sinks as Stream. The events keep their recorded timestamps and are emitted with the
+// recorded delays divided by the speed, a zero speed emits them without delays.
+// With the metrics enabled the metrics of the replayed events are served until the context
+// is canceled.
+func Replay(ctx context.Context, path string, speed float64, opts Options) error {
+	if speed < 0 {
+		return fmt.Errorf("invalid replay speed %v, expected a positive number", speed)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open the events recording: %w", err)
+	}
+	defer f.Close()
+
+	// the recording holds the events that were emitted, statistics included
+	opts.IncludeInterfaceStats = true
+
+	events, err := newPipeline(ctx, opts, nil)
+	if err != nil {
+		return err
+	}
+	defer events.close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordedEventSize)
+
+	var previous time.Time
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var ev aggregatedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			return fmt.Errorf("failed to parse the recorded event on line %d of %s: %w",
+				line, path, err)
+		}
+
+		if speed > 0 && !previous.IsZero() && ev.Timestamp.After(previous) {
+			delay := time.Duration(float64(ev.Timestamp.Sub(previous)) / speed)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		if !ev.Timestamp.IsZero() {
+			previous = ev.Timestamp
+		}
+
+		events.emit(ev)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read the events recording: %w", err)
+	}
+
+	if opts.MetricsListen != "" {
+		log.Info("Replay completed, serving the metrics until interrupted")
+
+		<-ctx.Done()
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func recordEvents(t *testing.T, events ...aggregatedEvent) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	s, err := newRecordSink(path)
+	if err != nil {
+		t.Fatalf("failed to create the recording: %v", err)
+	}
+
+	for _, ev := range events {
+		s.Send(ev)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("failed to close the recording: %v", err)
+	}
+
+	return path
+}
+
+func TestReplay(t *testing.T) {
+	start := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	path := recordEvents(t,
+		aggregatedEvent{
+			Timestamp: start,
+			Type:      "interface",
+			Action:    "update",
+			ActorID:   "c1",
+			Attributes: map[string]string{
+				"ifname": "e1-1", "lab": "lab1", "state": "down",
+			},
+		},
+		aggregatedEvent{
+			Timestamp:  start.Add(time.Second),
+			Type:       lifecycleEventType,
+			Action:     "deploy_done",
+			ActorName:  "lab1",
+			Attributes: map[string]string{"lab": "lab1"},
+		},
+		aggregatedEvent{
+			Timestamp:  start.Add(2 * time.Second),
+			Type:       "interface",
+			Action:     "stats",
+			ActorID:    "c1",
+			Attributes: map[string]string{"ifname": "e1-1", "rx_bytes": "10"},
+		},
+	)
+
+	var out bytes.Buffer
+
+	err := Replay(context.Background(), path, 0, Options{
+		Format:  "plain",
+		Filters: []string{"type=interface"},
+		Writer:  &out,
+	})
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	want := []string{
+		"2026-10-18T09:30:00Z interface update c1 (ifname=e1-1, lab=lab1, state=down)",
+		"2026-10-18T09:30:02Z interface stats c1 (ifname=e1-1, rx_bytes=10)",
+	}
+
+	if d := cmp.Diff(want, strings.Split(strings.TrimSpace(out.String()), "\n")); d != "" {
+		t.Errorf("replayed events mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestReplaySpeed(t *testing.T) {
+	start := time.Now()
+
+	path := recordEvents(t,
+		aggregatedEvent{Timestamp: start, Type: "container", Action: "start"},
+		aggregatedEvent{Timestamp: start.Add(time.Second), Type: "container", Action: "die"},
+	)
+
+	began := time.Now()
+
+	if err := Replay(context.Background(), path, 10, Options{Format: "json"}); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	// the recorded second between the events is replayed in a tenth of a second
+	elapsed := time.Since(began)
+	if elapsed < 100*time.Millisecond || elapsed > 900*time.Millisecond {
+		t.Errorf("expected the replay to take about 100ms, took %v", elapsed)
+	}
+}
+
+func TestReplayInvalidRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte("{\"type\":\"container\"}\nnot json\n"), 0o644); err != nil {
+		t.Fatalf("failed to write recording: %v", err)
+	}
+
+	err := Replay(context.Background(), path, 0, Options{Format: "plain"})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error for the line 2 of the recording, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+	"gopkg.in/yaml.v2"
+)
+
+// SinkConfig configures the sinks the events are delivered to in addition to the writer.
+type SinkConfig struct {
+	Webhooks []*WebhookSinkConfig `yaml:"webhooks,omitempty"`
+	File     *FileSinkConfig      `yaml:"file,omitempty"`
+	Socket   *SocketSinkConfig    `yaml:"socket,omitempty"`
+}
+
+// WebhookSinkConfig configures a sink posting the events to an HTTP endpoint.
+type WebhookSinkConfig struct {
+	URL string `yaml:"url"`
+	// Secret is the key of the HMAC-SHA256 signature of the request body,
+	// the requests are not signed when it is empty
+	Secret string `yaml:"secret,omitempty"`
+	// BatchSize is the maximum number of the events posted in a single request
+	BatchSize int `yaml:"batch-size,omitempty"`
+	// BatchInterval is the maximum time an event waits for the batch to fill up
+	BatchInterval time.Duration `yaml:"batch-interval,omitempty"`
+	// Retries is the number of times a failed request is retried, 3 by default,
+	// a negative value disables the retries
+	Retries int `yaml:"retries,omitempty"`
+}
+
+// FileSinkConfig configures a sink writing the events of every lab
+// to the rotated JSONL files in the lab directory.
+type FileSinkConfig struct {
+	// MaxSize is the size in bytes the file is rotated at, 10MiB by default
+	MaxSize int64 `yaml:"max-size,omitempty"`
+	// MaxFiles is the number of the rotated files kept next to the current file, 5 by default
+	MaxFiles int `yaml:"max-files,omitempty"`
+}
+
+// SocketSinkConfig configures a unix socket streaming the events as JSONL
+// to every connected client.
+type SocketSinkConfig struct {
+	Path string `yaml:"path"`
+}
+
+// LoadSinkConfig reads the sinks configuration from the YAML file.
+func LoadSinkConfig(path string) (*SinkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the event sinks config: %w", err)
+	}
+
+	cfg := &SinkConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the event sinks config %q: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// sink delivers the events to a destination. Send must not block the event stream.
+type sink interface {
+	Send(ev aggregatedEvent)
+	Close() error
+}
+
+// newSinks starts the configured sinks, the containers present when the stream starts
+// are passed to the sinks tracking the labs.
+func newSinks(
+	ctx context.Context,
+	cfg *SinkConfig,
+	containers []clabruntime.GenericContainer,
+) ([]sink, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var sinks []sink
+
+	for _, webhook := range cfg.Webhooks {
+		s, err := newWebhookSink(ctx, webhook)
+		if err != nil {
+			closeSinks(sinks)
+
+			return nil, err
+		}
+
+		sinks = append(sinks, s)
+	}
+
+	if cfg.File != nil {
+		sinks = append(sinks, newFileSink(cfg.File, containers))
+	}
+
+	if cfg.Socket != nil {
+		s, err := newSocketSink(cfg.Socket)
+		if err != nil {
+			closeSinks(sinks)
+
+			return nil, err
+		}
+
+		sinks = append(sinks, s)
+	}
+
+	return sinks, nil
+}
+
+func closeSinks(sinks []sink) {
+	var errs []error
+
+	for _, s := range sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		log.Warnf("failed to close event sinks: %v", err)
+	}
+}
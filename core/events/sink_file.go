@@ -0,0 +1,171 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+const (
+	eventsFileName         = "events.jsonl"
+	defaultEventsFileSize  = 10 * 1024 * 1024
+	defaultEventsFileCount = 5
+)
+
+// fileSink appends the events of every lab to the events.jsonl file in the lab directory,
+// the file is rotated to events.jsonl.1, events.jsonl.2, ... once it reaches the max size.
+type fileSink struct {
+	cfg FileSinkConfig
+	// labDirs are the lab directories keyed by the lab name
+	labDirs map[string]string
+	files   map[string]*os.File
+}
+
+func newFileSink(cfg *FileSinkConfig, containers []clabruntime.GenericContainer) *fileSink {
+	s := &fileSink{
+		cfg:     *cfg,
+		labDirs: make(map[string]string),
+		files:   make(map[string]*os.File),
+	}
+
+	if s.cfg.MaxSize <= 0 {
+		s.cfg.MaxSize = defaultEventsFileSize
+	}
+
+	if s.cfg.MaxFiles <= 0 {
+		s.cfg.MaxFiles = defaultEventsFileCount
+	}
+
+	for idx := range containers {
+		s.trackLab(containers[idx].Labels)
+	}
+
+	return s
+}
+
+// trackLab records the lab directory from the container labels.
+func (s *fileSink) trackLab(labels map[string]string) {
+	lab := labels[clabconstants.Containerlab]
+	nodeDir := labels[clabconstants.NodeLabDir]
+
+	if lab == "" || nodeDir == "" {
+		return
+	}
+
+	s.labDirs[lab] = filepath.Dir(nodeDir)
+}
+
+// Send is only called from the stream loop, so the sink is not guarded with a mutex.
+func (s *fileSink) Send(ev aggregatedEvent) {
+	if ev.Type == clabruntime.EventTypeContainer {
+		s.trackLab(ev.Attributes)
+	}
+
+	lab := ev.Attributes[clabconstants.Containerlab]
+	if lab == "" {
+		lab = ev.Attributes["lab"]
+	}
+
+	labDir, ok := s.labDirs[lab]
+	if !ok {
+		return
+	}
+
+	if err := s.write(lab, labDir, ev); err != nil {
+		log.Debugf("failed to write event to the lab %s events file: %v", lab, err)
+	}
+
+	// the lab directory is removed with the lab, the file is reopened for a new deployment
+	if ev.Type == clabruntime.EventTypeContainer &&
+		ev.Action == clabruntime.EventActionDestroy {
+		s.closeFile(lab)
+	}
+}
+
+func (s *fileSink) write(lab, labDir string, ev aggregatedEvent) error {
+	b, err := json.Marshal(withMergedAttributes(ev))
+	if err != nil {
+		return err
+	}
+
+	f, err := s.file(lab, labDir)
+	if err != nil {
+		return err
+	}
+
+	if info, err := f.Stat(); err == nil && info.Size() > 0 &&
+		info.Size()+int64(len(b))+1 > s.cfg.MaxSize {
+		if f, err = s.rotate(lab, labDir); err != nil {
+			return err
+		}
+	}
+
+	_, err = f.Write(append(b, '\n'))
+
+	return err
+}
+
+func (s *fileSink) file(lab, labDir string) (*os.File, error) {
+	if f, ok := s.files[lab]; ok {
+		return f, nil
+	}
+
+	if _, err := os.Stat(labDir); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(labDir, eventsFileName),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, clabconstants.PermissionsFileDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	s.files[lab] = f
+
+	return f, nil
+}
+
+// rotate shifts the rotated files by one, dropping the oldest, and opens a new file.
+func (s *fileSink) rotate(lab, labDir string) (*os.File, error) {
+	s.closeFile(lab)
+
+	path := filepath.Join(labDir, eventsFileName)
+
+	for i := s.cfg.MaxFiles; i > 0; i-- {
+		src := path
+		if i > 1 {
+			src = fmt.Sprintf("%s.%d", path, i-1)
+		}
+
+		err := os.Rename(src, fmt.Sprintf("%s.%d", path, i))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return s.file(lab, labDir)
+}
+
+func (s *fileSink) closeFile(lab string) {
+	if f, ok := s.files[lab]; ok {
+		f.Close()
+		delete(s.files, lab)
+	}
+}
+
+func (s *fileSink) Close() error {
+	var errs []error
+
+	for lab, f := range s.files {
+		errs = append(errs, f.Close())
+		delete(s.files, lab)
+	}
+
+	return errors.Join(errs...)
+}
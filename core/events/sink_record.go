@@ -0,0 +1,57 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+)
+
+// recordSink records the events to a JSONL file replayed with Replay. The events are
+// recorded as they are emitted, so the replay runs them through the formatters again.
+type recordSink struct {
+	file *os.File
+	w    *bufio.Writer
+}
+
+func newRecordSink(path string) (*recordSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC,
+		clabconstants.PermissionsFileDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the events recording: %w", err)
+	}
+
+	log.Info("Recording events", "path", path)
+
+	return &recordSink{file: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Send is only called from the stream loop, so the sink is not guarded with a mutex.
+func (s *recordSink) Send(ev aggregatedEvent) {
+	b, err := json.Marshal(ev)
+	if err == nil {
+		_, err = s.w.Write(append(b, '\n'))
+	}
+
+	// the recording is flushed after every event, so it is complete when the command is killed
+	if err == nil {
+		err = s.w.Flush()
+	}
+
+	if err != nil {
+		log.Debugf("failed to record event: %v", err)
+	}
+}
+
+func (s *recordSink) Close() error {
+	if err := s.w.Flush(); err != nil {
+		s.file.Close()
+
+		return err
+	}
+
+	return s.file.Close()
+}
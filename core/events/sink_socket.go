@@ -0,0 +1,150 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/log"
+)
+
+// socketClientQueueSize is the number of the events queued for a slow socket client,
+// the events are dropped for the client once the queue is full.
+const socketClientQueueSize = 256
+
+// socketSink streams the events as JSONL to every client connected to the unix socket.
+type socketSink struct {
+	path     string
+	listener net.Listener
+	mu       sync.Mutex
+	clients  map[*socketClient]struct{}
+	wg       sync.WaitGroup
+}
+
+type socketClient struct {
+	conn  net.Conn
+	queue chan aggregatedEvent
+}
+
+func newSocketSink(cfg *SocketSinkConfig) (*socketSink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("missing the events socket path")
+	}
+
+	// a socket file left behind by a previous run is replaced
+	if info, err := os.Lstat(cfg.Path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(cfg.Path); err != nil {
+			return nil, fmt.Errorf("failed to remove the stale events socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the events socket %q: %w", cfg.Path, err)
+	}
+
+	s := &socketSink{
+		path:     cfg.Path,
+		listener: listener,
+		clients:  make(map[*socketClient]struct{}),
+	}
+
+	log.Info("Streaming events to the unix socket", "path", cfg.Path)
+
+	s.wg.Add(1)
+
+	go s.accept()
+
+	return s, nil
+}
+
+func (s *socketSink) accept() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Debugf("failed to accept events socket client: %v", err)
+			}
+
+			return
+		}
+
+		client := &socketClient{
+			conn:  conn,
+			queue: make(chan aggregatedEvent, socketClientQueueSize),
+		}
+
+		s.mu.Lock()
+		s.clients[client] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+
+		go s.serve(client)
+	}
+}
+
+// serve writes the queued events to the client until the client disconnects
+// or the sink is closed.
+func (s *socketSink) serve(client *socketClient) {
+	defer s.wg.Done()
+	defer client.conn.Close()
+
+	encoder := json.NewEncoder(client.conn)
+	encoder.SetEscapeHTML(false)
+
+	for ev := range client.queue {
+		if err := encoder.Encode(withMergedAttributes(ev)); err != nil {
+			s.remove(client)
+
+			// the queue is drained for Send not to block on the removed client
+			for range client.queue {
+			}
+
+			return
+		}
+	}
+}
+
+func (s *socketSink) remove(client *socketClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[client]; ok {
+		delete(s.clients, client)
+		close(client.queue)
+	}
+}
+
+func (s *socketSink) Send(ev aggregatedEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for client := range s.clients {
+		select {
+		case client.queue <- ev:
+		default:
+			log.Debug("Dropping event for a slow events socket client")
+		}
+	}
+}
+
+func (s *socketSink) Close() error {
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	for client := range s.clients {
+		delete(s.clients, client)
+		close(client.queue)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	return err
+}
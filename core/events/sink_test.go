@@ -0,0 +1,271 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+)
+
+func sinkTestEvent(action string) aggregatedEvent {
+	return aggregatedEvent{
+		Type:        "interface",
+		Action:      action,
+		ActorName:   "clab-lab1-n1",
+		ActorFullID: "c1",
+		Attributes:  map[string]string{"ifname": "eth1", "lab": "lab1"},
+	}
+}
+
+func TestLoadSinkConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sinks.yml")
+	if err := os.WriteFile(path, []byte(`
+webhooks:
+  - url: https://example.com/events
+    secret: s3cret
+    batch-size: 10
+    batch-interval: 2s
+    retries: -1
+file:
+  max-files: 3
+socket:
+  path: /run/clab-events.sock
+`), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadSinkConfig(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	want := &SinkConfig{
+		Webhooks: []*WebhookSinkConfig{{
+			URL:           "https://example.com/events",
+			Secret:        "s3cret",
+			BatchSize:     10,
+			BatchInterval: 2 * time.Second,
+			Retries:       -1,
+		}},
+		File:   &FileSinkConfig{MaxFiles: 3},
+		Socket: &SocketSinkConfig{Path: "/run/clab-events.sock"},
+	}
+
+	if d := cmp.Diff(want, cfg); d != "" {
+		t.Errorf("config mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		batches  [][]aggregatedEvent
+		requests int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests++
+
+		// the first request fails and is retried
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if got, want := r.Header.Get(WebhookSignatureHeader),
+			"sha256="+signWebhookBody("s3cret", body); got != want {
+			t.Errorf("signature mismatch: got %q, want %q", got, want)
+		}
+
+		var batch []aggregatedEvent
+		if err := json.Unmarshal(body, &batch); err != nil {
+			t.Errorf("failed to unmarshal batch: %v", err)
+		}
+
+		batches = append(batches, batch)
+	}))
+	defer server.Close()
+
+	s, err := newWebhookSink(context.Background(), &WebhookSinkConfig{
+		URL:           server.URL,
+		Secret:        "s3cret",
+		BatchSize:     2,
+		BatchInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("failed to create webhook sink: %v", err)
+	}
+
+	for _, action := range []string{"create", "update", "delete"} {
+		s.Send(sinkTestEvent(action))
+	}
+
+	// the last incomplete batch is posted when the sink is closed
+	if err := s.Close(); err != nil {
+		t.Fatalf("failed to close webhook sink: %v", err)
+	}
+
+	var actions [][]string
+	for _, batch := range batches {
+		var batchActions []string
+		for _, ev := range batch {
+			batchActions = append(batchActions, ev.Action)
+		}
+
+		actions = append(actions, batchActions)
+	}
+
+	if d := cmp.Diff([][]string{{"create", "update"}, {"delete"}}, actions); d != "" {
+		t.Errorf("posted batches mismatch (-want +got):\n%s", d)
+	}
+
+	if got := batches[0][0].Attributes["name"]; got != "clab-lab1-n1" {
+		t.Errorf("expected the actor name in the event attributes, got %q", got)
+	}
+}
+
+func TestWebhookSinkInvalidURL(t *testing.T) {
+	if _, err := newWebhookSink(context.Background(),
+		&WebhookSinkConfig{URL: "ftp://example.com"}); err == nil {
+		t.Error("expected an error for a non-http webhook URL")
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	labDir := filepath.Join(t.TempDir(), "clab-lab1")
+	if err := os.Mkdir(labDir, 0o755); err != nil {
+		t.Fatalf("failed to create lab dir: %v", err)
+	}
+
+	event, err := json.Marshal(withMergedAttributes(sinkTestEvent("update")))
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	s := newFileSink(&FileSinkConfig{
+		// two events fit in a file
+		MaxSize:  int64(2 * (len(event) + 1)),
+		MaxFiles: 2,
+	}, []clabruntime.GenericContainer{{
+		Labels: map[string]string{
+			clabconstants.Containerlab: "lab1",
+			clabconstants.NodeLabDir:   filepath.Join(labDir, "n1"),
+		},
+	}})
+	defer s.Close()
+
+	for range 7 {
+		s.Send(sinkTestEvent("update"))
+	}
+
+	// the events of unknown labs are not written
+	s.Send(aggregatedEvent{Type: "interface", Attributes: map[string]string{"lab": "lab2"}})
+
+	lines := map[string]int{}
+
+	for _, name := range []string{"events.jsonl", "events.jsonl.1", "events.jsonl.2"} {
+		f, err := os.Open(filepath.Join(labDir, name))
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", name, err)
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines[name]++
+		}
+
+		f.Close()
+	}
+
+	want := map[string]int{"events.jsonl": 1, "events.jsonl.1": 2, "events.jsonl.2": 2}
+	if d := cmp.Diff(want, lines); d != "" {
+		t.Errorf("events per file mismatch (-want +got):\n%s", d)
+	}
+
+	if _, err := os.Stat(filepath.Join(labDir, "events.jsonl.3")); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 rotated files, got %v", err)
+	}
+}
+
+func TestSocketSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+
+	s, err := newSocketSink(&SocketSinkConfig{Path: path})
+	if err != nil {
+		t.Fatalf("failed to create socket sink: %v", err)
+	}
+
+	var readers []*bufio.Reader
+
+	for range 2 {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			t.Fatalf("failed to connect to the socket: %v", err)
+		}
+		defer conn.Close()
+
+		readers = append(readers, bufio.NewReader(conn))
+	}
+
+	// wait for the clients to be accepted
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mu.Lock()
+		clients := len(s.clients)
+		s.mu.Unlock()
+
+		if clients == 2 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 socket clients, got %d", clients)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	s.Send(sinkTestEvent("delete"))
+
+	for i, r := range readers {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("client %d failed to read event: %v", i, err)
+		}
+
+		var ev aggregatedEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			t.Fatalf("client %d failed to unmarshal event: %v", i, err)
+		}
+
+		if ev.Action != "delete" {
+			t.Errorf("client %d got event action %q, want delete", i, ev.Action)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("failed to close socket sink: %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the socket file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+const (
+	defaultWebhookBatchSize     = 100
+	defaultWebhookBatchInterval = time.Second
+	defaultWebhookRetries       = 3
+	webhookRequestTimeout       = 10 * time.Second
+	webhookRetryBackoff         = 500 * time.Millisecond
+	webhookFlushTimeout         = 5 * time.Second
+	webhookQueueSize            = 1024
+
+	// WebhookSignatureHeader carries the HMAC-SHA256 signature of the webhook request body.
+	WebhookSignatureHeader = "X-Clab-Signature-256"
+)
+
+// webhookSink posts the events in batches as a JSON array.
+type webhookSink struct {
+	cfg    WebhookSinkConfig
+	client *http.Client
+	queue  chan aggregatedEvent
+	// stop stops the sink, the queued events are posted before done is closed
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newWebhookSink(ctx context.Context, cfg *WebhookSinkConfig) (*webhookSink, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q, expected an http(s) URL", cfg.URL)
+	}
+
+	s := &webhookSink{
+		cfg:    *cfg,
+		client: &http.Client{Timeout: webhookRequestTimeout},
+		queue:  make(chan aggregatedEvent, webhookQueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	if s.cfg.BatchSize <= 0 {
+		s.cfg.BatchSize = defaultWebhookBatchSize
+	}
+
+	if s.cfg.BatchInterval <= 0 {
+		s.cfg.BatchInterval = defaultWebhookBatchInterval
+	}
+
+	if s.cfg.Retries < 0 {
+		s.cfg.Retries = 0
+	} else if cfg.Retries == 0 {
+		s.cfg.Retries = defaultWebhookRetries
+	}
+
+	go s.run(ctx)
+
+	return s, nil
+}
+
+func (s *webhookSink) Send(ev aggregatedEvent) {
+	select {
+	case s.queue <- ev:
+	default:
+		log.Warn("Dropping event, the webhook is not keeping up", "url", s.cfg.URL)
+	}
+}
+
+func (s *webhookSink) Close() error {
+	close(s.stop)
+	<-s.done
+
+	return nil
+}
+
+func (s *webhookSink) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.cfg.BatchInterval)
+	defer ticker.Stop()
+
+	batch := make([]aggregatedEvent, 0, s.cfg.BatchSize)
+
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := s.post(ctx, batch); err != nil {
+			log.Warn("Failed to deliver events to the webhook", "url", s.cfg.URL,
+				"events", len(batch), "error", err)
+		}
+
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case ev := <-s.queue:
+			batch = append(batch, withMergedAttributes(ev))
+			if len(batch) >= s.cfg.BatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		case <-s.stop:
+			// the stream context is canceled when the sink is closed,
+			// the queued events are posted within the flush timeout
+			flushCtx, cancel := context.WithTimeout(context.Background(), webhookFlushTimeout)
+			defer cancel()
+
+		drain:
+			for {
+				select {
+				case ev := <-s.queue:
+					batch = append(batch, withMergedAttributes(ev))
+					if len(batch) >= s.cfg.BatchSize {
+						flush(flushCtx)
+					}
+				default:
+					break drain
+				}
+			}
+
+			flush(flushCtx)
+
+			return
+		}
+	}
+}
+
+// post posts the batch, retrying the failed requests with an exponential backoff.
+func (s *webhookSink) post(ctx context.Context, batch []aggregatedEvent) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	backoff := webhookRetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		retry, err := s.postOnce(ctx, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= s.cfg.Retries {
+			return err
+		}
+
+		log.Debug("Retrying the webhook request", "url", s.cfg.URL, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+
+		backoff *= 2
+	}
+}
+
+// postOnce posts the body and reports whether a failed request should be retried.
+func (s *webhookSink) postOnce(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL,
+		bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.cfg.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+signWebhookBody(s.cfg.Secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < http.StatusMultipleChoices:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= http.StatusInternalServerError:
+		return true, fmt.Errorf("webhook responded with %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+}
+
+// signWebhookBody returns the hex encoded HMAC-SHA256 of the body.
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
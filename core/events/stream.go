@@ -11,6 +11,7 @@ import (
 	"github.com/charmbracelet/log"
 	clabconstants "github.com/srl-labs/containerlab/constants"
 	clabcore "github.com/srl-labs/containerlab/core"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
 	clabruntime "github.com/srl-labs/containerlab/runtime"
 	clabtypes "github.com/srl-labs/containerlab/types"
 	clabutils "github.com/srl-labs/containerlab/utils"
@@ -32,17 +33,13 @@ func Stream(ctx context.Context, opts Options) error {
 		return fmt.Errorf("runtime %q is not initialized", opts.Runtime)
 	}
 
-	printer, err := newFormatter(opts.Format, opts.writer())
-	if err != nil {
-		return err
-	}
-
 	eventCh := make(chan aggregatedEvent, 128)
 	registry := newNetlinkRegistry(
 		ctx,
 		eventCh,
 		opts.IncludeInitialState,
-		opts.IncludeInterfaceStats,
+		// the metrics are updated from the interface statistics
+		opts.IncludeInterfaceStats || opts.MetricsListen != "",
 		opts.StatsInterval,
 	)
 
@@ -51,6 +48,12 @@ func Stream(ctx context.Context, opts Options) error {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
+	events, err := newPipeline(ctx, opts, containers)
+	if err != nil {
+		return err
+	}
+	defer events.close()
+
 	if opts.IncludeInitialState {
 		go emitContainerSnapshots(ctx, containers, eventCh)
 	}
@@ -75,6 +78,13 @@ func Stream(ctx context.Context, opts Options) error {
 		return fmt.Errorf("failed to stream events for runtime %q: %w", opts.Runtime, err)
 	}
 
+	// the stream goes on without the lifecycle events when the event bus is not available
+	if subscriber, err := clabcorebus.Subscribe(ctx, clabcorebus.DefaultDir); err != nil {
+		log.Warnf("failed to subscribe to the containerlab lifecycle events: %v", err)
+	} else {
+		go forwardLifecycleEvents(ctx, subscriber.Events(), eventCh)
+	}
+
 	errCh := make(chan error, 1)
 	go forwardRuntimeEvents(ctx, runtime, registry, runtimeEvents, runtimeErrs, eventCh, errCh)
 
@@ -83,9 +93,7 @@ func Stream(ctx context.Context, opts Options) error {
 	for {
 		select {
 		case ev := <-eventCh:
-			if err := printer(ev); err != nil {
-				log.Debugf("failed to write event: %v", err)
-			}
+			events.emit(ev)
 		case err, ok := <-runtimeErrors:
 			if !ok {
 				runtimeErrors = nil
@@ -60,6 +60,7 @@ func (c *CLab) Exec(
 			}
 
 			resultCollection.Add(containers[idx].Names[0], execResult)
+			c.publishContainerExecResult(&containers[idx], execResult)
 		}
 	}
 
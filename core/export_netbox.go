@@ -0,0 +1,251 @@
+// Copyright 2020 Nokia
+// Licensed under the BSD 3-Clause License.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package core
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorenetbox "github.com/srl-labs/containerlab/core/netbox"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+const (
+	NetBoxFormatCSV  = "netbox-csv"
+	NetBoxFormatJSON = "netbox-json"
+
+	// netBoxFileName is the name of the NetBox JSON export file
+	netBoxFileName = "netbox.json"
+	// netBoxRoleColor is the color of the device roles
+	netBoxRoleColor = "9e9e9e"
+	// netBoxDefaultManufacturer is the manufacturer of the kinds without a vendor prefix
+	netBoxDefaultManufacturer = "containerlab"
+	// netBoxMgmtInterface is the management interface of the nodes that do not name one
+	netBoxMgmtInterface = "eth0"
+)
+
+// NetBoxData returns the NetBox import data of the lab graph. The lab is the site,
+// the node groups are the device roles and the node kinds are the device types.
+func (c *CLab) NetBoxData(g *GraphTopo) *clabcorenetbox.Data {
+	d := &clabcorenetbox.Data{}
+
+	site := c.Config.Name
+
+	d.Sites = append(d.Sites, clabcorenetbox.Site{
+		Name:        site,
+		Slug:        clabcorenetbox.Slug(site),
+		Status:      clabcorenetbox.StatusActive,
+		Description: "containerlab lab " + site,
+	})
+
+	manufacturers := make(map[string]bool)
+	roles := make(map[string]bool)
+	deviceTypes := make(map[string]bool)
+	interfaces := make(map[[2]string]bool)
+
+	addInterface := func(device, name string, mgmtOnly bool) {
+		if interfaces[[2]string{device, name}] {
+			return
+		}
+
+		interfaces[[2]string{device, name}] = true
+
+		d.Interfaces = append(d.Interfaces, clabcorenetbox.Interface{
+			Device:   device,
+			Name:     name,
+			Type:     clabcorenetbox.InterfaceType,
+			MgmtOnly: mgmtOnly,
+		})
+	}
+
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+
+		manufacturer := netBoxManufacturer(n.Kind)
+		if !manufacturers[manufacturer] {
+			manufacturers[manufacturer] = true
+
+			d.Manufacturers = append(d.Manufacturers, clabcorenetbox.Manufacturer{
+				Name: manufacturer,
+				Slug: clabcorenetbox.Slug(manufacturer),
+			})
+		}
+
+		role := n.Group
+		if role == "" {
+			role = clabcorenetbox.DefaultRole
+		}
+
+		if !roles[role] {
+			roles[role] = true
+
+			d.DeviceRoles = append(d.DeviceRoles, clabcorenetbox.DeviceRole{
+				Name:  role,
+				Slug:  clabcorenetbox.Slug(role),
+				Color: netBoxRoleColor,
+			})
+		}
+
+		if !deviceTypes[n.Kind] {
+			deviceTypes[n.Kind] = true
+
+			d.DeviceTypes = append(d.DeviceTypes, clabcorenetbox.DeviceType{
+				Manufacturer: manufacturer,
+				Model:        n.Kind,
+				Slug:         clabcorenetbox.Slug(n.Kind),
+			})
+		}
+
+		// the state of the deployed nodes is <state>/<status>, e.g. running/Up 5 minutes
+		status := clabcorenetbox.StatusPlanned
+		if strings.HasPrefix(n.State, "running") {
+			status = clabcorenetbox.StatusActive
+		}
+
+		d.Devices = append(d.Devices, clabcorenetbox.Device{
+			Name:         n.Name,
+			Role:         role,
+			Manufacturer: manufacturer,
+			DeviceType:   n.Kind,
+			Site:         site,
+			Status:       status,
+		})
+
+		mgmtIntf := netBoxMgmtInterface
+		if node, ok := c.Nodes[n.Name]; ok && node.Config().MgmtIntf != "" {
+			mgmtIntf = node.Config().MgmtIntf
+		}
+
+		addInterface(n.Name, mgmtIntf, true)
+
+		for _, addr := range []string{n.IPv4Address, n.IPv6Address} {
+			if addr == "" || addr == clabconstants.NotApplicable {
+				continue
+			}
+
+			d.IPAddresses = append(d.IPAddresses, clabcorenetbox.IPAddress{
+				Address:   c.netBoxAddress(addr),
+				Status:    clabcorenetbox.StatusActive,
+				Device:    n.Name,
+				Interface: mgmtIntf,
+				IsPrimary: true,
+			})
+		}
+	}
+
+	for _, l := range g.Links {
+		addInterface(l.Source, l.SourceEndpoint, false)
+		addInterface(l.Target, l.TargetEndpoint, false)
+
+		d.Cables = append(d.Cables, clabcorenetbox.Cable{
+			SideADevice: l.Source,
+			SideAType:   clabcorenetbox.CableTerminationType,
+			SideAName:   l.SourceEndpoint,
+			SideBDevice: l.Target,
+			SideBType:   clabcorenetbox.CableTerminationType,
+			SideBName:   l.TargetEndpoint,
+			Status:      clabcorenetbox.StatusConnected,
+		})
+	}
+
+	return d
+}
+
+// netBoxAddress returns the address with the prefix length, the addresses of the topology
+// file get the prefix length of the management subnet.
+func (c *CLab) netBoxAddress(addr string) string {
+	if strings.Contains(addr, "/") {
+		return addr
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return addr
+	}
+
+	subnet := ""
+	if c.Config.Mgmt != nil {
+		subnet = c.Config.Mgmt.IPv6Subnet
+		if ip.To4() != nil {
+			subnet = c.Config.Mgmt.IPv4Subnet
+		}
+	}
+
+	if _, ipNet, err := net.ParseCIDR(subnet); err == nil && ipNet.Contains(ip) {
+		ones, _ := ipNet.Mask.Size()
+
+		return addr + "/" + strconv.Itoa(ones)
+	}
+
+	if ip.To4() != nil {
+		return addr + "/32"
+	}
+
+	return addr + "/128"
+}
+
+// netBoxManufacturer returns the vendor prefix of the kind, e.g. nokia for nokia_srlinux.
+func netBoxManufacturer(kind string) string {
+	if vendor, _, ok := strings.Cut(kind, "_"); ok {
+		return vendor
+	}
+
+	return netBoxDefaultManufacturer
+}
+
+// GenerateNetBoxExport writes the NetBox import data of the lab graph to the directory,
+// either as the CSV file of every model or as a single JSON file.
+func (c *CLab) GenerateNetBoxExport(format, dir string, g *GraphTopo) error {
+	if dir == "" {
+		dir = c.TopoPaths.NetBoxDir()
+	}
+
+	switch format {
+	case NetBoxFormatCSV, NetBoxFormatJSON:
+	default:
+		return fmt.Errorf("unsupported export format %q, use one of %s, %s",
+			format, NetBoxFormatCSV, NetBoxFormatJSON)
+	}
+
+	clabutils.CreateDirectory(dir, clabconstants.PermissionsDirDefault)
+
+	d := c.NetBoxData(g)
+
+	if format == NetBoxFormatCSV {
+		if err := d.WriteCSV(dir); err != nil {
+			return err
+		}
+
+		log.Infof("Exported NetBox CSV files to %s", dir)
+
+		return nil
+	}
+
+	fname := filepath.Join(dir, netBoxFileName)
+
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := d.WriteJSON(f); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	log.Infof("Exported NetBox data to %s", fname)
+
+	return nil
+}
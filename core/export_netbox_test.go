@@ -0,0 +1,134 @@
+// Copyright 2020 Nokia
+// Licensed under the BSD 3-Clause License.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabcorenetbox "github.com/srl-labs/containerlab/core/netbox"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func netBoxTestGraph() *GraphTopo {
+	return &GraphTopo{
+		Nodes: []clabtypes.ContainerDetails{
+			{
+				Name: "srl1", Kind: "nokia_srlinux", Group: "spine", State: "running/Up 5 minutes",
+				IPv4Address: "172.20.20.2/24", IPv6Address: "N/A",
+			},
+			{Name: "client1", Kind: "linux", State: "N/A", IPv4Address: "172.20.20.3"},
+		},
+		Links: []Link{
+			{Source: "srl1", SourceEndpoint: "e1-1", Target: "client1", TargetEndpoint: "eth1"},
+		},
+	}
+}
+
+func TestNetBoxData(t *testing.T) {
+	c := newGraphTestCLab(t, nil)
+	c.Config.Mgmt = &clabtypes.MgmtNet{IPv4Subnet: "172.20.20.0/24"}
+
+	want := &clabcorenetbox.Data{
+		Sites: []clabcorenetbox.Site{
+			{
+				Name: "graphtest", Slug: "graphtest", Status: "active",
+				Description: "containerlab lab graphtest",
+			},
+		},
+		Manufacturers: []clabcorenetbox.Manufacturer{
+			{Name: "nokia", Slug: "nokia"},
+			{Name: "containerlab", Slug: "containerlab"},
+		},
+		DeviceRoles: []clabcorenetbox.DeviceRole{
+			{Name: "spine", Slug: "spine", Color: "9e9e9e"},
+			{Name: "lab-node", Slug: "lab-node", Color: "9e9e9e"},
+		},
+		DeviceTypes: []clabcorenetbox.DeviceType{
+			{Manufacturer: "nokia", Model: "nokia_srlinux", Slug: "nokia_srlinux"},
+			{Manufacturer: "containerlab", Model: "linux", Slug: "linux"},
+		},
+		Devices: []clabcorenetbox.Device{
+			{
+				Name: "srl1", Role: "spine", Manufacturer: "nokia",
+				DeviceType: "nokia_srlinux", Site: "graphtest", Status: "active",
+			},
+			{
+				Name: "client1", Role: "lab-node", Manufacturer: "containerlab",
+				DeviceType: "linux", Site: "graphtest", Status: "planned",
+			},
+		},
+		Interfaces: []clabcorenetbox.Interface{
+			{Device: "srl1", Name: "eth0", Type: "other", MgmtOnly: true},
+			{Device: "client1", Name: "eth0", Type: "other", MgmtOnly: true},
+			{Device: "srl1", Name: "e1-1", Type: "other"},
+			{Device: "client1", Name: "eth1", Type: "other"},
+		},
+		Cables: []clabcorenetbox.Cable{
+			{
+				SideADevice: "srl1", SideAType: "dcim.interface", SideAName: "e1-1",
+				SideBDevice: "client1", SideBType: "dcim.interface", SideBName: "eth1",
+				Status: "connected",
+			},
+		},
+		IPAddresses: []clabcorenetbox.IPAddress{
+			{
+				Address: "172.20.20.2/24", Status: "active", Device: "srl1",
+				Interface: "eth0", IsPrimary: true,
+			},
+			// the topology addresses get the prefix length of the management subnet
+			{
+				Address: "172.20.20.3/24", Status: "active", Device: "client1",
+				Interface: "eth0", IsPrimary: true,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, c.NetBoxData(netBoxTestGraph())); diff != "" {
+		t.Errorf("unexpected NetBox data (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNetBoxExport(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		files  []string
+	}{
+		"csv": {
+			format: NetBoxFormatCSV,
+			files: []string{
+				"sites.csv", "manufacturers.csv", "device_roles.csv", "device_types.csv",
+				"devices.csv", "interfaces.csv", "cables.csv", "ip_addresses.csv",
+			},
+		},
+		"json": {
+			format: NetBoxFormatJSON,
+			files:  []string{"netbox.json"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newGraphTestCLab(t, nil)
+
+			if err := c.GenerateNetBoxExport(tt.format, "", netBoxTestGraph()); err != nil {
+				t.Fatalf("GenerateNetBoxExport returned error: %v", err)
+			}
+
+			for _, f := range tt.files {
+				if _, err := os.Stat(filepath.Join(c.TopoPaths.NetBoxDir(), f)); err != nil {
+					t.Errorf("export file %s not written: %v", f, err)
+				}
+			}
+		})
+	}
+
+	c := newGraphTestCLab(t, nil)
+	if err := c.GenerateNetBoxExport("nautobot", "", netBoxTestGraph()); err == nil {
+		t.Error("expected an error for the unsupported format")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/hellt/envsubst"
@@ -88,6 +89,16 @@ func (c *CLab) LoadTopologyFromFile(topo string, varsFiles []string) error {
 		return err
 	}
 
+	// the lab is rolled back to the topology recorded in its state history
+	if c.topologyRevision > 0 {
+		yamlFile, err = c.loadTopologyRevision(yamlFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.renderedTopology = yamlFile
+
 	// save the rendered topology to disk if requested
 	if ExportRenderedTopology != "" {
 		if err := os.WriteFile(ExportRenderedTopology, yamlFile, 0644); err != nil {
@@ -109,7 +120,28 @@ func (c *CLab) LoadTopologyFromFile(topo string, varsFiles []string) error {
 
 	c.Config.Topology.ImportEnvs()
 
-	return nil
+	if err := c.Config.Topology.ExpandReplicas(); err != nil {
+		return err
+	}
+
+	// overrides address the replicated nodes and affect the kinds
+	// the link generators pick the interface names for
+	if err := c.applyTopologyOverrides(); err != nil {
+		return err
+	}
+
+	return c.Config.Topology.ExpandLinkGenerators(c.kindInterfaceFormat)
+}
+
+// kindInterfaceFormat returns the interface name format of a given kind
+// as registered in the node registry.
+func (c *CLab) kindInterfaceFormat(kind string) string {
+	regEntry := c.Reg.Kind(strings.ToLower(kind))
+	if regEntry == nil {
+		return ""
+	}
+
+	return regEntry.GetGenerateAttributes().GetInterfaceFormat()
 }
 
 func mergeTemplateVariables(dst, src any) any {
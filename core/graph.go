@@ -11,10 +11,13 @@ import (
 	"html/template"
 	"io"
 	"io/fs"
+	"maps"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -23,6 +26,7 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/google/shlex"
 	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorediagram "github.com/srl-labs/containerlab/core/diagram"
 	claberrors "github.com/srl-labs/containerlab/errors"
 	clabinternalmermaid "github.com/srl-labs/containerlab/internal/mermaid"
 	clabnodes "github.com/srl-labs/containerlab/nodes"
@@ -54,11 +58,15 @@ type Link struct {
 	SourceEndpoint string `json:"source_endpoint,omitempty"`
 	Target         string `json:"target,omitempty"`
 	TargetEndpoint string `json:"target_endpoint,omitempty"`
+	// Vars are the link-level vars of the topology link
+	Vars map[string]any `json:"vars,omitempty"`
 }
 
 type TopoData struct {
 	Name string
 	Data template.JS
+	// Live is set when the state of the lab is streamed to the graph on the /events path
+	Live bool
 }
 
 // noListFs embeds the http.Dir to override the Open method of a filesystem to prevent listing of
@@ -156,16 +164,19 @@ func (c *CLab) GenerateDotGraph(ctx context.Context) error {
 
 	pngfile := c.TopoPaths.GraphFilename(".png")
 
-	// Only try to create png
+	// the png is rendered natively when Graphviz is not installed
 	if commandExists("dot") {
 		err := generatePngFromDot(ctx, dotfile, pngfile)
 		if err != nil {
 			return err
 		}
-
-		log.Info("Created ", pngfile)
+	} else if err := renderDiagram(clabcorediagram.NewLayout(c.diagramGraph()), "png",
+		pngfile); err != nil {
+		return err
 	}
 
+	log.Info("Created ", pngfile)
+
 	return nil
 }
 
@@ -245,6 +256,8 @@ func (c *CLab) BuildGraphFromTopo(g *GraphTopo) {
 	for _, node := range c.Nodes {
 		g.Nodes = append(g.Nodes, buildGraphNode(node))
 	}
+
+	c.buildGraphLinks(g)
 }
 
 func (c *CLab) BuildGraphFromDeployedLab(g *GraphTopo, containers []clabruntime.GenericContainer) {
@@ -272,6 +285,36 @@ func (c *CLab) BuildGraphFromDeployedLab(g *GraphTopo, containers []clabruntime.
 			g.Nodes = append(g.Nodes, buildGraphNode(node))
 		}
 	}
+
+	c.buildGraphLinks(g)
+}
+
+// buildGraphLinks adds the point-to-point links of the lab to the graph
+// in the order of the topology links.
+func (c *CLab) buildGraphLinks(g *GraphTopo) {
+	for _, idx := range slices.Sorted(maps.Keys(c.Links)) {
+		link := c.Links[idx]
+
+		eps := link.GetEndpoints()
+		// Skip links that are not point-to-point (e.g. dummy, host, macvlan,
+		// mgmt-net links expose a single endpoint). Indexing eps[1] on such
+		// links would panic, so they are not rendered as edges.
+		if len(eps) != 2 {
+			log.Debugf(
+				"skipping non point-to-point link with %d endpoint(s) in graph topology",
+				len(eps),
+			)
+			continue
+		}
+
+		g.Links = append(g.Links, Link{
+			Source:         eps[0].GetNode().GetShortName(),
+			SourceEndpoint: eps[0].GetIfaceDisplayName(),
+			Target:         eps[1].GetNode().GetShortName(),
+			TargetEndpoint: eps[1].GetIfaceDisplayName(),
+			Vars:           link.GetVars(),
+		})
+	}
 }
 
 func (c *CLab) GenerateMermaidGraph(direction string) error {
@@ -318,13 +361,135 @@ func (c *CLab) GenerateMermaidGraph(direction string) error {
 	return nil
 }
 
+// graphLevelLabel is the node label setting the layer of the node in the rendered diagram.
+const graphLevelLabel = "graph-level"
+
+// GenerateDiagram renders the topology graph to the image files of the given formats,
+// svg and png, without Graphviz or Docker.
+func (c *CLab) GenerateDiagram(formats []string) error {
+	for _, format := range formats {
+		if format != "svg" && format != "png" {
+			return fmt.Errorf("unsupported diagram format %q, use svg or png", format)
+		}
+	}
+
+	// create graph directory
+	clabutils.CreateDirectory(c.TopoPaths.TopologyLabDir(), clabconstants.PermissionsDirDefault)
+	clabutils.CreateDirectory(c.TopoPaths.GraphDir(), clabconstants.PermissionsDirDefault)
+
+	layout := clabcorediagram.NewLayout(c.diagramGraph())
+
+	for _, format := range formats {
+		fname := c.TopoPaths.GraphFilename("." + format)
+
+		if err := renderDiagram(layout, format, fname); err != nil {
+			return err
+		}
+
+		log.Infof("Created %s", fname)
+	}
+
+	return nil
+}
+
+// diagramGraph returns the graph of the nodes and the point-to-point links of the lab.
+func (c *CLab) diagramGraph() *clabcorediagram.Graph {
+	g := &clabcorediagram.Graph{Name: c.Config.Name}
+
+	for _, node := range c.Nodes {
+		cfg := node.Config()
+
+		n := clabcorediagram.Node{
+			Name:  cfg.ShortName,
+			Kind:  cfg.Kind,
+			Group: cfg.Group,
+		}
+
+		if level := cfg.Labels[graphLevelLabel]; level != "" {
+			l, err := strconv.Atoi(level)
+			if err != nil || l < 1 {
+				log.Warnf("ignoring invalid %s label %q of node %s, expected a positive number",
+					graphLevelLabel, level, cfg.ShortName)
+			} else {
+				n.Level = l
+			}
+		}
+
+		if cfg.Position != "" {
+			p, err := clabcorediagram.ParsePosition(cfg.Position)
+			if err != nil {
+				log.Warnf("ignoring position of node %s: %v", cfg.ShortName, err)
+			} else {
+				n.Position = p
+			}
+		}
+
+		g.Nodes = append(g.Nodes, n)
+	}
+
+	for _, link := range c.Links {
+		eps := link.GetEndpoints()
+		// links that are not point-to-point are not drawn
+		if len(eps) != 2 {
+			continue
+		}
+
+		g.Links = append(g.Links, clabcorediagram.Link{
+			A:         eps[0].GetNode().GetShortName(),
+			AEndpoint: eps[0].GetIfaceDisplayName(),
+			B:         eps[1].GetNode().GetShortName(),
+			BEndpoint: eps[1].GetIfaceDisplayName(),
+		})
+	}
+
+	// the links are sorted for the parallel links to be drawn in the same order every time
+	sort.Slice(g.Links, func(i, j int) bool {
+		a, b := g.Links[i], g.Links[j]
+		if a.A != b.A {
+			return a.A < b.A
+		}
+
+		return a.AEndpoint < b.AEndpoint
+	})
+
+	return g
+}
+
+func renderDiagram(layout *clabcorediagram.Layout, format, fname string) error {
+	f, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("failed to create %s diagram file: %w", format, err)
+	}
+	defer f.Close()
+
+	switch format {
+	case "svg":
+		err = clabcorediagram.RenderSVG(f, layout)
+	case "png":
+		err = clabcorediagram.RenderPNG(f, layout)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write %s diagram file: %w", format, err)
+	}
+
+	return f.Close()
+}
+
 //go:embed graph_templates/nextui/nextui.html
 var defaultTemplate string
 
 //go:embed graph_templates/nextui/static
 var defaultStatic embed.FS
 
-func (c *CLab) ServeTopoGraph(tmpl, staticDir, srv string, topoD TopoData) error {
+// ServeTopoGraph serves the topology graph rendered with the template on the srv address.
+// When the live handler is set, it is served on the /events path the graph
+// subscribes to for the live state of the lab.
+func (c *CLab) ServeTopoGraph(
+	tmpl, staticDir, srv string,
+	topoD TopoData,
+	live http.Handler,
+) error {
 	var t *template.Template
 
 	switch {
@@ -359,6 +524,12 @@ func (c *CLab) ServeTopoGraph(tmpl, staticDir, srv string, topoD TopoData) error
 	svr := http.FileServer(noListFs{staticFS})
 	http.Handle("/static/", http.StripPrefix("/static/", svr))
 
+	if live != nil {
+		topoD.Live = true
+
+		http.Handle("/events", live)
+	}
+
 	http.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
 		_ = t.Execute(w, topoD)
 	})
@@ -0,0 +1,522 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	clabcoredependency_manager "github.com/srl-labs/containerlab/core/dependency_manager"
+	clabinternalmermaid "github.com/srl-labs/containerlab/internal/mermaid"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+// Reasons of the dependencies between the node stages.
+const (
+	DependencyReasonWaitFor     = "wait-for"
+	DependencyReasonNetworkMode = "network-mode"
+	DependencyReasonStaticMgmt  = "static-mgmt-ip"
+)
+
+// DependencyGraph is the graph of the dependencies between the node stages
+// the deployment is scheduled with.
+type DependencyGraph struct {
+	Lab          string                 `json:"lab"`
+	Nodes        []string               `json:"nodes"`
+	Dependencies []*DependencyGraphEdge `json:"dependencies"`
+	// Cycles are the groups of nodes depending on each other, the deployment
+	// is refused when the dependencies have cycles.
+	Cycles [][]string `json:"cycles,omitempty"`
+	// Unreachable are the node stages that would never be reached during the deployment.
+	Unreachable []*UnreachableStage `json:"unreachable,omitempty"`
+	// Warnings are the node stages whose reachability depends on the node image.
+	Warnings []*StageWarning `json:"warnings,omitempty"`
+}
+
+// DependencyGraphEdge is a dependency between two node stages.
+type DependencyGraphEdge struct {
+	clabcoredependency_manager.Dependency
+	// Reasons are the topology settings the dependency originates from.
+	Reasons []string `json:"reasons"`
+	// Cyclic marks the dependencies that are part of a cycle.
+	Cyclic bool `json:"cyclic,omitempty"`
+}
+
+// UnreachableStage is a node stage that would never be reached during the deployment.
+type UnreachableStage struct {
+	Node   string                 `json:"node"`
+	Stage  clabtypes.WaitForStage `json:"stage"`
+	Reason string                 `json:"reason"`
+}
+
+// StageWarning is a node stage that may not be reached during the deployment.
+type StageWarning struct {
+	Node   string                 `json:"node"`
+	Stage  clabtypes.WaitForStage `json:"stage"`
+	Reason string                 `json:"reason"`
+}
+
+// DependencyGraph creates the dependencies between the node stages the same way
+// the deployment does and returns their graph, with the dependency cycles
+// and the unreachable stages detected.
+func (c *CLab) DependencyGraph() (*DependencyGraph, error) {
+	if err := c.createDependencies(); err != nil {
+		return nil, err
+	}
+
+	g := &DependencyGraph{
+		Lab:          c.Config.Name,
+		Nodes:        c.sortedNodeNames(),
+		Dependencies: []*DependencyGraphEdge{},
+	}
+
+	seen := map[clabcoredependency_manager.Dependency]bool{}
+
+	for _, d := range c.dependencyManager.GetDependencies() {
+		// the same dependency is registered once per setting it originates from
+		if seen[*d] {
+			continue
+		}
+
+		seen[*d] = true
+
+		g.Dependencies = append(g.Dependencies, &DependencyGraphEdge{
+			Dependency: *d,
+			Reasons:    c.dependencyReasons(d),
+		})
+	}
+
+	g.Cycles = dependencyCycles(g.Dependencies)
+
+	cyclic := map[string]int{}
+
+	for i, cycle := range g.Cycles {
+		for _, n := range cycle {
+			cyclic[n] = i
+		}
+	}
+
+	for _, e := range g.Dependencies {
+		ci, ok1 := cyclic[e.Dependee]
+		cj, ok2 := cyclic[e.Depender]
+		e.Cyclic = ok1 && ok2 && ci == cj
+	}
+
+	g.Unreachable = c.unreachableStages(g.Dependencies)
+	g.Warnings = c.stageWarnings(g)
+
+	return g, nil
+}
+
+// dependencyReasons returns the topology settings the dependency originates from.
+func (c *CLab) dependencyReasons(d *clabcoredependency_manager.Dependency) []string {
+	var reasons []string
+
+	dependee, ok1 := c.Nodes[d.Dependee]
+	depender, ok2 := c.Nodes[d.Depender]
+
+	if !ok1 || !ok2 {
+		return reasons
+	}
+
+	for _, wf := range depender.Config().Stages.GetWaitFor()[d.DependerStage] {
+		if wf.Node == d.Dependee && wf.Stage == d.DependeeStage {
+			reasons = append(reasons, DependencyReasonWaitFor)
+
+			break
+		}
+	}
+
+	if d.DependeeStage != clabtypes.WaitForCreate || d.DependerStage != clabtypes.WaitForCreate {
+		return reasons
+	}
+
+	if depender.Config().NetworkMode == "container:"+d.Dependee {
+		reasons = append(reasons, DependencyReasonNetworkMode)
+	}
+
+	dependeeCfg, dependerCfg := dependee.Config(), depender.Config()
+	if (dependeeCfg.MgmtIPv4Address != "" || dependeeCfg.MgmtIPv6Address != "") &&
+		dependerCfg.MgmtIPv4Address == "" && dependerCfg.MgmtIPv6Address == "" {
+		reasons = append(reasons, DependencyReasonStaticMgmt)
+	}
+
+	return reasons
+}
+
+// dependencyCycles returns the groups of nodes depending on each other,
+// found as the strongly connected components of the node dependency graph.
+func dependencyCycles(edges []*DependencyGraphEdge) [][]string {
+	adj := map[string][]string{}
+
+	for _, e := range edges {
+		adj[e.Dependee] = append(adj[e.Dependee], e.Depender)
+	}
+
+	selfLoops := map[string]bool{}
+
+	for _, e := range edges {
+		if e.Dependee == e.Depender {
+			selfLoops[e.Dependee] = true
+		}
+	}
+
+	var cycles [][]string
+
+	for _, scc := range stronglyConnected(adj) {
+		if len(scc) > 1 || selfLoops[scc[0]] {
+			cycles = append(cycles, scc)
+		}
+	}
+
+	return cycles
+}
+
+// stageVertex is the vertex name of a node stage in the stage dependency graph.
+func stageVertex(node string, stage clabtypes.WaitForStage) string {
+	return node + "/" + string(stage)
+}
+
+// unreachableStages returns the node stages that would never be reached. A node goes through
+// its stages in order, so a stage is unreachable when it is part of a stage dependency cycle,
+// or when it waits for an unreachable stage.
+func (c *CLab) unreachableStages(edges []*DependencyGraphEdge) []*UnreachableStage {
+	adj := map[string][]string{}
+
+	// the stages of a node are entered in order
+	for _, n := range c.Nodes {
+		name := n.Config().ShortName
+
+		adj[stageVertex(name, clabtypes.WaitForCreate)] = []string{
+			stageVertex(name, clabtypes.WaitForCreateLinks),
+		}
+		adj[stageVertex(name, clabtypes.WaitForCreateLinks)] = []string{
+			stageVertex(name, clabtypes.WaitForConfigure),
+		}
+		adj[stageVertex(name, clabtypes.WaitForConfigure)] = []string{
+			stageVertex(name, clabtypes.WaitForHealthy),
+			stageVertex(name, clabtypes.WaitForExit),
+		}
+	}
+
+	for _, e := range edges {
+		from := stageVertex(e.Dependee, e.DependeeStage)
+		adj[from] = append(adj[from], stageVertex(e.Depender, e.DependerStage))
+	}
+
+	reasons := map[string]string{}
+
+	var queue []string
+
+	for _, scc := range stronglyConnected(adj) {
+		if len(scc) < 2 { //nolint: mnd
+			continue
+		}
+
+		for _, v := range scc {
+			reasons[v] = fmt.Sprintf("dependency cycle between %s", strings.Join(scc, ", "))
+			queue = append(queue, v)
+		}
+	}
+
+	// the stages waiting for an unreachable stage are not reached either
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+
+		for _, next := range adj[v] {
+			if _, ok := reasons[next]; ok {
+				continue
+			}
+
+			reasons[next] = fmt.Sprintf("waits for the unreachable %s stage", v)
+			queue = append(queue, next)
+		}
+	}
+
+	stages := make([]*UnreachableStage, 0, len(reasons))
+
+	for v, reason := range reasons {
+		node, stage, _ := strings.Cut(v, "/")
+
+		dn, err := c.dependencyManager.GetNode(node)
+		if err != nil {
+			continue
+		}
+
+		// the healthy and exit stages are only entered when other stages wait for them
+		s := clabtypes.WaitForStage(stage)
+		if (s == clabtypes.WaitForHealthy || s == clabtypes.WaitForExit) && !dn.MustWait(s) {
+			continue
+		}
+
+		stages = append(stages, &UnreachableStage{Node: node, Stage: s, Reason: reason})
+	}
+
+	stageOrder := clabtypes.GetWaitForStages()
+
+	slices.SortFunc(stages, func(a, b *UnreachableStage) int {
+		if n := clabutils.NaturalCompare(a.Node, b.Node); n != 0 {
+			return n
+		}
+
+		return slices.Index(stageOrder, a.Stage) - slices.Index(stageOrder, b.Stage)
+	})
+
+	return stages
+}
+
+// stageWarnings returns the healthy stages others wait for of the nodes without a topology
+// healthcheck. The runtime reports the node healthy when its image defines a healthcheck,
+// so the stage is only reached with such an image.
+func (c *CLab) stageWarnings(g *DependencyGraph) []*StageWarning {
+	warnings := []*StageWarning{}
+
+	for _, e := range g.Dependencies {
+		if e.DependeeStage != clabtypes.WaitForHealthy ||
+			g.isUnreachable(e.Dependee, e.DependeeStage) {
+			continue
+		}
+
+		n, ok := c.Nodes[e.Dependee]
+		if !ok || n.Config().Healthcheck != nil {
+			continue
+		}
+
+		if slices.ContainsFunc(warnings, func(w *StageWarning) bool {
+			return w.Node == e.Dependee
+		}) {
+			continue
+		}
+
+		warnings = append(warnings, &StageWarning{
+			Node:   e.Dependee,
+			Stage:  e.DependeeStage,
+			Reason: "no topology healthcheck, relies on the image healthcheck",
+		})
+	}
+
+	slices.SortFunc(warnings, func(a, b *StageWarning) int {
+		return clabutils.NaturalCompare(a.Node, b.Node)
+	})
+
+	return warnings
+}
+
+// stronglyConnected returns the strongly connected components of the directed graph
+// using the Tarjan's algorithm. The components and their vertices are sorted.
+func stronglyConnected(adj map[string][]string) [][]string {
+	vertices := map[string]struct{}{}
+
+	for v, next := range adj {
+		vertices[v] = struct{}{}
+
+		for _, n := range next {
+			vertices[n] = struct{}{}
+		}
+	}
+
+	var (
+		index   = map[string]int{}
+		low     = map[string]int{}
+		onStack = map[string]bool{}
+		stack   []string
+		sccs    [][]string
+		visit   func(v string)
+	)
+
+	visit = func(v string) {
+		index[v] = len(index)
+		low[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, seen := index[w]; !seen {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+
+		if low[v] != index[v] {
+			return
+		}
+
+		var scc []string
+
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+
+			if w == v {
+				break
+			}
+		}
+
+		slices.SortFunc(scc, clabutils.NaturalCompare)
+		sccs = append(sccs, scc)
+	}
+
+	for _, v := range sortedKeys(vertices) {
+		if _, seen := index[v]; !seen {
+			visit(v)
+		}
+	}
+
+	slices.SortFunc(sccs, func(a, b []string) int {
+		return clabutils.NaturalCompare(a[0], b[0])
+	})
+
+	return sccs
+}
+
+// isUnreachable returns true if the node stage is unreachable.
+func (g *DependencyGraph) isUnreachable(node string, stage clabtypes.WaitForStage) bool {
+	return slices.ContainsFunc(g.Unreachable, func(u *UnreachableStage) bool {
+		return u.Node == node && u.Stage == stage
+	})
+}
+
+// stages returns the stages of the node taking part in the dependencies, in the stage order.
+func (g *DependencyGraph) stages(node string) []clabtypes.WaitForStage {
+	var stages []clabtypes.WaitForStage
+
+	for _, s := range clabtypes.GetWaitForStages() {
+		used := slices.ContainsFunc(g.Dependencies, func(e *DependencyGraphEdge) bool {
+			return (e.Dependee == node && e.DependeeStage == s) ||
+				(e.Depender == node && e.DependerStage == s)
+		})
+
+		if used || g.isUnreachable(node, s) {
+			stages = append(stages, s)
+		}
+	}
+
+	return stages
+}
+
+// JSON returns the dependency graph in the JSON format.
+func (g *DependencyGraph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// Mermaid returns the dependency graph as a mermaid flowchart. Every node is rendered
+// as a subgraph of its stages, the cyclic dependencies and the unreachable stages
+// are highlighted in red.
+func (g *DependencyGraph) Mermaid(direction string) (string, error) {
+	// the direction is validated the same way as for the topology graph
+	if err := clabinternalmermaid.NewFlowChart().SetDirection(direction); err != nil {
+		return "", err
+	}
+
+	var w strings.Builder
+
+	fmt.Fprintf(&w, "---\ntitle: %s dependencies\n---\n", g.Lab)
+	fmt.Fprintf(&w, "graph %s\n", direction)
+
+	ids := map[string]string{}
+
+	for i, n := range g.Nodes {
+		fmt.Fprintf(&w, "  subgraph n%d [%q]\n", i, n)
+
+		stages := g.stages(n)
+		if len(stages) == 0 {
+			// nodes without dependencies are shown with their create stage
+			stages = []clabtypes.WaitForStage{clabtypes.WaitForCreate}
+		}
+
+		for _, s := range stages {
+			id := fmt.Sprintf("n%d_%s", i, strings.ReplaceAll(string(s), "-", "_"))
+			ids[stageVertex(n, s)] = id
+
+			class := ""
+			if g.isUnreachable(n, s) {
+				class = ":::unreachable"
+			}
+
+			fmt.Fprintf(&w, "    %s[%q]%s\n", id, string(s), class)
+		}
+
+		fmt.Fprintf(&w, "  end\n")
+	}
+
+	var cyclic []string
+
+	for i, e := range g.Dependencies {
+		arrow := "-->"
+		if len(e.Reasons) > 0 {
+			arrow += "|" + strings.Join(e.Reasons, ", ") + "|"
+		}
+
+		fmt.Fprintf(&w, "  %s %s %s\n",
+			ids[stageVertex(e.Dependee, e.DependeeStage)],
+			arrow,
+			ids[stageVertex(e.Depender, e.DependerStage)],
+		)
+
+		if e.Cyclic {
+			cyclic = append(cyclic, strconv.Itoa(i))
+		}
+	}
+
+	fmt.Fprintf(&w, "  classDef unreachable fill:#f88,stroke:#c00\n")
+
+	if len(cyclic) > 0 {
+		fmt.Fprintf(&w, "  linkStyle %s stroke:#c00,stroke-width:2px\n", strings.Join(cyclic, ","))
+	}
+
+	return w.String(), nil
+}
+
+// Dot returns the dependency graph in the graphviz dot format. Every node is rendered
+// as a cluster of its stages, the cyclic dependencies and the unreachable stages
+// are highlighted in red.
+func (g *DependencyGraph) Dot() string {
+	var w strings.Builder
+
+	fmt.Fprintf(&w, "digraph %s {\n", dotIdentifier(g.Lab+" dependencies"))
+	fmt.Fprintf(&w, "  rankdir=LR;\n  node [shape=box, style=rounded];\n")
+
+	for i, n := range g.Nodes {
+		fmt.Fprintf(&w, "  subgraph cluster_%d {\n    label=%s;\n", i, dotIdentifier(n))
+
+		stages := g.stages(n)
+		if len(stages) == 0 {
+			stages = []clabtypes.WaitForStage{clabtypes.WaitForCreate}
+		}
+
+		for _, s := range stages {
+			attrs := fmt.Sprintf("label=%s", dotIdentifier(string(s)))
+			if g.isUnreachable(n, s) {
+				attrs += ", style=\"rounded,filled\", fillcolor=red, color=red"
+			}
+
+			fmt.Fprintf(&w, "    %s [%s];\n", dotIdentifier(stageVertex(n, s)), attrs)
+		}
+
+		fmt.Fprintf(&w, "  }\n")
+	}
+
+	for _, e := range g.Dependencies {
+		attrs := fmt.Sprintf("label=%s", dotIdentifier(strings.Join(e.Reasons, ", ")))
+		if e.Cyclic {
+			attrs += ", color=red, penwidth=2"
+		}
+
+		fmt.Fprintf(&w, "  %s -> %s [%s];\n",
+			dotIdentifier(stageVertex(e.Dependee, e.DependeeStage)),
+			dotIdentifier(stageVertex(e.Depender, e.DependerStage)),
+			attrs,
+		)
+	}
+
+	fmt.Fprintf(&w, "}\n")
+
+	return w.String()
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	c, err := NewContainerLab(WithTopoPath("test_data/topo_deps.yml", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := c.DependencyGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := cmp.Diff([][]string{{"n4", "n5"}}, g.Cycles); d != "" {
+		t.Errorf("cycles mismatch (-want +got):\n%s", d)
+	}
+
+	type edge struct {
+		dependee string
+		depender string
+		reasons  string
+		cyclic   bool
+	}
+
+	edges := map[edge]bool{}
+	for _, e := range g.Dependencies {
+		edges[edge{
+			dependee: e.Dependee + "/" + string(e.DependeeStage),
+			depender: e.Depender + "/" + string(e.DependerStage),
+			reasons:  strings.Join(e.Reasons, ","),
+			cyclic:   e.Cyclic,
+		}] = true
+	}
+
+	for _, want := range []edge{
+		{"n1/healthy", "n2/create", DependencyReasonWaitFor, false},
+		{"n1/create", "n2/create", DependencyReasonStaticMgmt, false},
+		{"n2/create", "n3/create", DependencyReasonNetworkMode, false},
+		{"n4/configure", "n5/create", DependencyReasonWaitFor, true},
+		{"n5/configure", "n4/create", DependencyReasonWaitFor, true},
+	} {
+		if !edges[want] {
+			t.Errorf("dependency %+v not found", want)
+		}
+	}
+
+	unreachable := map[string]bool{}
+	for _, u := range g.Unreachable {
+		unreachable[stageVertex(u.Node, u.Stage)] = true
+	}
+
+	// n2 is created after the nodes with a static management address forming a cycle
+	for _, v := range []string{"n2/create", "n3/configure", "n4/create", "n5/configure"} {
+		if !unreachable[v] {
+			t.Errorf("stage %s is expected to be unreachable", v)
+		}
+	}
+
+	// the healthy stage of a node without a topology healthcheck is reached
+	// with an image healthcheck, it is only warned about
+	for _, v := range []string{"n1/create", "n1/healthy"} {
+		if unreachable[v] {
+			t.Errorf("stage %s is expected to be reachable", v)
+		}
+	}
+
+	wantWarnings := []*StageWarning{{
+		Node:   "n1",
+		Stage:  clabtypes.WaitForHealthy,
+		Reason: "no topology healthcheck, relies on the image healthcheck",
+	}}
+
+	if d := cmp.Diff(wantWarnings, g.Warnings); d != "" {
+		t.Errorf("warnings mismatch (-want +got):\n%s", d)
+	}
+
+	mermaid, err := g.Mermaid("LR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"graph LR",
+		`n0_healthy["healthy"]`,
+		"n0_healthy -->|wait-for| n1_create",
+		"linkStyle 6,9 stroke:#c00",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid graph does not contain %q:\n%s", want, mermaid)
+		}
+	}
+
+	dot := g.Dot()
+	for _, want := range []string{
+		`"n1/healthy" -> "n2/create" [label="wait-for"];`,
+		`"n4/configure" -> "n5/create" [label="wait-for", color=red, penwidth=2];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot graph does not contain %q:\n%s", want, dot)
+		}
+	}
+}
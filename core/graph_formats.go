@@ -0,0 +1,556 @@
+// Copyright 2020 Nokia
+// Licensed under the BSD 3-Clause License.
+// SPDX-License-Identifier: BSD-3-Clause
+
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+// graphFormat is a structured output format of the topology graph.
+type graphFormat struct {
+	ext   string
+	write func(w io.Writer, name string, g *GraphTopo) error
+}
+
+// graphFormats are the graph formats keyed by their name.
+var graphFormats = map[string]graphFormat{ //nolint:gochecknoglobals
+	"d2":        {ext: ".d2", write: writeD2Graph},
+	"plantuml":  {ext: ".puml", write: writePlantUMLGraph},
+	"graphml":   {ext: ".graphml", write: writeGraphMLGraph},
+	"cytoscape": {ext: ".cyjs", write: writeCytoscapeGraph},
+}
+
+// GraphFormats returns the names of the structured graph formats.
+func GraphFormats() []string {
+	return slices.Sorted(maps.Keys(graphFormats))
+}
+
+// GenerateGraphFile writes the graph in the given format to the graph directory of the lab.
+func (c *CLab) GenerateGraphFile(format string, g *GraphTopo) error {
+	f, ok := graphFormats[format]
+	if !ok {
+		return fmt.Errorf("unsupported graph format %q, use one of %v", format, GraphFormats())
+	}
+
+	// create graph directory
+	clabutils.CreateDirectory(c.TopoPaths.TopologyLabDir(), clabconstants.PermissionsDirDefault)
+	clabutils.CreateDirectory(c.TopoPaths.GraphDir(), clabconstants.PermissionsDirDefault)
+
+	fname := c.TopoPaths.GraphFilename(f.ext)
+
+	file, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("failed to create %s graph file: %w", format, err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+
+	if err := f.write(w, c.Config.Name, g); err != nil {
+		return fmt.Errorf("failed to write %s graph file: %w", format, err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s graph file: %w", format, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	log.Infof("Created %s graph file: %s", format, fname)
+
+	return nil
+}
+
+// graphGroups returns the groups of the graph nodes in alphabetical order and the nodes
+// of every group, the nodes without a group are returned under the empty group.
+func graphGroups(g *GraphTopo) ([]string, map[string][]clabtypes.ContainerDetails) {
+	members := make(map[string][]clabtypes.ContainerDetails)
+
+	for _, n := range g.Nodes {
+		members[n.Group] = append(members[n.Group], n)
+	}
+
+	groups := make([]string, 0, len(members))
+	for group := range members {
+		if group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Strings(groups)
+
+	return groups, members
+}
+
+// graphAddress returns the management address of the node, empty when it is not applicable.
+func graphAddress(addr string) string {
+	if addr == clabconstants.NotApplicable {
+		return ""
+	}
+
+	return addr
+}
+
+// graphNodeDetails returns the kind and the management addresses of the node.
+func graphNodeDetails(n *clabtypes.ContainerDetails) []string {
+	var details []string
+
+	for _, d := range []string{n.Kind, graphAddress(n.IPv4Address), graphAddress(n.IPv6Address)} {
+		if d != "" {
+			details = append(details, d)
+		}
+	}
+
+	return details
+}
+
+// formatLinkVars formats the link vars as the comma separated key=value pairs sorted
+// by the key, the values that are not strings are formatted as JSON.
+func formatLinkVars(vars map[string]any) string {
+	pairs := make([]string, 0, len(vars))
+
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		value, ok := vars[key].(string)
+		if !ok {
+			b, _ := json.Marshal(vars[key])
+			value = string(b)
+		}
+
+		pairs = append(pairs, key+"="+value)
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+// writeD2Graph writes the graph in the D2 language, the groups are the containers
+// of their nodes.
+func writeD2Graph(w io.Writer, name string, g *GraphTopo) error {
+	groups, members := graphGroups(g)
+
+	// path of the node in the diagram keyed by the node name
+	paths := make(map[string]string, len(g.Nodes))
+
+	fmt.Fprintf(w, "# %s\n", name)
+	fmt.Fprintln(w, "direction: down")
+
+	writeNodes := func(indent string, nodes []clabtypes.ContainerDetails, parent string) {
+		for i := range nodes {
+			n := &nodes[i]
+
+			paths[n.Name] = parent + strconv.Quote(n.Name)
+
+			label := strings.Join(append([]string{n.Name}, graphNodeDetails(n)...), "\n")
+
+			fmt.Fprintf(w, "%s%s: %s\n", indent, strconv.Quote(n.Name), strconv.Quote(label))
+		}
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(w, "%s: {\n", strconv.Quote(group))
+		writeNodes("  ", members[group], strconv.Quote(group)+".")
+		fmt.Fprintln(w, "}")
+	}
+
+	writeNodes("", members[""], "")
+
+	for _, link := range g.Links {
+		source, okSource := paths[link.Source]
+		target, okTarget := paths[link.Target]
+
+		if !okSource || !okTarget {
+			continue
+		}
+
+		fmt.Fprintf(w, "%s -- %s: {\n", source, target)
+		fmt.Fprintf(w, "  source-arrowhead.label: %s\n", strconv.Quote(link.SourceEndpoint))
+		fmt.Fprintf(w, "  target-arrowhead.label: %s\n", strconv.Quote(link.TargetEndpoint))
+
+		if len(link.Vars) > 0 {
+			fmt.Fprintf(w, "  tooltip: %s\n", strconv.Quote(formatLinkVars(link.Vars)))
+		}
+
+		fmt.Fprintln(w, "}")
+	}
+
+	return nil
+}
+
+// plantUMLIdentifierRe matches the characters not allowed in the nwdiag identifiers.
+var plantUMLIdentifierRe = regexp.MustCompile(`[^A-Za-z0-9_]`) //nolint:gochecknoglobals
+
+func plantUMLIdentifier(name string) string {
+	return plantUMLIdentifierRe.ReplaceAllString(name, "_")
+}
+
+// plantUMLString returns the double quoted string, nwdiag has no escape for the quotes.
+func plantUMLString(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// writePlantUMLGraph writes the graph as a PlantUML network diagram, every point-to-point
+// link is a network of its two endpoints and the management addresses form
+// the management network.
+func writePlantUMLGraph(w io.Writer, name string, g *GraphTopo) error {
+	groups, members := graphGroups(g)
+
+	nodes := make(map[string]bool, len(g.Nodes))
+
+	fmt.Fprintln(w, "@startuml")
+	fmt.Fprintf(w, "title %s\n", name)
+	fmt.Fprintln(w, "nwdiag {")
+
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+		nodes[n.Name] = true
+
+		description := n.Name
+		if n.Kind != "" {
+			description += `\n` + n.Kind
+		}
+
+		fmt.Fprintf(w, "  %s [description = %s];\n",
+			plantUMLIdentifier(n.Name), plantUMLString(description))
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(w, "  group %s {\n", plantUMLIdentifier(group))
+		fmt.Fprintf(w, "    description = %s;\n", plantUMLString(group))
+
+		for _, n := range members[group] {
+			fmt.Fprintf(w, "    %s;\n", plantUMLIdentifier(n.Name))
+		}
+
+		fmt.Fprintln(w, "  }")
+	}
+
+	var mgmt []string
+
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+
+		addrs := slices.DeleteFunc(
+			[]string{graphAddress(n.IPv4Address), graphAddress(n.IPv6Address)},
+			func(s string) bool { return s == "" })
+		if len(addrs) == 0 {
+			continue
+		}
+
+		mgmt = append(mgmt, fmt.Sprintf("    %s [address = %s];\n",
+			plantUMLIdentifier(n.Name), plantUMLString(strings.Join(addrs, ", "))))
+	}
+
+	if len(mgmt) > 0 {
+		fmt.Fprintln(w, "  network mgmt {")
+		fmt.Fprintln(w, `    description = "management";`)
+
+		for _, m := range mgmt {
+			fmt.Fprint(w, m)
+		}
+
+		fmt.Fprintln(w, "  }")
+	}
+
+	idx := 0
+
+	for _, link := range g.Links {
+		if !nodes[link.Source] || !nodes[link.Target] {
+			continue
+		}
+
+		idx++
+
+		description := fmt.Sprintf("%s:%s - %s:%s",
+			link.Source, link.SourceEndpoint, link.Target, link.TargetEndpoint)
+		if len(link.Vars) > 0 {
+			description += " (" + formatLinkVars(link.Vars) + ")"
+		}
+
+		fmt.Fprintf(w, "  network link%d {\n", idx)
+		fmt.Fprintf(w, "    description = %s;\n", plantUMLString(description))
+		fmt.Fprintf(w, "    %s [address = %s];\n",
+			plantUMLIdentifier(link.Source), plantUMLString(link.SourceEndpoint))
+		fmt.Fprintf(w, "    %s [address = %s];\n",
+			plantUMLIdentifier(link.Target), plantUMLString(link.TargetEndpoint))
+		fmt.Fprintln(w, "  }")
+	}
+
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "@enduml")
+
+	return nil
+}
+
+type graphML struct {
+	XMLName xml.Name      `xml:"graphml"`
+	XMLNS   string        `xml:"xmlns,attr"`
+	Keys    []graphMLKey  `xml:"key"`
+	Graph   graphMLSubset `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+// graphMLSubset is a graph or a nested graph of a group.
+type graphMLSubset struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Data        []graphMLData `xml:"data,omitempty"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge,omitempty"`
+}
+
+type graphMLNode struct {
+	ID    string         `xml:"id,attr"`
+	Data  []graphMLData  `xml:"data"`
+	Graph *graphMLSubset `xml:"graph,omitempty"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphMLNodeKeys are the node attributes of the GraphML graph.
+var graphMLNodeKeys = []string{ //nolint:gochecknoglobals
+	"label", "kind", "image", "group", "state", "ipv4_address", "ipv6_address",
+}
+
+// graphMLEdgeKeys are the edge attributes of the GraphML graph.
+var graphMLEdgeKeys = []string{ //nolint:gochecknoglobals
+	"source_endpoint", "target_endpoint", "vars",
+}
+
+// graphMLValues returns the data elements of the non-empty values.
+func graphMLValues(kv ...string) []graphMLData {
+	var data []graphMLData
+
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			data = append(data, graphMLData{Key: kv[i], Value: kv[i+1]})
+		}
+	}
+
+	return data
+}
+
+// writeGraphMLGraph writes the graph in the GraphML format, the groups are the nodes
+// with the nested graphs of their nodes.
+func writeGraphMLGraph(w io.Writer, name string, g *GraphTopo) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLSubset{
+			ID:          "G",
+			EdgeDefault: "undirected",
+			Data:        graphMLValues("label", name),
+		},
+	}
+
+	doc.Keys = append(doc.Keys, graphMLKey{ID: "label", For: "graph", Name: "label", Type: "string"})
+
+	for _, key := range graphMLNodeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{
+			ID: "n_" + key, For: "node", Name: key, Type: "string",
+		})
+	}
+
+	for _, key := range graphMLEdgeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{
+			ID: "e_" + key, For: "edge", Name: key, Type: "string",
+		})
+	}
+
+	groups, members := graphGroups(g)
+
+	nodes := func(nodes []clabtypes.ContainerDetails) []graphMLNode {
+		result := make([]graphMLNode, 0, len(nodes))
+
+		for i := range nodes {
+			n := &nodes[i]
+
+			result = append(result, graphMLNode{
+				ID: n.Name,
+				Data: graphMLValues(
+					"n_label", n.Name,
+					"n_kind", n.Kind,
+					"n_image", n.Image,
+					"n_group", n.Group,
+					"n_state", n.State,
+					"n_ipv4_address", graphAddress(n.IPv4Address),
+					"n_ipv6_address", graphAddress(n.IPv6Address),
+				),
+			})
+		}
+
+		return result
+	}
+
+	for _, group := range groups {
+		id := "group:" + group
+
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID:   id,
+			Data: graphMLValues("n_label", group),
+			Graph: &graphMLSubset{
+				ID:          id + ":",
+				EdgeDefault: "undirected",
+				Nodes:       nodes(members[group]),
+			},
+		})
+	}
+
+	doc.Graph.Nodes = append(doc.Graph.Nodes, nodes(members[""])...)
+
+	for i, link := range g.Links {
+		var vars string
+
+		if len(link.Vars) > 0 {
+			b, err := json.Marshal(link.Vars)
+			if err != nil {
+				return err
+			}
+
+			vars = string(b)
+		}
+
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: link.Source,
+			Target: link.Target,
+			Data: graphMLValues(
+				"e_source_endpoint", link.SourceEndpoint,
+				"e_target_endpoint", link.TargetEndpoint,
+				"e_vars", vars,
+			),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+type cytoscapeGraph struct {
+	Data     map[string]string `json:"data"`
+	Elements cytoscapeElements `json:"elements"`
+}
+
+type cytoscapeElements struct {
+	Nodes []cytoscapeElement `json:"nodes"`
+	Edges []cytoscapeElement `json:"edges"`
+}
+
+type cytoscapeElement struct {
+	Data map[string]any `json:"data"`
+}
+
+// writeCytoscapeGraph writes the graph as the Cytoscape.js JSON elements, the groups are
+// the compound nodes of their nodes.
+func writeCytoscapeGraph(w io.Writer, name string, g *GraphTopo) error {
+	doc := cytoscapeGraph{
+		Data: map[string]string{"name": name},
+		Elements: cytoscapeElements{
+			Nodes: []cytoscapeElement{},
+			Edges: []cytoscapeElement{},
+		},
+	}
+
+	groups, _ := graphGroups(g)
+
+	for _, group := range groups {
+		doc.Elements.Nodes = append(doc.Elements.Nodes, cytoscapeElement{Data: map[string]any{
+			"id":    "group:" + group,
+			"label": group,
+		}})
+	}
+
+	for i := range g.Nodes {
+		n := &g.Nodes[i]
+
+		data := map[string]any{
+			"id":    n.Name,
+			"label": n.Name,
+		}
+
+		for key, value := range map[string]string{
+			"kind":         n.Kind,
+			"image":        n.Image,
+			"group":        n.Group,
+			"state":        n.State,
+			"ipv4_address": graphAddress(n.IPv4Address),
+			"ipv6_address": graphAddress(n.IPv6Address),
+		} {
+			if value != "" {
+				data[key] = value
+			}
+		}
+
+		if n.Group != "" {
+			data["parent"] = "group:" + n.Group
+		}
+
+		doc.Elements.Nodes = append(doc.Elements.Nodes, cytoscapeElement{Data: data})
+	}
+
+	for i, link := range g.Links {
+		data := map[string]any{
+			"id":              "e" + strconv.Itoa(i),
+			"source":          link.Source,
+			"target":          link.Target,
+			"source_endpoint": link.SourceEndpoint,
+			"target_endpoint": link.TargetEndpoint,
+		}
+
+		if len(link.Vars) > 0 {
+			data["vars"] = link.Vars
+		}
+
+		doc.Elements.Edges = append(doc.Elements.Edges, cytoscapeElement{Data: data})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(doc)
+}
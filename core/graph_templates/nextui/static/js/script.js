@@ -300,8 +300,130 @@
 
     topo.on('ready', function () {
         topo.data(data);
+
+        // the graph of a deployed lab follows the lab state streamed by containerlab
+        if (typeof live !== 'undefined' && live) {
+            connectLiveState();
+        }
     });
 
+    // live state of the lab nodes and interfaces, the interfaces are keyed by node/interface
+    var liveNodes = {};
+    var liveInterfaces = {};
+    var liveRetryInterval = 2000;
+
+    var nodeColors = {
+        running: '#16a34a',
+        stopped: '#9ca3af',
+        destroyed: '#9ca3af',
+    };
+    var healthColors = {
+        unhealthy: '#dc2626',
+        starting: '#f59e0b',
+    };
+    var linkColors = {
+        up: '#16a34a',
+        down: '#dc2626',
+        impaired: '#f59e0b',
+    };
+
+    function connectLiveState() {
+        var scheme = window.location.protocol === 'https:' ? 'wss://' : 'ws://';
+        var ws = new WebSocket(scheme + window.location.host + '/events');
+
+        ws.onopen = function () {
+            setLiveStatus('Live state of the lab');
+        };
+        ws.onmessage = function (msg) {
+            applyLiveUpdate(JSON.parse(msg.data));
+        };
+        ws.onclose = function () {
+            setLiveStatus('Disconnected from the lab, reconnecting...');
+            setTimeout(connectLiveState, liveRetryInterval);
+        };
+    }
+
+    function setLiveStatus(text) {
+        var el = document.getElementById('live-status');
+        if (el) {
+            el.textContent = text;
+        }
+    }
+
+    function applyLiveUpdate(update) {
+        if (update.type === 'node') {
+            liveNodes[update.node] = update;
+            updateNode(update);
+        } else if (update.type === 'interface') {
+            liveInterfaces[update.node + '/' + update.interface] = update;
+        }
+
+        updateLinks(update.node);
+    }
+
+    function updateNode(update) {
+        var node = topo.getNode(update.node);
+        if (!node) {
+            return;
+        }
+
+        node.color(healthColors[update.health] || nodeColors[update.state] || nodeColors.stopped);
+        node.model().set('state', update.health ? update.state + ' (' + update.health + ')' : update.state);
+    }
+
+    // updateLinks colors the links of the node by the oper-state of their interfaces,
+    // highlights the impaired links and labels them with the throughput
+    function updateLinks(name) {
+        topo.eachLink(function (link) {
+            var source = link.sourceNode().model().get('name');
+            var target = link.targetNode().model().get('name');
+            if (source !== name && target !== name) {
+                return;
+            }
+
+            var ends = [
+                liveInterfaces[source + '/' + link.sourcelabel()],
+                liveInterfaces[target + '/' + link.targetlabel()],
+            ].filter(function (end) { return end; });
+            if (ends.length === 0) {
+                return;
+            }
+
+            var up = ends.every(function (end) { return end.state === 'up'; });
+            var netem = ends.filter(function (end) { return end.netem; }).map(function (end) {
+                return end.node + ' ' + Object.keys(end.netem).map(function (key) {
+                    return key + ' ' + end.netem[key];
+                }).join(', ');
+            });
+
+            link.color(netem.length > 0 ? linkColors.impaired : (up ? linkColors.up : linkColors.down));
+            link.dotted(netem.length > 0);
+
+            var label = [];
+            if (up) {
+                label.push(throughput(ends[0]));
+            }
+            link.label(label.concat(netem).join(' | ') || null);
+        });
+    }
+
+    // throughput returns the rate the interface transmits and receives at
+    function throughput(end) {
+        return end.interface + ' tx ' + formatBps(end.tx_bps) + ' rx ' + formatBps(end.rx_bps);
+    }
+
+    function formatBps(bps) {
+        var units = ['bps', 'kbps', 'Mbps', 'Gbps'];
+        var value = bps || 0;
+        var idx = 0;
+        while (value >= 1000 && idx < units.length - 1) {
+            value /= 1000;
+            idx++;
+        }
+
+        return value.toFixed(idx === 0 ? 0 : 1) + ' ' + units[idx];
+    }
+
     adaptToContainer = function () {
         topo.adaptToContainer();
     };
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	clablinks "github.com/srl-labs/containerlab/links"
@@ -17,6 +18,7 @@ import (
 	clabtypes "github.com/srl-labs/containerlab/types"
 
 	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/google/go-cmp/cmp"
 	"github.com/vishvananda/netlink"
 )
 
@@ -231,3 +233,133 @@ func TestGenerateMermaidGraphSkipsNonPointToPointLinks(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateDiagram(t *testing.T) {
+	c := newGraphTestCLab(t, map[int]clablinks.Link{
+		0: newVethLink("node1", "node2"),
+		1: newDummyLink("node1"),
+	})
+
+	if err := c.GenerateDiagram([]string{"svg", "png"}); err != nil {
+		t.Fatalf("GenerateDiagram returned error: %v", err)
+	}
+
+	if out := readGraphFile(t, c, ".svg"); !strings.Contains(out, "<title>graphtest</title>") {
+		t.Errorf("unexpected svg diagram:\n%s", out)
+	}
+
+	if out := readGraphFile(t, c, ".png"); !strings.HasPrefix(out, "\x89PNG") {
+		t.Error("the png diagram is not a PNG image")
+	}
+
+	if err := c.GenerateDiagram([]string{"jpg"}); err == nil {
+		t.Error("expected an error for an unsupported diagram format")
+	}
+}
+
+func TestBuildGraphFromTopoLinks(t *testing.T) {
+	c := newGraphTestCLab(t, map[int]clablinks.Link{
+		0: newVethLink("node1", "node2"),
+		1: newDummyLink("node1"),
+		2: newVethLink("node2", "node3"),
+	})
+
+	g := &GraphTopo{}
+	c.BuildGraphFromTopo(g)
+
+	want := []Link{
+		{Source: "node1", SourceEndpoint: "eth1", Target: "node2", TargetEndpoint: "eth1"},
+		{Source: "node2", SourceEndpoint: "eth1", Target: "node3", TargetEndpoint: "eth1"},
+	}
+
+	if diff := cmp.Diff(want, g.Links); diff != "" {
+		t.Errorf("unexpected graph links (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateGraphFile(t *testing.T) {
+	g := &GraphTopo{
+		Nodes: []clabtypes.ContainerDetails{
+			{
+				Name: "spine1", Kind: "nokia_srlinux", Group: "spine",
+				IPv4Address: "172.20.20.2/24", IPv6Address: "N/A",
+			},
+			{Name: "leaf1", Kind: "nokia_srlinux", Group: "leaf", IPv4Address: "172.20.20.3/24"},
+			{Name: "client1", Kind: "linux"},
+		},
+		Links: []Link{
+			{
+				Source: "spine1", SourceEndpoint: "e1-1", Target: "leaf1", TargetEndpoint: "e1-49",
+				Vars: map[string]any{"mtu": 9000, "vlan": "10"},
+			},
+			{Source: "leaf1", SourceEndpoint: "e1-1", Target: "client1", TargetEndpoint: "eth1"},
+		},
+	}
+
+	tests := map[string]struct {
+		ext  string
+		want []string
+	}{
+		"d2": {
+			ext: ".d2",
+			want: []string{
+				`"spine": {`,
+				`  "spine1": "spine1\nnokia_srlinux\n172.20.20.2/24"`,
+				`"client1": "client1\nlinux"`,
+				`"spine"."spine1" -- "leaf"."leaf1": {`,
+				`  source-arrowhead.label: "e1-1"`,
+				`  tooltip: "mtu=9000, vlan=10"`,
+			},
+		},
+		"plantuml": {
+			ext: ".puml",
+			want: []string{
+				"@startuml", `spine1 [description = "spine1\nnokia_srlinux"];`,
+				"group spine {", "network mgmt {", `leaf1 [address = "172.20.20.3/24"];`,
+				`description = "spine1:e1-1 - leaf1:e1-49 (mtu=9000, vlan=10)";`,
+				`client1 [address = "eth1"];`, "@enduml",
+			},
+		},
+		"graphml": {
+			ext: ".graphml",
+			want: []string{
+				`<node id="group:spine">`, `<data key="n_ipv4_address">172.20.20.2/24</data>`,
+				`<edge id="e0" source="spine1" target="leaf1">`,
+				`<data key="e_vars">{&#34;mtu&#34;:9000,&#34;vlan&#34;:&#34;10&#34;}</data>`,
+			},
+		},
+		"cytoscape": {
+			ext: ".cyjs",
+			want: []string{
+				`"id": "group:leaf"`, `"parent": "group:leaf"`, `"ipv4_address": "172.20.20.3/24"`,
+				`"source_endpoint": "e1-1"`, `"mtu": 9000`,
+			},
+		},
+	}
+
+	for format, tt := range tests {
+		t.Run(format, func(t *testing.T) {
+			c := newGraphTestCLab(t, nil)
+
+			if err := c.GenerateGraphFile(format, g); err != nil {
+				t.Fatalf("GenerateGraphFile returned error: %v", err)
+			}
+
+			out := readGraphFile(t, c, tt.ext)
+
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("%s graph does not contain %q:\n%s", format, want, out)
+				}
+			}
+
+			if strings.Contains(out, "N/A") {
+				t.Errorf("%s graph contains the not applicable address:\n%s", format, out)
+			}
+		})
+	}
+
+	if err := newGraphTestCLab(t, nil).GenerateGraphFile("gml", g); err == nil {
+		t.Error("expected an error for an unsupported graph format")
+	}
+}
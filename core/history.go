@@ -0,0 +1,249 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// maxStateRevisions is the number of the most recent revisions kept in the state history.
+	maxStateRevisions = 50
+
+	stateRevisionFilePrefix = "revision-"
+	stateRevisionFileSuffix = ".yaml"
+)
+
+// StateRevision is a topology state of the lab recorded in the state history
+// each time the lab is deployed or a topology change is applied to it.
+type StateRevision struct {
+	// Revision is the number of the revision, the revisions are numbered from 1
+	Revision  int       `json:"revision"  yaml:"revision"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	// ToRevision is the revision the lab was rolled back to,
+	// zero when the topology file was applied
+	ToRevision   int    `json:"to-revision,omitempty" yaml:"to-revision,omitempty"`
+	TopologyFile string `json:"topology-file"         yaml:"topology-file"`
+	// Topology is the rendered topology definition the lab was deployed with
+	Topology string `json:"topology" yaml:"topology"`
+	// Overrides are the overrides of the topology fields the lab was deployed with,
+	// the YAML values of the overrides are not JSON encodable, see Definition
+	Overrides []*clabtypes.TopologyOverride `json:"-" yaml:"overrides,omitempty"`
+	// Result is the outcome of the deployment or apply that produced the revision
+	Result *ApplyResult `json:"result" yaml:"result"`
+}
+
+// Definition returns the rendered topology of the revision followed by its overrides.
+func (r *StateRevision) Definition() string {
+	if len(r.Overrides) == 0 {
+		return r.Topology
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(strings.TrimRight(r.Topology, "\n"))
+	sb.WriteString("\n# overrides\n")
+
+	for _, o := range r.Overrides {
+		b, err := yaml.Marshal(yaml.MapSlice{{Key: o.Path, Value: o.Value}})
+		if err != nil {
+			b = fmt.Appendf(nil, "%s: %v\n", o.Path, o.Value)
+		}
+
+		for line := range strings.SplitSeq(strings.TrimRight(string(b), "\n"), "\n") {
+			sb.WriteString("# " + line + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// recordStateRevision adds the deployed topology to the state history of the lab.
+// The history is best effort, a failure to record the revision is logged.
+func (c *CLab) recordStateRevision(result *ApplyResult) {
+	if c.renderedTopology == nil {
+		return
+	}
+
+	if err := c.writeStateRevision(result); err != nil {
+		log.Warn("Failed to record the topology state in the history", "err", err)
+	}
+}
+
+func (c *CLab) writeStateRevision(result *ApplyResult) error {
+	revisions, err := c.stateRevisionNumbers()
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if len(revisions) > 0 {
+		next = revisions[len(revisions)-1] + 1
+	}
+
+	rev := &StateRevision{
+		Revision:     next,
+		Timestamp:    time.Now(),
+		ToRevision:   c.topologyRevision,
+		TopologyFile: c.TopoPaths.TopologyFilenameAbsPath(),
+		Topology:     string(c.renderedTopology),
+		Overrides:    c.topologyOverrides,
+		Result:       result,
+	}
+
+	data, err := yaml.Marshal(rev)
+	if err != nil {
+		return err
+	}
+
+	dir := c.TopoPaths.StateHistoryDir()
+	if err := os.MkdirAll(dir, clabconstants.PermissionsDirDefault); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(stateRevisionFile(dir, next), data,
+		clabconstants.PermissionsFileDefault); err != nil {
+		return err
+	}
+
+	log.Debug("Recorded the topology state", "revision", next)
+
+	// the oldest revisions are pruned
+	revisions = append(revisions, next)
+	for len(revisions) > maxStateRevisions {
+		if err := os.Remove(stateRevisionFile(dir, revisions[0])); err != nil {
+			return err
+		}
+
+		revisions = revisions[1:]
+	}
+
+	return nil
+}
+
+// StateHistory returns the recorded topology states of the lab, the oldest first.
+func (c *CLab) StateHistory() ([]*StateRevision, error) {
+	numbers, err := c.stateRevisionNumbers()
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*StateRevision, 0, len(numbers))
+
+	for _, n := range numbers {
+		rev, err := c.StateRevision(n)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, nil
+}
+
+// StateRevision returns the recorded topology state with the given revision number.
+func (c *CLab) StateRevision(revision int) (*StateRevision, error) {
+	path := stateRevisionFile(c.TopoPaths.StateHistoryDir(), revision)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("revision %d is not found in the history of lab %q",
+				revision, c.Config.Name)
+		}
+
+		return nil, err
+	}
+
+	rev := &StateRevision{}
+	if err := yaml.Unmarshal(data, rev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal revision %d: %w", revision, err)
+	}
+
+	return rev, nil
+}
+
+// stateRevisionNumbers returns the sorted numbers of the recorded revisions.
+func (c *CLab) stateRevisionNumbers() ([]int, error) {
+	entries, err := os.ReadDir(c.TopoPaths.StateHistoryDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var numbers []int
+
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, stateRevisionFilePrefix) ||
+			!strings.HasSuffix(name, stateRevisionFileSuffix) {
+			continue
+		}
+
+		n, err := strconv.Atoi(strings.TrimSuffix(
+			strings.TrimPrefix(name, stateRevisionFilePrefix), stateRevisionFileSuffix))
+		if err != nil || n <= 0 {
+			continue
+		}
+
+		numbers = append(numbers, n)
+	}
+
+	sort.Ints(numbers)
+
+	return numbers, nil
+}
+
+func stateRevisionFile(dir string, revision int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%d%s",
+		stateRevisionFilePrefix, revision, stateRevisionFileSuffix))
+}
+
+// loadTopologyRevision returns the rendered topology of the revision the lab is rolled
+// back to in place of the rendered topology file, and sets the overrides of the revision.
+// The revision is looked up in the state history of the lab the topology file defines.
+func (c *CLab) loadTopologyRevision(rendered []byte) ([]byte, error) {
+	topo := &struct {
+		Name string `yaml:"name"`
+	}{}
+
+	if err := yaml.Unmarshal(rendered, topo); err != nil {
+		return nil, err
+	}
+
+	name := topo.Name
+	if strings.Contains(name, gitBranchVar) || strings.Contains(name, gitHashVar) {
+		name = c.magicTopoNameReplacer().Replace(name)
+	}
+
+	if err := c.TopoPaths.SetLabDirByPrefix(name); err != nil {
+		return nil, err
+	}
+
+	c.Config.Name = name
+
+	rev, err := c.StateRevision(c.topologyRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Rolling back the lab topology", "revision", rev.Revision,
+		"recorded", rev.Timestamp.Format(time.RFC3339))
+
+	c.topologyOverrides = rev.Overrides
+
+	return []byte(rev.Topology), nil
+}
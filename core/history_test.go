@@ -0,0 +1,164 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func newHistoryTestLab(t *testing.T) *CLab {
+	t.Helper()
+
+	labDir := t.TempDir()
+	topoFile := filepath.Join(labDir, "history.clab.yml")
+	if err := os.WriteFile(topoFile, []byte("name: history\n"), 0o644); err != nil {
+		t.Fatalf("failed to write topology file: %v", err)
+	}
+
+	topoPaths, err := clabtypes.NewTopoPaths(topoFile, nil)
+	if err != nil {
+		t.Fatalf("failed to create topo paths: %v", err)
+	}
+
+	if err := topoPaths.SetLabDir(labDir); err != nil {
+		t.Fatalf("failed to set lab dir: %v", err)
+	}
+
+	return &CLab{
+		Config:    &Config{Name: "history"},
+		TopoPaths: topoPaths,
+	}
+}
+
+func TestStateHistory(t *testing.T) {
+	c := newHistoryTestLab(t)
+
+	// nothing is recorded before the topology is loaded
+	c.recordStateRevision(&ApplyResult{DeployedLab: true})
+
+	revisions, err := c.StateHistory()
+	if err != nil {
+		t.Fatalf("failed to read the state history: %v", err)
+	}
+
+	if len(revisions) != 0 {
+		t.Fatalf("expected an empty history, got %d revisions", len(revisions))
+	}
+
+	c.renderedTopology = []byte("name: history\n")
+	c.recordStateRevision(&ApplyResult{DeployedLab: true, AddedNodes: []string{"n1"}})
+
+	c.renderedTopology = []byte("name: history\ntopology: {}\n")
+	c.topologyOverrides = []*clabtypes.TopologyOverride{
+		{Path: "nodes.n1.image", Value: "alpine:3"},
+	}
+	c.recordStateRevision(&ApplyResult{DeletedNodes: []string{"n1"}})
+
+	c.topologyRevision = 1
+	c.recordStateRevision(&ApplyResult{AddedNodes: []string{"n1"}})
+
+	revisions, err = c.StateHistory()
+	if err != nil {
+		t.Fatalf("failed to read the state history: %v", err)
+	}
+
+	var numbers, toRevisions []int
+	for _, rev := range revisions {
+		numbers = append(numbers, rev.Revision)
+		toRevisions = append(toRevisions, rev.ToRevision)
+	}
+
+	if d := cmp.Diff([]int{1, 2, 3}, numbers); d != "" {
+		t.Errorf("revision numbers mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff([]int{0, 0, 1}, toRevisions); d != "" {
+		t.Errorf("rolled back revisions mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff(&ApplyResult{DeletedNodes: []string{"n1"}}, revisions[1].Result); d != "" {
+		t.Errorf("revision 2 result mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff(c.topologyOverrides, revisions[1].Overrides); d != "" {
+		t.Errorf("revision 2 overrides mismatch (-want +got):\n%s", d)
+	}
+
+	rev, err := c.StateRevision(1)
+	if err != nil {
+		t.Fatalf("failed to read revision 1: %v", err)
+	}
+
+	if rev.Topology != "name: history\n" {
+		t.Errorf("unexpected topology of revision 1: %q", rev.Topology)
+	}
+
+	if _, err := c.StateRevision(4); err == nil ||
+		!strings.Contains(err.Error(), "revision 4 is not found") {
+		t.Errorf("expected revision 4 to be not found, got %v", err)
+	}
+}
+
+func TestStateHistoryPrune(t *testing.T) {
+	c := newHistoryTestLab(t)
+	c.renderedTopology = []byte("name: history\n")
+
+	for range maxStateRevisions + 2 {
+		c.recordStateRevision(&ApplyResult{})
+	}
+
+	// the files not named after a revision are ignored
+	if err := os.WriteFile(filepath.Join(c.TopoPaths.StateHistoryDir(), "notes.yaml"),
+		nil, 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	numbers, err := c.stateRevisionNumbers()
+	if err != nil {
+		t.Fatalf("failed to list the revisions: %v", err)
+	}
+
+	if len(numbers) != maxStateRevisions {
+		t.Fatalf("expected %d revisions, got %d", maxStateRevisions, len(numbers))
+	}
+
+	if numbers[0] != 3 || numbers[len(numbers)-1] != maxStateRevisions+2 {
+		t.Errorf("expected revisions 3..%d, got %d..%d", maxStateRevisions+2,
+			numbers[0], numbers[len(numbers)-1])
+	}
+}
+
+func TestStateRevisionDefinition(t *testing.T) {
+	tests := map[string]struct {
+		rev  *StateRevision
+		want string
+	}{
+		"no-overrides": {
+			rev:  &StateRevision{Topology: "name: lab\n"},
+			want: "name: lab\n",
+		},
+		"overrides": {
+			rev: &StateRevision{
+				Topology: "name: lab\n",
+				Overrides: []*clabtypes.TopologyOverride{
+					{Path: "nodes.n1.image", Value: "alpine:3"},
+					{Path: "nodes.n1.binds", Value: []any{"a:/a", "b:/b"}},
+				},
+			},
+			want: "name: lab\n# overrides\n# nodes.n1.image: alpine:3\n" +
+				"# nodes.n1.binds:\n# - a:/a\n# - b:/b\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if d := cmp.Diff(tt.want, tt.rev.Definition()); d != "" {
+				t.Errorf("definition mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
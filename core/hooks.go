@@ -0,0 +1,118 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+// hookWaitDelay is the time the output pipes of a killed hook are waited for,
+// the processes started in the background by the hook command may keep them open.
+const hookWaitDelay = 5 * time.Second
+
+// runHooks runs the lab hooks of the event on the host in the order they are defined.
+// A failed hook with the fail policy stops the remaining hooks and its error is returned,
+// the failure of a hook with the continue policy is logged.
+func (c *CLab) runHooks(ctx context.Context, event clabtypes.HookEvent) error {
+	hooks := c.Config.Hooks.Get(event)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	// the topology data is passed to the hooks on stdin
+	var export bytes.Buffer
+	if err := c.GenerateExports(ctx, &export, ""); err != nil {
+		log.Warn("Failed to generate the topology data for the hooks", "err", err)
+	}
+
+	env := append(os.Environ(), c.hookEnv(event)...)
+
+	for _, hook := range hooks {
+		err := c.runHook(ctx, event, hook, env, export.Bytes())
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("%s hook %q failed: %w", event, hook.Command, err)
+
+		if hook.GetOnFailure() == clabtypes.HookOnFailureContinue {
+			log.Warn(err)
+
+			continue
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// hookEnv returns the lab environment variables set for the hooks.
+func (c *CLab) hookEnv(event clabtypes.HookEvent) []string {
+	return []string{
+		"CLAB_HOOK=" + string(event),
+		"CLAB_LAB_NAME=" + c.Config.Name,
+		"CLAB_LAB_DIR=" + c.TopoPaths.TopologyLabDir(),
+		"CLAB_TOPO_FILE=" + c.TopoPaths.TopologyFilenameAbsPath(),
+		"CLAB_TOPO_EXPORT_FILE=" + c.TopoPaths.TopoExportFile(),
+	}
+}
+
+// runHook runs the hook command by the shell in the topology file directory.
+func (c *CLab) runHook(
+	ctx context.Context,
+	event clabtypes.HookEvent,
+	hook *clabtypes.Hook,
+	env []string,
+	stdin []byte,
+) error {
+	timeout := hook.GetTimeoutDuration()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	log.Info("Running hook", "event", event, "command", hook.Command)
+
+	cmd := osexec.CommandContext(ctx, "sh", "-c", hook.Command)
+	cmd.Dir = c.TopoPaths.TopologyFileDir()
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.WaitDelay = hookWaitDelay
+	// the hook runs in its own process group which is killed as a whole on the timeout
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	duration := time.Since(start).Round(time.Millisecond)
+
+	if output := strings.TrimSpace(string(out)); output != "" {
+		log.Info("Hook output", "event", event, "command", hook.Command, "output", output)
+	}
+
+	if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	log.Info("Hook finished", "event", event, "command", hook.Command, "duration", duration)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func TestRunHooks(t *testing.T) {
+	c, err := NewContainerLab(WithTopoPath("test_data/topo1.yml", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "hooks.log")
+	t.Setenv("HOOKS_TEST_OUT", out)
+
+	c.Config.Hooks = &clabtypes.Hooks{
+		PostDeploy: []*clabtypes.Hook{
+			{Command: `echo "$CLAB_HOOK $CLAB_LAB_NAME" >> "$HOOKS_TEST_OUT"`},
+			// the topology data is passed on stdin
+			{Command: `grep -q '"name": "topo1"' && echo stdin >> "$HOOKS_TEST_OUT"`},
+			{Command: "exit 1", OnFailure: clabtypes.HookOnFailureContinue},
+			{Command: "sleep 10", Timeout: 1, OnFailure: clabtypes.HookOnFailureContinue},
+			{Command: `echo last >> "$HOOKS_TEST_OUT"`},
+		},
+		PreDestroy: []*clabtypes.Hook{
+			{Command: "exit 3"},
+			// not run after the failed hook
+			{Command: `echo skipped >> "$HOOKS_TEST_OUT"`},
+		},
+	}
+
+	if err := c.runHooks(context.Background(), clabtypes.HookPostDeploy); err != nil {
+		t.Fatalf("post-deploy hooks failed: %v", err)
+	}
+
+	err = c.runHooks(context.Background(), clabtypes.HookPreDestroy)
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("expected the pre-destroy hook to fail with exit status 3, got %v", err)
+	}
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"post-deploy topo1", "stdin", "last"}
+	if d := cmp.Diff(want, strings.Split(strings.TrimSpace(string(b)), "\n")); d != "" {
+		t.Errorf("hooks output mismatch (-want +got):\n%s", d)
+	}
+}
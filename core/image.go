@@ -68,6 +68,7 @@ func (c *CLab) pullNodeImages(
 	errCh chan<- error, pullMutex *sync.Mutex, ongoingPulls map[string]*pullResult,
 ) {
 	defer wg.Done()
+	defer c.profiler.begin(node.Config().ShortName, profilePhaseImagePull)()
 
 	select {
 	case <-ctx.Done():
@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// cmlLab is the part of the CML (VIRL2) lab file the topology is imported from.
+type cmlLab struct {
+	Lab struct {
+		Title string `yaml:"title"`
+	} `yaml:"lab"`
+	Nodes []cmlNode `yaml:"nodes"`
+	Links []cmlLink `yaml:"links"`
+}
+
+type cmlNode struct {
+	ID             string         `yaml:"id"`
+	Label          string         `yaml:"label"`
+	NodeDefinition string         `yaml:"node_definition"`
+	X              *float64       `yaml:"x"`
+	Y              *float64       `yaml:"y"`
+	Interfaces     []cmlInterface `yaml:"interfaces"`
+}
+
+type cmlInterface struct {
+	ID   string `yaml:"id"`
+	Slot *int   `yaml:"slot"`
+	Type string `yaml:"type"`
+}
+
+type cmlLink struct {
+	N1 string `yaml:"n1"`
+	N2 string `yaml:"n2"`
+	I1 string `yaml:"i1"`
+	I2 string `yaml:"i2"`
+}
+
+// parseCML parses the CML lab file, the interfaces are numbered by their slots.
+func parseCML(data []byte) (*lab, error) {
+	var cl cmlLab
+
+	if err := yaml.Unmarshal(data, &cl); err != nil {
+		return nil, err
+	}
+
+	l := &lab{name: cl.Lab.Title}
+
+	// slots of the physical interfaces keyed by the node and the interface id
+	slots := make(map[string]map[string]int, len(cl.Nodes))
+
+	for i := range cl.Nodes {
+		cn := &cl.Nodes[i]
+
+		n := &node{
+			id:       cn.ID,
+			name:     cn.Label,
+			template: cn.NodeDefinition,
+		}
+
+		if cn.X != nil && cn.Y != nil {
+			n.position = &position{x: *cn.X, y: *cn.Y}
+		}
+
+		l.nodes = append(l.nodes, n)
+
+		slots[cn.ID] = make(map[string]int, len(cn.Interfaces))
+
+		for _, iface := range cn.Interfaces {
+			if iface.Type == "physical" && iface.Slot != nil {
+				slots[cn.ID][iface.ID] = *iface.Slot
+			}
+		}
+	}
+
+	for i, lnk := range cl.Links {
+		var eps [2]endpoint
+
+		for j, ep := range [2][2]string{{lnk.N1, lnk.I1}, {lnk.N2, lnk.I2}} {
+			nodeSlots, ok := slots[ep[0]]
+			if !ok {
+				return nil, fmt.Errorf("link %d refers to the unknown node %q", i+1, ep[0])
+			}
+
+			slot, ok := nodeSlots[ep[1]]
+			if !ok {
+				return nil, fmt.Errorf("link %d refers to the unknown interface %q of the node %q",
+					i+1, ep[1], ep[0])
+			}
+
+			eps[j] = endpoint{node: ep[0], iface: slot}
+		}
+
+		l.links = append(l.links, link{a: eps[0], b: eps[1]})
+	}
+
+	return l, nil
+}
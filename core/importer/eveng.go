@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"encoding/xml"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+)
+
+// iolPortsPerSlot is the number of the interfaces in an IOL slot.
+const iolPortsPerSlot = 4
+
+// evengLab is the part of the EVE-NG .unl lab file the topology is imported from.
+type evengLab struct {
+	Name     string `xml:"name,attr"`
+	Topology struct {
+		Nodes    []evengNode    `xml:"nodes>node"`
+		Networks []evengNetwork `xml:"networks>network"`
+	} `xml:"topology"`
+}
+
+type evengNode struct {
+	ID         string           `xml:"id,attr"`
+	Name       string           `xml:"name,attr"`
+	Type       string           `xml:"type,attr"`
+	Template   string           `xml:"template,attr"`
+	Left       string           `xml:"left,attr"`
+	Top        string           `xml:"top,attr"`
+	Interfaces []evengInterface `xml:"interface"`
+}
+
+type evengInterface struct {
+	ID        int    `xml:"id,attr"`
+	NetworkID string `xml:"network_id,attr"`
+}
+
+type evengNetwork struct {
+	ID   string `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// iface returns the number of the interface. The IOL interface ids encode
+// the port in the upper bits and the slot in the lower four bits, the interfaces
+// are numbered by the slot first.
+func (n *evengNode) iface(i evengInterface) int {
+	if n.Type == "iol" {
+		return (i.ID%16)*iolPortsPerSlot + i.ID/16 //nolint:mnd
+	}
+
+	return i.ID
+}
+
+// parseEVENG parses the EVE-NG lab file. The networks connecting two interfaces
+// are the links, the other networks are not imported.
+func parseEVENG(data []byte) (*lab, error) {
+	var el evengLab
+
+	if err := xml.Unmarshal(data, &el); err != nil {
+		return nil, err
+	}
+
+	l := &lab{name: el.Name}
+
+	members := make(map[string][]endpoint)
+
+	for i := range el.Topology.Nodes {
+		en := &el.Topology.Nodes[i]
+
+		n := &node{
+			id:       en.ID,
+			name:     en.Name,
+			template: en.Template,
+		}
+
+		x, errX := strconv.ParseFloat(en.Left, 64)
+		y, errY := strconv.ParseFloat(en.Top, 64)
+
+		if errX == nil && errY == nil {
+			n.position = &position{x: x, y: y}
+		}
+
+		l.nodes = append(l.nodes, n)
+
+		for _, iface := range en.Interfaces {
+			if iface.NetworkID == "" || iface.NetworkID == "0" {
+				continue
+			}
+
+			members[iface.NetworkID] = append(members[iface.NetworkID],
+				endpoint{node: en.ID, iface: en.iface(iface)})
+		}
+	}
+
+	networks := make(map[string]evengNetwork, len(el.Topology.Networks))
+	for _, nw := range el.Topology.Networks {
+		networks[nw.ID] = nw
+	}
+
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+
+	// the networks are imported in the order of their numeric ids
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+
+		if a != b {
+			return a < b
+		}
+
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		eps := members[id]
+
+		if len(eps) != 2 { //nolint:mnd
+			log.Warnf("Skipping the network %s (%s) connecting %d interfaces, "+
+				"only the point-to-point networks are imported",
+				networks[id].Name, networks[id].Type, len(eps))
+
+			continue
+		}
+
+		l.links = append(l.links, link{a: eps[0], b: eps[1]})
+	}
+
+	return l, nil
+}
@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+)
+
+// gns3Project is the part of the .gns3 project file the topology is imported from.
+type gns3Project struct {
+	Name     string `json:"name"`
+	Topology struct {
+		Nodes []gns3Node `json:"nodes"`
+		Links []gns3Link `json:"links"`
+	} `json:"topology"`
+}
+
+type gns3Node struct {
+	NodeID     string         `json:"node_id"`
+	Name       string         `json:"name"`
+	NodeType   string         `json:"node_type"`
+	X          *float64       `json:"x"`
+	Y          *float64       `json:"y"`
+	Properties gns3Properties `json:"properties"`
+	Ports      []gns3Port     `json:"ports"`
+}
+
+type gns3Properties struct {
+	// Image is the container image of the docker nodes and the IOS image
+	// of the dynamips nodes
+	Image string `json:"image"`
+	// HDADiskImage is the disk image of the qemu nodes
+	HDADiskImage string `json:"hda_disk_image"`
+	// Path is the image of the IOU nodes
+	Path string `json:"path"`
+}
+
+type gns3Port struct {
+	AdapterNumber int `json:"adapter_number"`
+	PortNumber    int `json:"port_number"`
+}
+
+type gns3Link struct {
+	Nodes []struct {
+		NodeID string `json:"node_id"`
+		gns3Port
+	} `json:"nodes"`
+}
+
+// template returns the node type and the base name of the node image.
+func (n *gns3Node) template() string {
+	for _, image := range []string{
+		n.Properties.Image, n.Properties.HDADiskImage, n.Properties.Path,
+	} {
+		if image == "" {
+			continue
+		}
+
+		if n.NodeType != "docker" {
+			image = path.Base(image)
+		}
+
+		return n.NodeType + ":" + image
+	}
+
+	return n.NodeType
+}
+
+// iface returns the number of the port. The ports are numbered in the order of the node
+// ports, the number of the port of a node without them is its adapter number
+// for the single-port adapters and its port number for the switches.
+func (n *gns3Node) iface(p gns3Port) int {
+	if len(n.Ports) == 0 {
+		return p.AdapterNumber + p.PortNumber
+	}
+
+	ports := make([]gns3Port, len(n.Ports))
+	copy(ports, n.Ports)
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].AdapterNumber != ports[j].AdapterNumber {
+			return ports[i].AdapterNumber < ports[j].AdapterNumber
+		}
+
+		return ports[i].PortNumber < ports[j].PortNumber
+	})
+
+	for i, port := range ports {
+		if port == p {
+			return i
+		}
+	}
+
+	return p.AdapterNumber + p.PortNumber
+}
+
+// parseGNS3 parses the GNS3 project file.
+func parseGNS3(data []byte) (*lab, error) {
+	var p gns3Project
+
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+
+	l := &lab{name: p.Name}
+
+	nodes := make(map[string]*gns3Node, len(p.Topology.Nodes))
+
+	for i := range p.Topology.Nodes {
+		gn := &p.Topology.Nodes[i]
+		nodes[gn.NodeID] = gn
+
+		n := &node{
+			id:       gn.NodeID,
+			name:     gn.Name,
+			template: gn.template(),
+		}
+
+		if gn.NodeType == "docker" {
+			n.image = gn.Properties.Image
+		}
+
+		if gn.X != nil && gn.Y != nil {
+			n.position = &position{x: *gn.X, y: *gn.Y}
+		}
+
+		l.nodes = append(l.nodes, n)
+	}
+
+	for i, gl := range p.Topology.Links {
+		if len(gl.Nodes) != 2 { //nolint:mnd
+			return nil, fmt.Errorf("link %d has %d nodes, expected 2", i+1, len(gl.Nodes))
+		}
+
+		var eps [2]endpoint
+
+		for j, ep := range gl.Nodes {
+			gn, ok := nodes[ep.NodeID]
+			if !ok {
+				return nil, fmt.Errorf("link %d refers to the unknown node %q", i+1, ep.NodeID)
+			}
+
+			eps[j] = endpoint{node: ep.NodeID, iface: gn.iface(ep.gns3Port)}
+		}
+
+		l.links = append(l.links, link{a: eps[0], b: eps[1]})
+	}
+
+	return l, nil
+}
@@ -0,0 +1,272 @@
+// Package importer converts the topologies of the other network emulators
+// to containerlab topologies.
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+const (
+	FormatGNS3  = "gns3"
+	FormatEVENG = "eve-ng"
+	FormatCML   = "cml"
+	// FormatNetBox is the JSON data of the containerlab NetBox export
+	FormatNetBox = "netbox-export"
+
+	// fallbackKind is the kind of the nodes whose template is not in the mapping table
+	fallbackKind = "linux"
+	// defaultInterfaceFormat is the interface format of the kinds that do not define one
+	defaultInterfaceFormat = "eth%d"
+)
+
+// Formats returns the names of the supported source formats.
+func Formats() []string {
+	return []string{FormatGNS3, FormatEVENG, FormatCML, FormatNetBox}
+}
+
+// lab is the topology parsed from a source format.
+type lab struct {
+	name  string
+	nodes []*node
+	links []link
+	// the management subnets of the node addresses
+	mgmtIPv4Subnet string
+	mgmtIPv6Subnet string
+}
+
+// node is a node of the source topology.
+type node struct {
+	// id is the identifier of the node the links refer to
+	id   string
+	name string
+	// template identifies the node type in the source format and is matched against
+	// the mapping table
+	template string
+	// image is the container image of the node, set for the container based source nodes
+	image    string
+	position *position
+	group    string
+	mgmtIPv4 string
+	mgmtIPv6 string
+}
+
+type position struct {
+	x, y float64
+}
+
+// link is a point-to-point link between the source interfaces.
+type link struct {
+	a, b endpoint
+}
+
+// endpoint is the interface of the source node, the interface is either named
+// or numbered from 0 in the order the source node defines them.
+type endpoint struct {
+	node  string
+	iface int
+	name  string
+}
+
+// Lab is the imported containerlab topology, it is marshaled to the topology file.
+type Lab struct {
+	Name     string              `yaml:"name"`
+	Mgmt     *clabtypes.MgmtNet  `yaml:"mgmt,omitempty"`
+	Topology *clabtypes.Topology `yaml:"topology"`
+}
+
+// Import parses the topology in the source format and converts it to a containerlab
+// topology. The kinds of the nodes are looked up in the mapping table and the interface
+// names are formatted with the interface formats keyed by the kind.
+func Import(
+	format string,
+	data []byte,
+	mapping Mapping,
+	interfaceFormats map[string]string,
+) (*Lab, error) {
+	var (
+		l   *lab
+		err error
+	)
+
+	switch format {
+	case FormatGNS3:
+		l, err = parseGNS3(data)
+	case FormatEVENG:
+		l, err = parseEVENG(data)
+	case FormatCML:
+		l, err = parseCML(data)
+	case FormatNetBox:
+		l, err = parseNetBox(data)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q, use one of %v", format, Formats())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s topology: %w", format, err)
+	}
+
+	return l.convert(format, mapping, interfaceFormats)
+}
+
+// nodeNameRe matches the characters not allowed in the node names.
+var nodeNameRe = regexp.MustCompile(`[^A-Za-z0-9_.-]+`) //nolint:gochecknoglobals
+
+// nodeName returns the containerlab node name of the source node name
+// which is unique among the taken names.
+func nodeName(name string, taken map[string]bool) string {
+	base := strings.Trim(nodeNameRe.ReplaceAllString(name, "-"), "-.")
+	if base == "" {
+		base = "node"
+	}
+
+	result := base
+	for i := 2; taken[result]; i++ {
+		result = base + "-" + strconv.Itoa(i)
+	}
+
+	taken[result] = true
+
+	return result
+}
+
+func (l *lab) convert(
+	format string,
+	mapping Mapping,
+	interfaceFormats map[string]string,
+) (*Lab, error) {
+	topo := &clabtypes.Topology{
+		Nodes: make(map[string]*clabtypes.NodeDefinition, len(l.nodes)),
+	}
+
+	type mappedNode struct {
+		name  string
+		entry *MappingEntry
+	}
+
+	nodes := make(map[string]mappedNode, len(l.nodes))
+	taken := make(map[string]bool, len(l.nodes))
+
+	for _, n := range l.nodes {
+		if _, ok := nodes[n.id]; ok {
+			return nil, fmt.Errorf("duplicate node id %q", n.id)
+		}
+
+		name := nodeName(n.name, taken)
+
+		entry := mapping.Lookup(format, n.template)
+		if _, isKind := interfaceFormats[n.template]; entry == nil && isKind {
+			// the templates naming a kind, e.g. the device types of the netbox export,
+			// are mapped to the kind
+			entry = &MappingEntry{Kind: n.template}
+		}
+
+		if entry == nil {
+			log.Warnf("No mapping for the template %q of the node %s, using the %s kind",
+				n.template, name, fallbackKind)
+
+			entry = &MappingEntry{Kind: fallbackKind}
+		}
+
+		def := &clabtypes.NodeDefinition{
+			Kind:     entry.Kind,
+			Image:    entry.Image,
+			Type:     entry.Type,
+			Group:    n.group,
+			MgmtIPv4: n.mgmtIPv4,
+			MgmtIPv6: n.mgmtIPv6,
+		}
+
+		if def.Image == "" {
+			def.Image = n.image
+		}
+
+		if n.position != nil {
+			def.Position = strconv.FormatFloat(n.position.x, 'f', -1, 64) + "," +
+				strconv.FormatFloat(n.position.y, 'f', -1, 64)
+		}
+
+		nodes[n.id] = mappedNode{name: name, entry: entry}
+		topo.Nodes[name] = def
+	}
+
+	ifaceName := func(ep endpoint) (string, string, error) {
+		n, ok := nodes[ep.node]
+		if !ok {
+			return "", "", fmt.Errorf("link refers to the unknown node %q", ep.node)
+		}
+
+		if ep.name != "" {
+			return n.name, ep.name, nil
+		}
+
+		ifFormat := interfaceFormats[n.entry.Kind]
+		if ifFormat == "" {
+			ifFormat = defaultInterfaceFormat
+		}
+
+		num := ep.iface + n.entry.interfaceOffset()
+		if num < 0 {
+			return "", "", fmt.Errorf("interface %d of the node %s maps to no %s interface",
+				ep.iface, n.name, n.entry.Kind)
+		}
+
+		return n.name, formatInterface(ifFormat, num), nil
+	}
+
+	for _, lnk := range l.links {
+		aNode, aIface, err := ifaceName(lnk.a)
+		if err != nil {
+			return nil, err
+		}
+
+		bNode, bIface, err := ifaceName(lnk.b)
+		if err != nil {
+			return nil, err
+		}
+
+		raw := &clablinks.LinkVEthRaw{
+			Endpoints: []*clablinks.EndpointRaw{
+				clablinks.NewEndpointRaw(aNode, aIface, ""),
+				clablinks.NewEndpointRaw(bNode, bIface, ""),
+			},
+		}
+
+		topo.Links = append(topo.Links, &clablinks.LinkDefinition{
+			Link: raw.ToLinkBriefRaw(),
+		})
+	}
+
+	result := &Lab{Name: nodeName(l.name, map[string]bool{}), Topology: topo}
+
+	if l.mgmtIPv4Subnet != "" || l.mgmtIPv6Subnet != "" {
+		result.Mgmt = &clabtypes.MgmtNet{
+			IPv4Subnet: l.mgmtIPv4Subnet,
+			IPv6Subnet: l.mgmtIPv6Subnet,
+		}
+	}
+
+	return result, nil
+}
+
+// formatInterface formats the interface number with the kind interface format,
+// the leading numbers of the formats with several numbers, e.g. %d/%d/%d, are set to 1.
+func formatInterface(format string, num int) string {
+	verbs := strings.Count(format, "%d")
+	if verbs == 0 {
+		return format + strconv.Itoa(num)
+	}
+
+	args := make([]any, 0, verbs)
+	for range verbs - 1 {
+		args = append(args, 1)
+	}
+
+	return fmt.Sprintf(format, append(args, num)...)
+}
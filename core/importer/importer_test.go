@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+// testInterfaceFormats are the interface formats of the kinds used in the tests.
+var testInterfaceFormats = map[string]string{ //nolint:gochecknoglobals
+	"juniper_vmx":   "ge-0/0/%d",
+	"bridge":        "eth%d",
+	"nokia_srlinux": "e1-%d",
+	"linux":         "eth%d",
+}
+
+func briefLink(a, b string) *clablinks.LinkDefinition {
+	return &clablinks.LinkDefinition{
+		Link: &clablinks.LinkBriefRaw{Endpoints: []string{a, b}},
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		file   string
+		want   *Lab
+	}{
+		"gns3": {
+			format: FormatGNS3,
+			file:   "lab.gns3",
+			want: &Lab{
+				Name: "campus",
+				Topology: &clabtypes.Topology{
+					Nodes: map[string]*clabtypes.NodeDefinition{
+						"R1": {
+							Kind: "cisco_vios", Image: "vrnetlab/cisco_vios:latest",
+							Position: "-150,-100",
+						},
+						"R2": {
+							Kind: "cisco_vios", Image: "vrnetlab/cisco_vios:latest",
+							Position: "50,-100",
+						},
+						"Switch-1": {Kind: "bridge", Position: "50,50"},
+						"web": {
+							Kind: "linux", Image: "ghcr.io/hellt/network-multitool:latest",
+							Position: "200,50",
+						},
+					},
+					Links: []*clablinks.LinkDefinition{
+						briefLink("R1:eth1", "R2:eth1"),
+						briefLink("R2:eth2", "Switch-1:eth4"),
+						briefLink("Switch-1:eth2", "web:eth1"),
+					},
+				},
+			},
+		},
+		"eve-ng": {
+			format: FormatEVENG,
+			file:   "lab.unl",
+			want: &Lab{
+				Name: "core",
+				Topology: &clabtypes.Topology{
+					Nodes: map[string]*clabtypes.NodeDefinition{
+						"vMX1": {
+							Kind: "juniper_vmx", Image: "vrnetlab/juniper_vmx:latest",
+							Position: "300,150",
+						},
+						"IOL-2": {
+							Kind: "cisco_iol", Image: "vrnetlab/cisco_iol:latest",
+							Position: "600,150",
+						},
+						// the percent positions are not imported
+						"PC":   {Kind: "linux", Image: "alpine:latest"},
+						"PC-2": {Kind: "linux", Image: "alpine:latest", Position: "700,400"},
+					},
+					// the LAN network of three interfaces is not imported
+					Links: []*clablinks.LinkDefinition{
+						briefLink("vMX1:ge-0/0/0", "IOL-2:eth1"),
+						briefLink("IOL-2:eth4", "PC:eth1"),
+					},
+				},
+			},
+		},
+		"cml": {
+			format: FormatCML,
+			file:   "lab.yaml",
+			want: &Lab{
+				Name: "Small-NXOS-IOSXE-Network",
+				Topology: &clabtypes.Topology{
+					Nodes: map[string]*clabtypes.NodeDefinition{
+						"iosv-0": {
+							Kind: "cisco_vios", Image: "vrnetlab/cisco_vios:latest",
+							Position: "-400,0",
+						},
+						"csr-0": {
+							Kind: "cisco_csr1000v", Image: "vrnetlab/cisco_csr1000v:latest",
+							Position: "-200,0",
+						},
+						// the unmapped templates fall back to the linux kind
+						"ext-conn-0": {Kind: "linux", Position: "0,100"},
+					},
+					Links: []*clablinks.LinkDefinition{
+						briefLink("iosv-0:eth2", "csr-0:eth1"),
+						briefLink("csr-0:eth2", "ext-conn-0:eth1"),
+					},
+				},
+			},
+		},
+		"netbox export": {
+			format: FormatNetBox,
+			file:   "netbox.json",
+			want: &Lab{
+				Name: "srl02",
+				Mgmt: &clabtypes.MgmtNet{
+					IPv4Subnet: "172.20.20.0/24",
+					IPv6Subnet: "3fff:172:20:20::/64",
+				},
+				Topology: &clabtypes.Topology{
+					Nodes: map[string]*clabtypes.NodeDefinition{
+						"srl1": {
+							Kind: "nokia_srlinux", Group: "spine",
+							MgmtIPv4: "172.20.20.2", MgmtIPv6: "3fff:172:20:20::2",
+						},
+						"client1": {Kind: "linux", MgmtIPv4: "172.20.20.3"},
+						// the device types that are not kinds fall back to the linux kind
+						"edge-1": {Kind: "linux"},
+					},
+					// the interface names are kept and the cables to the ports are not imported
+					Links: []*clablinks.LinkDefinition{
+						briefLink("srl1:e1-1", "client1:eth1"),
+						briefLink("srl1:e1-2", "edge-1:Ethernet1"),
+					},
+				},
+			},
+		},
+	}
+
+	mapping, err := LoadMapping("")
+	if err != nil {
+		t.Fatalf("failed to load the default mapping: %v", err)
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("test_data", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Import(tt.format, data, mapping, testInterfaceFormats)
+			if err != nil {
+				t.Fatalf("Import returned error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected topology (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		data   string
+	}{
+		"unsupported format": {
+			format: "virl",
+		},
+		"gns3 link to an unknown node": {
+			format: FormatGNS3,
+			data: `{"topology": {"nodes": [{"node_id": "a", "name": "a"}], "links": [
+				{"nodes": [{"node_id": "a"}, {"node_id": "b"}]}]}}`,
+		},
+		"cml link to an unknown interface": {
+			format: FormatCML,
+			data: `
+nodes:
+  - {id: n0, label: a, interfaces: [{id: i0, slot: 0, type: physical}]}
+  - {id: n1, label: b, interfaces: [{id: i0, type: loopback}]}
+links:
+  - {n1: n0, i1: i0, n2: n1, i2: i0}`,
+		},
+		"interface below the kind interfaces": {
+			format: FormatEVENG,
+			data: `<lab name="l"><topology><nodes>
+				<node id="1" name="a" template="vmx"><interface id="0" network_id="1"/></node>
+				<node id="2" name="b" template="vmx"><interface id="1" network_id="1"/></node>
+			</nodes></topology></lab>`,
+		},
+	}
+
+	mapping, err := LoadMapping("")
+	if err != nil {
+		t.Fatalf("failed to load the default mapping: %v", err)
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Import(tt.format, []byte(tt.data), mapping, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFormatInterface(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		num    int
+		want   string
+	}{
+		"single number":   {format: "e1-%d", num: 3, want: "e1-3"},
+		"several numbers": {format: "%d/%d/%d", num: 2, want: "1/1/2"},
+		"no number":       {format: "port", num: 4, want: "port4"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := formatInterface(tt.format, tt.num); got != tt.want {
+				t.Errorf("formatInterface(%q, %d) = %q, want %q", tt.format, tt.num, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package importer
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultMapping is the default mapping table of the node templates to the kinds.
+//
+//go:embed mapping.yml
+var DefaultMapping []byte
+
+// defaultInterfaceOffset is the interface offset of the entries that do not set it.
+const defaultInterfaceOffset = 1
+
+// MappingEntry maps the node templates matching the pattern to a containerlab kind.
+type MappingEntry struct {
+	// From is the source format the entry applies to, all formats when empty
+	From string `yaml:"from,omitempty"`
+	// Template is the pattern matched case-insensitively against the node template
+	Template string `yaml:"template"`
+	Kind     string `yaml:"kind"`
+	Image    string `yaml:"image,omitempty"`
+	Type     string `yaml:"type,omitempty"`
+	// InterfaceOffset is added to the number of the source interface to get the number
+	// of the kind interface
+	InterfaceOffset *int `yaml:"interface-offset,omitempty"`
+}
+
+func (e *MappingEntry) interfaceOffset() int {
+	if e.InterfaceOffset == nil {
+		return defaultInterfaceOffset
+	}
+
+	return *e.InterfaceOffset
+}
+
+// Mapping is the mapping table, the entries are matched in order.
+type Mapping []*MappingEntry
+
+// ParseMapping parses the mapping table.
+func ParseMapping(data []byte) (Mapping, error) {
+	var m Mapping
+
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return nil, err
+	}
+
+	for i, e := range m {
+		if e == nil || e.Template == "" || e.Kind == "" {
+			return nil, fmt.Errorf("mapping entry %d must set the template and the kind", i+1)
+		}
+
+		if e.From != "" && !slices.Contains(Formats(), e.From) {
+			return nil, fmt.Errorf("mapping entry %d has unsupported format %q, use one of %v",
+				i+1, e.From, Formats())
+		}
+	}
+
+	return m, nil
+}
+
+// LoadMapping returns the default mapping table preceded by the entries
+// of the mapping file, if it is set.
+func LoadMapping(file string) (Mapping, error) {
+	m, err := ParseMapping(DefaultMapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the default mapping: %w", err)
+	}
+
+	if file == "" {
+		return m, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	custom, err := ParseMapping(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", file, err)
+	}
+
+	return append(custom, m...), nil
+}
+
+// Lookup returns the first entry matching the template of the source format,
+// nil if there is none.
+func (m Mapping) Lookup(format, template string) *MappingEntry {
+	for _, e := range m {
+		if e.From != "" && e.From != format {
+			continue
+		}
+
+		if templateRegexp(e.Template).MatchString(template) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// templateRegexp returns the case-insensitive regexp of the template pattern,
+// * matches any characters, including the slashes of the image names, and ? any character.
+func templateRegexp(pattern string) *regexp.Regexp {
+	re := regexp.QuoteMeta(pattern)
+	re = strings.ReplaceAll(re, `\*`, ".*")
+	re = strings.ReplaceAll(re, `\?`, ".")
+
+	return regexp.MustCompile("(?i)^" + re + "$")
+}
@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMappingLookup(t *testing.T) {
+	mapping, err := LoadMapping("")
+	if err != nil {
+		t.Fatalf("failed to load the default mapping: %v", err)
+	}
+
+	tests := map[string]struct {
+		format   string
+		template string
+		wantKind string
+	}{
+		"case insensitive": {
+			format: FormatCML, template: "IOSv", wantKind: "cisco_vios",
+		},
+		"pattern matches the image path": {
+			format: FormatGNS3, template: "docker:ghcr.io/srl-labs/alpine", wantKind: "linux",
+		},
+		"entry of another format": {
+			format: FormatEVENG, template: "iosv",
+		},
+		"no match": {
+			format: FormatCML, template: "unknown",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := mapping.Lookup(tt.format, tt.template)
+
+			switch {
+			case tt.wantKind == "" && e != nil:
+				t.Errorf("unexpected match %+v", e)
+			case tt.wantKind != "" && (e == nil || e.Kind != tt.wantKind):
+				t.Errorf("expected the %s kind, got %+v", tt.wantKind, e)
+			}
+		})
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "mapping.yml")
+
+	err := os.WriteFile(file, []byte(`
+- template: "iosv"
+  kind: cisco_c8000v
+  interface-offset: 0
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapping, err := LoadMapping(file)
+	if err != nil {
+		t.Fatalf("LoadMapping returned error: %v", err)
+	}
+
+	// the entries of the mapping file take precedence over the default ones
+	e := mapping.Lookup(FormatCML, "iosv")
+	if e == nil || e.Kind != "cisco_c8000v" || e.interfaceOffset() != 0 {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	if e := mapping.Lookup(FormatCML, "csr1000v"); e == nil || e.Kind != "cisco_csr1000v" {
+		t.Errorf("the default entry is not used, got %+v", e)
+	}
+
+	for name, data := range map[string]string{
+		"no kind":      `[{template: iosv}]`,
+		"bad format":   `[{from: virl, template: iosv, kind: linux}]`,
+		"unknown keys": `[{template: iosv, kind: linux, offset: 1}]`,
+	} {
+		if _, err := ParseMapping([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
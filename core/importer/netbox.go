@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"net"
+
+	"github.com/charmbracelet/log"
+	clabcorenetbox "github.com/srl-labs/containerlab/core/netbox"
+)
+
+// parseNetBox parses the NetBox export data. The device types are the templates,
+// the device roles are the groups and the primary IP addresses are the management
+// addresses of the nodes. The cables between the interfaces are the links.
+func parseNetBox(data []byte) (*lab, error) {
+	d, err := clabcorenetbox.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &lab{}
+
+	if len(d.Sites) > 0 {
+		l.name = d.Sites[0].Name
+	}
+
+	nodes := make(map[string]*node, len(d.Devices))
+
+	for _, dev := range d.Devices {
+		n := &node{
+			id:       dev.Name,
+			name:     dev.Name,
+			template: dev.DeviceType,
+		}
+
+		if dev.Role != clabcorenetbox.DefaultRole {
+			n.group = dev.Role
+		}
+
+		nodes[dev.Name] = n
+		l.nodes = append(l.nodes, n)
+	}
+
+	for _, addr := range d.IPAddresses {
+		n, ok := nodes[addr.Device]
+		if !ok || !addr.IsPrimary {
+			continue
+		}
+
+		ip, ipNet, err := net.ParseCIDR(addr.Address)
+		if err != nil {
+			log.Warnf("Skipping the invalid IP address %q of the device %s", addr.Address, addr.Device)
+
+			continue
+		}
+
+		// the management subnet is the subnet of the first address with one
+		ones, bits := ipNet.Mask.Size()
+
+		subnet := ""
+		if ones < bits {
+			subnet = ipNet.String()
+		}
+
+		if ip.To4() != nil {
+			n.mgmtIPv4 = ip.String()
+
+			if l.mgmtIPv4Subnet == "" {
+				l.mgmtIPv4Subnet = subnet
+			}
+		} else {
+			n.mgmtIPv6 = ip.String()
+
+			if l.mgmtIPv6Subnet == "" {
+				l.mgmtIPv6Subnet = subnet
+			}
+		}
+	}
+
+	for _, c := range d.Cables {
+		_, okA := nodes[c.SideADevice]
+		_, okB := nodes[c.SideBDevice]
+
+		if !okA || !okB || c.SideAType != clabcorenetbox.CableTerminationType ||
+			c.SideBType != clabcorenetbox.CableTerminationType {
+			log.Warnf("Skipping the cable %s:%s - %s:%s not connecting the interfaces "+
+				"of the exported devices",
+				c.SideADevice, c.SideAName, c.SideBDevice, c.SideBName)
+
+			continue
+		}
+
+		l.links = append(l.links, link{
+			a: endpoint{node: c.SideADevice, name: c.SideAName},
+			b: endpoint{node: c.SideBDevice, name: c.SideBName},
+		})
+	}
+
+	return l, nil
+}
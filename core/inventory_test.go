@@ -362,3 +362,42 @@ node3:
 		})
 	}
 }
+
+func TestGenerateInventoriesIPAMLoopbacks(t *testing.T) {
+	c, err := NewContainerLab(WithTopoPath("test_data/topo_ipam.yml", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ansible strings.Builder
+	if err := c.generateAnsibleInventory(&ansible); err != nil {
+		t.Fatal(err)
+	}
+
+	wantAnsible := "ansible_host: 192.168.79.2\n          loopback_ipv4: 10.255.0.2/32"
+	if !strings.Contains(ansible.String(), wantAnsible) {
+		t.Fatalf("expected loopback host var in ansible inventory, got:\n%s", ansible.String())
+	}
+
+	var nornir strings.Builder
+	if err := c.generateNornirSimpleInventory(&nornir); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(nornir.String(), "    data:\n      loopback_ipv4: 10.255.0.1/32") {
+		t.Fatalf("expected loopback data in nornir inventory, got:\n%s", nornir.String())
+	}
+
+	vars := c.Nodes["n1"].Config().Config.GetVars()
+	wantVars := map[string]any{
+		"loopback-ipv4": "10.255.0.1/32",
+		"loopback-ipv6": "",
+		"interfaces": map[string]any{
+			"eth1": map[string]any{"ipv4": "10.0.0.0/31", "ipv6": ""},
+		},
+	}
+
+	if d := cmp.Diff(wantVars, vars["ipam"]); d != "" {
+		t.Errorf("ipam config vars mismatch (-want +got):\n%s", d)
+	}
+}
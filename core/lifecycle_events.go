@@ -0,0 +1,151 @@
+package core
+
+import (
+	"maps"
+	"strconv"
+
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
+	clabcoredependency_manager "github.com/srl-labs/containerlab/core/dependency_manager"
+	clabexec "github.com/srl-labs/containerlab/exec"
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+	clabruntime "github.com/srl-labs/containerlab/runtime"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+// publishEvent publishes the lab-wide lifecycle event on the event bus.
+func (c *CLab) publishEvent(action string, attributes map[string]string) {
+	if c.eventBus == nil {
+		return
+	}
+
+	attrs := map[string]string{"lab": c.Config.Name}
+	maps.Copy(attrs, attributes)
+
+	c.eventBus.Publish(clabcorebus.Event{
+		Action:     action,
+		Actor:      c.Config.Name,
+		Attributes: attrs,
+	})
+}
+
+// publishNodeEvent publishes the lifecycle event of the node on the event bus.
+func (c *CLab) publishNodeEvent(
+	node clabnodes.Node,
+	action string,
+	attributes map[string]string,
+) {
+	if c.eventBus == nil {
+		return
+	}
+
+	cfg := node.Config()
+
+	attrs := map[string]string{
+		"lab":  c.Config.Name,
+		"node": cfg.ShortName,
+		"kind": cfg.Kind,
+	}
+	maps.Copy(attrs, attributes)
+
+	c.eventBus.Publish(clabcorebus.Event{
+		Action:     action,
+		Actor:      cfg.LongName,
+		Attributes: attrs,
+	})
+}
+
+// publishOutcome publishes the done or the fail action of the lab operation.
+func (c *CLab) publishOutcome(done, fail string, err error) {
+	if err != nil {
+		c.publishEvent(fail, map[string]string{"error": err.Error()})
+
+		return
+	}
+
+	c.publishEvent(done, nil)
+}
+
+func (c *CLab) publishNodeStage(node clabnodes.Node, stage clabtypes.WaitForStage) {
+	c.publishNodeEvent(node, clabcorebus.ActionNodeStage, map[string]string{
+		"stage": string(stage),
+	})
+}
+
+// publishNodeLinks publishes the deployed links of the node endpoints.
+func (c *CLab) publishNodeLinks(node clabnodes.Node) {
+	if c.eventBus == nil {
+		return
+	}
+
+	for _, ep := range node.GetEndpoints() {
+		attrs := map[string]string{"interface": ep.GetIfaceName()}
+
+		if link := ep.GetLink(); link != nil {
+			maps.Copy(attrs, linkEventAttributes(link))
+		}
+
+		c.publishNodeEvent(node, clabcorebus.ActionLinkDeploy, attrs)
+	}
+}
+
+func linkEventAttributes(link clablinks.Link) map[string]string {
+	return map[string]string{
+		"link":      applyLinkName(link),
+		"link_type": string(link.GetType()),
+	}
+}
+
+// publishExecResults publishes the results of the exec commands run on the node.
+func (c *CLab) publishExecResults(node clabnodes.Node, results []*clabexec.ExecResult) {
+	for _, res := range results {
+		c.publishNodeEvent(node, clabcorebus.ActionExec, map[string]string{
+			"command":   res.GetCmdString(),
+			"exit_code": strconv.Itoa(res.GetReturnCode()),
+		})
+	}
+}
+
+// publishContainerExecResult publishes the result of the exec command run on the container.
+func (c *CLab) publishContainerExecResult(
+	container *clabruntime.GenericContainer,
+	res *clabexec.ExecResult,
+) {
+	lab, node := TopoIdentity(container.Labels)
+
+	c.eventBus.Publish(clabcorebus.Event{
+		Action: clabcorebus.ActionExec,
+		Actor:  container.Names[0],
+		Attributes: map[string]string{
+			"lab":       lab,
+			"node":      node,
+			"kind":      container.Labels[clabconstants.NodeKind],
+			"command":   res.GetCmdString(),
+			"exit_code": strconv.Itoa(res.GetReturnCode()),
+		},
+	})
+}
+
+// publishStageExecResult publishes the result of the stage exec command.
+func (c *CLab) publishStageExecResult(res *clabcoredependency_manager.StageExecResult) {
+	node, ok := c.Nodes[res.Node]
+	if !ok {
+		return
+	}
+
+	attrs := map[string]string{
+		"stage":     string(res.Stage),
+		"phase":     string(res.Phase),
+		"target":    string(res.Target),
+		"command":   res.Command,
+		"attempts":  strconv.Itoa(res.Attempts),
+		"exit_code": strconv.Itoa(res.ExitCode),
+	}
+
+	if res.Error != "" {
+		attrs["error"] = res.Error
+	}
+
+	c.publishNodeEvent(node, clabcorebus.ActionExec, attrs)
+}
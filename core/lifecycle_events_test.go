@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func TestPublishLifecycleEvents(t *testing.T) {
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber, err := clabcorebus.Subscribe(ctx, dir)
+	if err != nil {
+		t.Fatalf("failed to subscribe to the event bus: %v", err)
+	}
+
+	c, err := NewContainerLab(WithTopoPath("test_data/topo1.yml", nil))
+	if err != nil {
+		t.Fatalf("failed to load topology: %v", err)
+	}
+
+	c.eventBus = clabcorebus.NewPublisher(dir)
+
+	c.publishEvent(clabcorebus.ActionDeployStart, nil)
+	c.publishNodeStage(c.Nodes["node1"], clabtypes.WaitForConfigure)
+	c.publishOutcome(clabcorebus.ActionDeployDone, clabcorebus.ActionDeployFail,
+		errors.New("node1 failed"))
+
+	want := []clabcorebus.Event{
+		{
+			Action:     clabcorebus.ActionDeployStart,
+			Actor:      "topo1",
+			Attributes: map[string]string{"lab": "topo1"},
+		},
+		{
+			Action: clabcorebus.ActionNodeStage,
+			Actor:  "clab-topo1-node1",
+			Attributes: map[string]string{
+				"lab":   "topo1",
+				"node":  "node1",
+				"kind":  "nokia_srlinux",
+				"stage": "configure",
+			},
+		},
+		{
+			Action:     clabcorebus.ActionDeployFail,
+			Actor:      "topo1",
+			Attributes: map[string]string{"lab": "topo1", "error": "node1 failed"},
+		},
+	}
+
+	var got []clabcorebus.Event
+
+	for range want {
+		select {
+		case ev := <-subscriber.Events():
+			got = append(got, ev)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the events, got %d", len(got))
+		}
+	}
+
+	if d := cmp.Diff(want, got, cmpopts.IgnoreFields(clabcorebus.Event{}, "Timestamp")); d != "" {
+		t.Errorf("lifecycle events mismatch (-want +got):\n%s", d)
+	}
+}
@@ -0,0 +1,500 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+
+	clabtypes "github.com/srl-labs/containerlab/types"
+	clabutils "github.com/srl-labs/containerlab/utils"
+	clabvirt "github.com/srl-labs/containerlab/virt"
+)
+
+// LintSeverity is the severity of a linter finding.
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+	LintSeverityInfo    LintSeverity = "info"
+)
+
+// rank orders the severities from the least to the most severe.
+func (s LintSeverity) rank() int {
+	switch s {
+	case LintSeverityError:
+		return 2 //nolint: mnd
+	case LintSeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as severe as or more severe than other.
+func (s LintSeverity) AtLeast(other LintSeverity) bool {
+	return s.rank() >= other.rank()
+}
+
+// ParseLintSeverity parses the severity name.
+func ParseLintSeverity(s string) (LintSeverity, error) {
+	switch sev := LintSeverity(strings.ToLower(s)); sev {
+	case LintSeverityError, LintSeverityWarning, LintSeverityInfo:
+		return sev, nil
+	}
+
+	return "", fmt.Errorf("unknown severity %q, expected one of %s, %s, %s",
+		s, LintSeverityError, LintSeverityWarning, LintSeverityInfo)
+}
+
+// LintRule is a semantic check of the topology.
+type LintRule struct {
+	ID          string       `json:"id"`
+	Severity    LintSeverity `json:"severity"`
+	Description string       `json:"description"`
+	// check returns the rule findings, the severity of the findings
+	// defaults to the rule severity when not set.
+	check func(ctx context.Context, c *CLab) []*LintFinding
+}
+
+// LintFinding is a single issue reported by a linter rule.
+type LintFinding struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Node     string       `json:"node,omitempty"`
+	Message  string       `json:"message"`
+	// Suppressed findings are reported, but do not affect the lint result.
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+// LintReport is the result of the topology linting.
+type LintReport struct {
+	Topology string         `json:"topology"`
+	Rules    []*LintRule    `json:"-"`
+	Findings []*LintFinding `json:"findings"`
+}
+
+// Failed reports whether the report has unsuppressed findings with the severity
+// of at least minSeverity.
+func (r *LintReport) Failed(minSeverity LintSeverity) bool {
+	for _, f := range r.Findings {
+		if !f.Suppressed && f.Severity.AtLeast(minSeverity) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LintRules returns the linter rules in the order they run.
+func LintRules() []*LintRule {
+	return []*LintRule{
+		{
+			ID:          "unused-kind",
+			Severity:    LintSeverityWarning,
+			Description: "A kind defined in the topology is not used by any node.",
+			check:       lintUnusedKinds,
+		},
+		{
+			ID:          "unused-group",
+			Severity:    LintSeverityWarning,
+			Description: "A group defined in the topology is not used by any node.",
+			check:       lintUnusedGroups,
+		},
+		{
+			ID:          "invalid-interface-name",
+			Severity:    LintSeverityError,
+			Description: "An interface name does not match the interface naming of the node kind.",
+			check:       lintInterfaceNames,
+		},
+		{
+			ID:          "duplicate-endpoint",
+			Severity:    LintSeverityError,
+			Description: "The same node interface is used by more than one link.",
+			check:       lintDuplicateEndpoints,
+		},
+		{
+			ID:          "link-mtu-mismatch",
+			Severity:    LintSeverityWarning,
+			Description: "Links bundled between the same nodes or attached to the same bridge use different MTUs.",
+			check:       lintLinkMTUs,
+		},
+		{
+			ID:          "mgmt-ip-outside-subnet",
+			Severity:    LintSeverityError,
+			Description: "A node management address is outside of the management network subnet.",
+			check:       lintMgmtAddresses,
+		},
+		{
+			ID:          "missing-startup-config",
+			Severity:    LintSeverityError,
+			Description: "The startup-config file of a node does not exist.",
+			check:       lintStartupConfigs,
+		},
+		{
+			ID:          "missing-license",
+			Severity:    LintSeverityError,
+			Description: "The license file of a node does not exist or a required license is not set.",
+			check:       lintLicenses,
+		},
+		{
+			ID:          "host-resources",
+			Severity:    LintSeverityWarning,
+			Description: "The host does not satisfy the resource requirements of the lab nodes.",
+			check:       lintHostResources,
+		},
+	}
+}
+
+// Lint runs the linter rules against the parsed topology. Links must be resolved
+// with ResolveLinks beforehand. Findings matching the suppressions of the topology
+// settings or the given suppressions are marked as suppressed.
+func (c *CLab) Lint(ctx context.Context, suppressions []*clabtypes.LintSuppression) *LintReport {
+	if c.Config.Settings != nil && c.Config.Settings.Lint != nil {
+		suppressions = append(slices.Clone(c.Config.Settings.Lint.Suppress), suppressions...)
+	}
+
+	report := &LintReport{
+		Topology: c.TopoPaths.TopologyFilenameAbsPath(),
+		Rules:    LintRules(),
+		Findings: []*LintFinding{},
+	}
+
+	for _, rule := range report.Rules {
+		for _, f := range rule.check(ctx, c) {
+			f.Rule = rule.ID
+
+			if f.Severity == "" {
+				f.Severity = rule.Severity
+			}
+
+			f.Suppressed = slices.ContainsFunc(suppressions, func(s *clabtypes.LintSuppression) bool {
+				return s.Matches(f.Rule, f.Node)
+			})
+
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	return report
+}
+
+// ParseLintSuppression parses the suppression given as `rule` or `rule:node`.
+func ParseLintSuppression(s string) (*clabtypes.LintSuppression, error) {
+	rule, node, _ := strings.Cut(s, ":")
+	if rule == "" {
+		return nil, fmt.Errorf("invalid suppression %q, expected rule or rule:node", s)
+	}
+
+	if !slices.ContainsFunc(LintRules(), func(r *LintRule) bool { return r.ID == rule }) {
+		return nil, fmt.Errorf("invalid suppression %q, unknown rule %q", s, rule)
+	}
+
+	sup := &clabtypes.LintSuppression{Rule: rule}
+	if node != "" {
+		sup.Nodes = []string{node}
+	}
+
+	return sup, nil
+}
+
+// sortedNodeNames returns the names of the lab nodes in a stable order.
+func (c *CLab) sortedNodeNames() []string {
+	names := make([]string, 0, len(c.Nodes))
+	for name := range c.Nodes {
+		names = append(names, name)
+	}
+
+	slices.SortFunc(names, clabutils.NaturalCompare)
+
+	return names
+}
+
+func lintUnusedKinds(_ context.Context, c *CLab) []*LintFinding {
+	topo := c.Config.Topology
+
+	used := map[string]struct{}{}
+	for nodeName := range topo.Nodes {
+		used[topo.GetNodeKind(nodeName)] = struct{}{}
+	}
+
+	var findings []*LintFinding
+
+	for _, kind := range sortedKeys(topo.Kinds) {
+		if _, ok := used[kind]; !ok {
+			findings = append(findings, &LintFinding{
+				Message: fmt.Sprintf("kind %q is defined but not used by any node", kind),
+			})
+		}
+	}
+
+	return findings
+}
+
+func lintUnusedGroups(_ context.Context, c *CLab) []*LintFinding {
+	topo := c.Config.Topology
+
+	used := map[string]struct{}{}
+	for nodeName := range topo.Nodes {
+		used[topo.GetNodeGroup(nodeName)] = struct{}{}
+	}
+
+	var findings []*LintFinding
+
+	for _, group := range sortedKeys(topo.Groups) {
+		if _, ok := used[group]; !ok {
+			findings = append(findings, &LintFinding{
+				Message: fmt.Sprintf("group %q is defined but not used by any node", group),
+			})
+		}
+	}
+
+	return findings
+}
+
+func lintInterfaceNames(_ context.Context, c *CLab) []*LintFinding {
+	var findings []*LintFinding
+
+	for _, name := range c.sortedNodeNames() {
+		if err := c.Nodes[name].CheckInterfaceName(); err != nil {
+			findings = append(findings, &LintFinding{
+				Node:    name,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return findings
+}
+
+func lintDuplicateEndpoints(_ context.Context, c *CLab) []*LintFinding {
+	var (
+		findings []*LintFinding
+		// index of the first link using an endpoint
+		seen = map[string]int{}
+	)
+
+	for i, ld := range c.Config.Topology.Links {
+		if ld == nil {
+			continue
+		}
+
+		for _, ep := range ld.RawEndpoints() {
+			if ep == nil || ep.Iface == "" {
+				continue
+			}
+
+			key := ep.Node + ":" + ep.Iface
+
+			first, dup := seen[key]
+			if !dup {
+				seen[key] = i
+				continue
+			}
+
+			findings = append(findings, &LintFinding{
+				Node: ep.Node,
+				Message: fmt.Sprintf("endpoint %s is used by link %d and link %d",
+					key, first, i),
+			})
+		}
+	}
+
+	return findings
+}
+
+func lintLinkMTUs(_ context.Context, c *CLab) []*LintFinding {
+	// MTUs of the links per node pair and per bridge node
+	mtus := map[string]map[int]struct{}{}
+	nodes := map[string]string{}
+
+	add := func(key, node string, mtu int) {
+		if _, ok := mtus[key]; !ok {
+			mtus[key] = map[int]struct{}{}
+		}
+
+		mtus[key][mtu] = struct{}{}
+		nodes[key] = node
+	}
+
+	for _, l := range c.Links {
+		eps := l.GetEndpoints()
+
+		names := make([]string, 0, len(eps))
+
+		for _, ep := range eps {
+			n := ep.GetNode()
+			if n == nil {
+				continue
+			}
+
+			name := n.GetShortName()
+			names = append(names, name)
+
+			if node, ok := c.Nodes[name]; ok && isBridgeKind(node.Config().Kind) {
+				add("bridge "+name, name, l.GetMTU())
+			}
+		}
+
+		if len(names) == 2 { //nolint: mnd
+			slices.Sort(names)
+			add("links between "+names[0]+" and "+names[1], names[0], l.GetMTU())
+		}
+	}
+
+	var findings []*LintFinding
+
+	for _, key := range sortedKeys(mtus) {
+		if len(mtus[key]) < 2 { //nolint: mnd
+			continue
+		}
+
+		values := make([]string, 0, len(mtus[key]))
+		for _, mtu := range slices.Sorted(maps.Keys(mtus[key])) {
+			values = append(values, fmt.Sprint(mtu))
+		}
+
+		findings = append(findings, &LintFinding{
+			Node:    nodes[key],
+			Message: fmt.Sprintf("%s use different MTUs: %s", key, strings.Join(values, ", ")),
+		})
+	}
+
+	return findings
+}
+
+func isBridgeKind(kind string) bool {
+	return kind == "bridge" || kind == "ovs-bridge"
+}
+
+func lintMgmtAddresses(_ context.Context, c *CLab) []*LintFinding {
+	var findings []*LintFinding
+
+	check := func(node, addr, subnet string) {
+		if addr == "" || subnet == "" {
+			return
+		}
+
+		a, err := netip.ParseAddr(addr)
+		if err != nil {
+			findings = append(findings, &LintFinding{
+				Node:    node,
+				Message: fmt.Sprintf("management address %q is not a valid IP address", addr),
+			})
+
+			return
+		}
+
+		p, err := netip.ParsePrefix(subnet)
+		if err != nil || p.Contains(a) {
+			return
+		}
+
+		findings = append(findings, &LintFinding{
+			Node: node,
+			Message: fmt.Sprintf("management address %s is outside of the management subnet %s",
+				addr, subnet),
+		})
+	}
+
+	for _, name := range c.sortedNodeNames() {
+		cfg := c.Nodes[name].Config()
+		// nodes sharing the network stack of another container have no own management address
+		if cfg.NetworkMode != "" && cfg.NetworkMode != "bridge" {
+			continue
+		}
+
+		check(name, cfg.MgmtIPv4Address, c.Config.Mgmt.IPv4Subnet)
+		check(name, cfg.MgmtIPv6Address, c.Config.Mgmt.IPv6Subnet)
+	}
+
+	return findings
+}
+
+func lintStartupConfigs(_ context.Context, c *CLab) []*LintFinding {
+	var findings []*LintFinding
+
+	for _, name := range c.sortedNodeNames() {
+		if err := c.Nodes[name].VerifyStartupConfig(c.TopoPaths.TopologyFileDir()); err != nil {
+			findings = append(findings, &LintFinding{
+				Node:    name,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return findings
+}
+
+func lintLicenses(ctx context.Context, c *CLab) []*LintFinding {
+	var findings []*LintFinding
+
+	for _, name := range c.sortedNodeNames() {
+		n, ok := c.Nodes[name].(interface {
+			VerifyLicenseFileExists(context.Context) error
+		})
+		if !ok {
+			continue
+		}
+
+		if err := n.VerifyLicenseFileExists(ctx); err != nil {
+			findings = append(findings, &LintFinding{
+				Node:    name,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return findings
+}
+
+func lintHostResources(_ context.Context, c *CLab) []*LintFinding {
+	var (
+		findings []*LintFinding
+		// memory required by all nodes together
+		totalMemGB int
+	)
+
+	for _, name := range c.sortedNodeNames() {
+		n, ok := c.Nodes[name].(interface {
+			GetHostRequirements() *clabtypes.HostRequirements
+		})
+		if !ok || n.GetHostRequirements() == nil {
+			continue
+		}
+
+		reqs := n.GetHostRequirements()
+		totalMemGB += reqs.MinAvailMemoryGb
+
+		for _, issue := range reqs.Check(c.Nodes[name].Config().Kind, name) {
+			f := &LintFinding{
+				Node:    name,
+				Message: issue.Message,
+			}
+
+			if issue.FailAction == clabtypes.FailBehaviourError {
+				f.Severity = LintSeverityError
+			}
+
+			findings = append(findings, f)
+		}
+	}
+
+	availMemGB := clabvirt.GetSysMemory(clabvirt.MemoryTypeAvailable) / 1024 / 1024 / 1024 //nolint: mnd
+	if totalMemGB > 0 && uint64(totalMemGB) > availMemGB {
+		findings = append(findings, &LintFinding{
+			Message: fmt.Sprintf(
+				"lab nodes require %d GB of available memory in total whilst only %d GB is available",
+				totalMemGB, availMemGB),
+		})
+	}
+
+	return findings
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
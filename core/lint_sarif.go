@@ -0,0 +1,190 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolURI = "https://containerlab.dev/cmd/validate/"
+)
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version,omitempty"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	ShortDescription     *sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string              `json:"ruleId"`
+	RuleIndex    int                 `json:"ruleIndex"`
+	Level        string              `json:"level"`
+	Message      *sarifMessage       `json:"message"`
+	Locations    []*sarifLocation    `json:"locations"`
+	Suppressions []*sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion           `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+// sarifLevel maps the linter severity to the SARIF result level.
+func sarifLevel(s LintSeverity) string {
+	if s == LintSeverityInfo {
+		return "note"
+	}
+
+	return string(s)
+}
+
+// SARIF returns the report in the SARIF 2.1.0 format.
+// The results are located in the topology file, relative to the current working directory
+// when possible, on the line the node of a finding is defined at.
+func (r *LintReport) SARIF(toolVersion string) ([]byte, error) {
+	uri := r.Topology
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, r.Topology); err == nil && filepath.IsLocal(rel) {
+			uri = filepath.ToSlash(rel)
+		}
+	}
+
+	nodeLines := topologyNodeLines(r.Topology)
+
+	driver := &sarifDriver{
+		Name:           "containerlab",
+		Version:        toolVersion,
+		InformationURI: sarifToolURI,
+		Rules:          make([]*sarifRule, 0, len(r.Rules)),
+	}
+
+	ruleIndex := map[string]int{}
+
+	for i, rule := range r.Rules {
+		ruleIndex[rule.ID] = i
+
+		driver.Rules = append(driver.Rules, &sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     &sarifMessage{Text: rule.Description},
+			DefaultConfiguration: &sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	results := make([]*sarifResult, 0, len(r.Findings))
+
+	for _, f := range r.Findings {
+		loc := &sarifPhysicalLocation{
+			ArtifactLocation: &sarifArtifactLocation{URI: uri},
+		}
+
+		if line, ok := nodeLines[f.Node]; ok {
+			loc.Region = &sarifRegion{StartLine: line}
+		}
+
+		res := &sarifResult{
+			RuleID:    f.Rule,
+			RuleIndex: ruleIndex[f.Rule],
+			Level:     sarifLevel(f.Severity),
+			Message:   &sarifMessage{Text: f.Message},
+			Locations: []*sarifLocation{{PhysicalLocation: loc}},
+		}
+
+		if f.Suppressed {
+			res.Suppressions = []*sarifSuppression{{Kind: "external"}}
+		}
+
+		results = append(results, res)
+	}
+
+	return json.MarshalIndent(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []*sarifRun{
+			{
+				Tool:    &sarifTool{Driver: driver},
+				Results: results,
+			},
+		},
+	}, "", "  ")
+}
+
+var topologyKeyRe = regexp.MustCompile(`^\s+([^\s#:]+):\s*(#.*)?$`)
+
+// topologyNodeLines returns the line numbers of the map keys found in the topology file,
+// the first occurrence of a key wins. It is a best-effort way of locating the node definitions
+// in the topology file, as the topology is parsed after the template is rendered.
+func topologyNodeLines(topoFile string) map[string]int {
+	lines := map[string]int{}
+
+	f, err := os.Open(topoFile)
+	if err != nil {
+		return lines
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for n := 1; scanner.Scan(); n++ {
+		m := topologyKeyRe.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+
+		if _, ok := lines[m[1]]; !ok {
+			lines[m[1]] = n
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func TestLint(t *testing.T) {
+	c, err := NewContainerLab(WithTopoPath("test_data/topo_lint.yml", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ResolveLinks(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := c.Lint(context.Background(), []*clabtypes.LintSuppression{
+		{Rule: "unused-group"},
+	})
+
+	type finding struct {
+		Rule       string
+		Node       string
+		Suppressed bool
+	}
+
+	var got []finding
+	for _, f := range report.Findings {
+		got = append(got, finding{Rule: f.Rule, Node: f.Node, Suppressed: f.Suppressed})
+	}
+
+	want := []finding{
+		{Rule: "unused-kind"},
+		{Rule: "unused-group", Suppressed: true},
+		{Rule: "link-mtu-mismatch", Node: "n1"},
+		{Rule: "mgmt-ip-outside-subnet", Node: "n1"},
+		{Rule: "missing-startup-config", Node: "n1"},
+	}
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Fatalf("Lint() findings mismatch (-want +got):\n%s", d)
+	}
+
+	if !report.Failed(LintSeverityError) {
+		t.Error("expected the report to fail on errors")
+	}
+
+	b, err := report.SARIF("0.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sarif sarifLog
+	if err := json.Unmarshal(b, &sarif); err != nil {
+		t.Fatal(err)
+	}
+
+	results := sarif.Runs[0].Results
+	if len(results) != len(want) {
+		t.Fatalf("got %d SARIF results, want %d", len(results), len(want))
+	}
+
+	if len(results[1].Suppressions) != 1 {
+		t.Error("expected the suppressed finding to have a SARIF suppression")
+	}
+
+	if r := results[3].Locations[0].PhysicalLocation.Region; r == nil || r.StartLine != 14 {
+		t.Errorf("expected the n1 finding to be located at line 14, got %+v", r)
+	}
+}
+
+func TestParseLintSuppression(t *testing.T) {
+	sup, err := ParseLintSuppression("missing-license:n1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := cmp.Diff(&clabtypes.LintSuppression{Rule: "missing-license", Nodes: []string{"n1"}}, sup); d != "" {
+		t.Errorf("ParseLintSuppression() mismatch (-want +got):\n%s", d)
+	}
+
+	if _, err := ParseLintSuppression("no-such-rule"); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+}
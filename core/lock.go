@@ -0,0 +1,265 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"golang.org/x/sys/unix"
+
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+// labLockPollInterval is the interval the lab lock is retried at while waiting for it.
+const labLockPollInterval = 500 * time.Millisecond
+
+// LabLockHolder describes the process holding the lab lock, it is written to the lock file.
+type LabLockHolder struct {
+	Owner    string    `json:"owner"`
+	Hostname string    `json:"hostname,omitempty"`
+	PID      int       `json:"pid"`
+	Command  string    `json:"command"`
+	Started  time.Time `json:"started"`
+}
+
+func (h *LabLockHolder) String() string {
+	return fmt.Sprintf("%s (pid %d on %s) running %q since %s", h.Owner, h.PID, h.Hostname,
+		h.Command, h.Started.Format(time.DateTime))
+}
+
+// LabLockedError is returned when the lab is locked by another containerlab process.
+type LabLockedError struct {
+	Lab string
+	// Holder is nil when the lock file does not describe the holder
+	Holder *LabLockHolder
+}
+
+func (e *LabLockedError) Error() string {
+	holder := "another containerlab process"
+	if e.Holder != nil {
+		holder = e.Holder.String()
+	}
+
+	return fmt.Sprintf("lab %q is locked by %s; use --wait to wait for the lock "+
+		"or --force-unlock to remove a stale lock", e.Lab, holder)
+}
+
+// labLock is the lab lock held by this process. The lock is re-entrant within the process,
+// so that a command can hold the lock across several operations, e.g. redeploy holds it
+// across destroy and deploy.
+type labLock struct {
+	file *os.File
+	refs int
+}
+
+var (
+	labLocksMu sync.Mutex
+	// labLocks are the lab locks held by this process keyed by the lock file path
+	labLocks = map[string]*labLock{}
+)
+
+// LockLab acquires the advisory lock of the lab, creating the lab directory if needed.
+// The returned function releases the lock.
+func (c *CLab) LockLab(ctx context.Context) (func(), error) {
+	return c.lockLab(ctx, true)
+}
+
+// lockLab acquires the advisory lock stored in the lab directory. Labs without the lab
+// directory are not locked unless createLabDir is set. The lock waits for the lock holder
+// or removes the lock of the holder according to the WithLabLock option.
+func (c *CLab) lockLab(ctx context.Context, createLabDir bool) (func(), error) {
+	labDir := c.TopoPaths.TopologyLabDir()
+	if labDir == "" {
+		return func() {}, nil
+	}
+
+	if !clabutils.DirExists(labDir) {
+		if !createLabDir {
+			return func() {}, nil
+		}
+
+		if err := os.MkdirAll(labDir, clabconstants.PermissionsDirDefault); err != nil {
+			return nil, fmt.Errorf("creating lab directory %q: %w", labDir, err)
+		}
+	}
+
+	path := c.TopoPaths.LabLockFile()
+
+	labLocksMu.Lock()
+
+	// the lock file of a held lock may have been removed with the lab directory
+	// by the operation holding the lock, the lock is then acquired again
+	if l, ok := labLocks[path]; ok && lockFileIsCurrent(l.file, path) {
+		l.refs++
+		labLocksMu.Unlock()
+
+		return func() { releaseLabLock(path, l) }, nil
+	}
+
+	labLocksMu.Unlock()
+
+	f, err := c.acquireLabLock(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	labLocksMu.Lock()
+	defer labLocksMu.Unlock()
+
+	l, ok := labLocks[path]
+	if ok {
+		l.file.Close()
+		l.file = f
+		l.refs++
+	} else {
+		l = &labLock{file: f, refs: 1}
+		labLocks[path] = l
+	}
+
+	return func() { releaseLabLock(path, l) }, nil
+}
+
+func releaseLabLock(path string, l *labLock) {
+	labLocksMu.Lock()
+	defer labLocksMu.Unlock()
+
+	l.refs--
+	if l.refs > 0 {
+		return
+	}
+
+	// the holder is cleared for the lock file not to point to a finished process
+	_ = l.file.Truncate(0)
+	l.file.Close()
+
+	if labLocks[path] == l {
+		delete(labLocks, path)
+	}
+}
+
+// acquireLabLock locks the lock file and writes the lock holder to it.
+func (c *CLab) acquireLabLock(ctx context.Context, path string) (*os.File, error) {
+	waiting, unlocked := false, false
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, clabconstants.PermissionsFileDefault)
+		if err != nil {
+			return nil, fmt.Errorf("opening lab lock %q: %w", path, err)
+		}
+
+		err = unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+		if err == nil {
+			// the lock file was removed while it was being locked,
+			// the lock is only valid on the file present in the lab directory
+			if !lockFileIsCurrent(f, path) {
+				f.Close()
+
+				continue
+			}
+
+			if err := writeLabLockHolder(f); err != nil {
+				f.Close()
+
+				return nil, err
+			}
+
+			return f, nil
+		}
+
+		f.Close()
+
+		if !errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, fmt.Errorf("acquiring lab lock: %w", err)
+		}
+
+		holder := readLabLockHolder(path)
+
+		switch {
+		case c.forceUnlockLab && !unlocked:
+			log.Warn("Removing the lab lock", "lab", c.Config.Name, "holder", holder)
+
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("removing lab lock %q: %w", path, err)
+			}
+
+			unlocked = true
+		case c.waitLabLock:
+			if !waiting {
+				log.Info("Waiting for the lab lock", "lab", c.Config.Name, "holder", holder)
+
+				waiting = true
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("waiting for lab %q lock: %w", c.Config.Name, ctx.Err())
+			case <-time.After(labLockPollInterval):
+			}
+		default:
+			return nil, &LabLockedError{Lab: c.Config.Name, Holder: holder}
+		}
+	}
+}
+
+// lockFileIsCurrent reports whether the opened lock file is the file present at the path.
+func lockFileIsCurrent(f *os.File, path string) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	pi, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(fi, pi)
+}
+
+func writeLabLockHolder(f *os.File) error {
+	hostname, _ := os.Hostname()
+
+	b, err := json.Marshal(&LabLockHolder{
+		Owner:    clabutils.GetOwner(),
+		Hostname: hostname,
+		PID:      os.Getpid(),
+		Command:  strings.Join(os.Args, " "),
+		Started:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("writing lab lock holder: %w", err)
+	}
+
+	if _, err := f.WriteAt(append(b, '\n'), 0); err != nil {
+		return fmt.Errorf("writing lab lock holder: %w", err)
+	}
+
+	return nil
+}
+
+// readLabLockHolder returns the holder written to the lock file,
+// nil is returned if the holder can't be read.
+func readLabLockHolder(path string) *LabLockHolder {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	holder := &LabLockHolder{}
+	if err := json.Unmarshal(b, holder); err != nil {
+		return nil
+	}
+
+	return holder
+}
@@ -0,0 +1,251 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	"golang.org/x/sys/unix"
+)
+
+func newLockTestLab(t *testing.T, labDir string) *CLab {
+	t.Helper()
+
+	topoPaths := &clabtypes.TopoPaths{}
+	if err := topoPaths.SetLabDir(labDir); err != nil {
+		t.Fatalf("failed to set lab dir: %v", err)
+	}
+
+	return &CLab{
+		Config:    &Config{Name: "locked"},
+		TopoPaths: topoPaths,
+	}
+}
+
+// holdLabLock locks the lab lock file through a separate open file description,
+// the same way another process holds the lock.
+func holdLabLock(t *testing.T, path string, holder *LabLockHolder) *os.File {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open lock file: %v", err)
+	}
+
+	t.Cleanup(func() { f.Close() })
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		t.Fatalf("failed to lock the lock file: %v", err)
+	}
+
+	b, err := json.Marshal(holder)
+	if err != nil {
+		t.Fatalf("failed to marshal lock holder: %v", err)
+	}
+
+	if _, err := f.Write(b); err != nil {
+		t.Fatalf("failed to write lock holder: %v", err)
+	}
+
+	return f
+}
+
+func testLockHolder() *LabLockHolder {
+	return &LabLockHolder{
+		Owner:    "alice",
+		Hostname: "lab-host",
+		PID:      4242,
+		Command:  "containerlab deploy -t lab.clab.yml",
+		Started:  time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestLockLabLocked(t *testing.T) {
+	c := newLockTestLab(t, t.TempDir())
+	holdLabLock(t, c.TopoPaths.LabLockFile(), testLockHolder())
+
+	_, err := c.lockLab(context.Background(), false)
+
+	var lockedErr *LabLockedError
+	if !errors.As(err, &lockedErr) {
+		t.Fatalf("expected a lab locked error, got %v", err)
+	}
+
+	if d := cmp.Diff(testLockHolder(), lockedErr.Holder); d != "" {
+		t.Errorf("lock holder mismatch (-want +got):\n%s", d)
+	}
+
+	want := `lab "locked" is locked by alice (pid 4242 on lab-host) running ` +
+		`"containerlab deploy -t lab.clab.yml" since 2026-10-18 09:30:00; ` +
+		`use --wait to wait for the lock or --force-unlock to remove a stale lock`
+	if err.Error() != want {
+		t.Errorf("unexpected error message:\n got: %s\nwant: %s", err, want)
+	}
+}
+
+func TestLockLabReentrant(t *testing.T) {
+	c := newLockTestLab(t, t.TempDir())
+	path := c.TopoPaths.LabLockFile()
+
+	unlock, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to lock the lab: %v", err)
+	}
+
+	holder := readLabLockHolder(path)
+	if holder == nil || holder.PID != os.Getpid() {
+		t.Fatalf("expected the lock to be held by this process, got %+v", holder)
+	}
+
+	unlockNested, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to lock the lab again: %v", err)
+	}
+
+	unlockNested()
+
+	// the lock is still held by the outer lock
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open lock file: %v", err)
+	}
+	defer f.Close()
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); !errors.Is(err,
+		unix.EWOULDBLOCK) {
+		t.Fatalf("expected the lab to stay locked, got %v", err)
+	}
+
+	unlock()
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		t.Fatalf("expected the lab to be unlocked, got %v", err)
+	}
+
+	if holder := readLabLockHolder(path); holder != nil {
+		t.Errorf("expected the lock holder to be cleared, got %+v", holder)
+	}
+}
+
+func TestLockLabRemovedLockFile(t *testing.T) {
+	c := newLockTestLab(t, t.TempDir())
+	path := c.TopoPaths.LabLockFile()
+
+	unlock, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to lock the lab: %v", err)
+	}
+	defer unlock()
+
+	// the lab directory is removed by the operation holding the lock
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to remove lock file: %v", err)
+	}
+
+	unlockNested, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to lock the lab again: %v", err)
+	}
+	defer unlockNested()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("expected the lock file to be created again: %v", err)
+	}
+	defer f.Close()
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); !errors.Is(err,
+		unix.EWOULDBLOCK) {
+		t.Fatalf("expected the recreated lock file to be locked, got %v", err)
+	}
+}
+
+func TestLockLabForceUnlock(t *testing.T) {
+	c := newLockTestLab(t, t.TempDir())
+	c.forceUnlockLab = true
+	path := c.TopoPaths.LabLockFile()
+
+	held := holdLabLock(t, path, testLockHolder())
+
+	unlock, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to force unlock the lab: %v", err)
+	}
+	defer unlock()
+
+	if lockFileIsCurrent(held, path) {
+		t.Error("expected the lock file of the previous holder to be removed")
+	}
+
+	if holder := readLabLockHolder(path); holder == nil || holder.PID != os.Getpid() {
+		t.Errorf("expected the lock to be held by this process, got %+v", holder)
+	}
+}
+
+func TestLockLabWait(t *testing.T) {
+	c := newLockTestLab(t, t.TempDir())
+	c.waitLabLock = true
+	path := c.TopoPaths.LabLockFile()
+
+	held := holdLabLock(t, path, testLockHolder())
+
+	ctx, cancel := context.WithTimeout(context.Background(), labLockPollInterval)
+	defer cancel()
+
+	if _, err := c.lockLab(ctx, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected waiting for the lock to time out, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(labLockPollInterval)
+		held.Close()
+	}()
+
+	unlock, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to wait for the lab lock: %v", err)
+	}
+
+	unlock()
+}
+
+func TestLockLabWithoutLabDir(t *testing.T) {
+	labDir := filepath.Join(t.TempDir(), "clab-locked")
+	if err := os.Mkdir(labDir, 0o755); err != nil {
+		t.Fatalf("failed to create lab dir: %v", err)
+	}
+
+	c := newLockTestLab(t, labDir)
+
+	if err := os.Remove(labDir); err != nil {
+		t.Fatalf("failed to remove lab dir: %v", err)
+	}
+
+	unlock, err := c.lockLab(context.Background(), false)
+	if err != nil {
+		t.Fatalf("failed to lock the lab: %v", err)
+	}
+
+	unlock()
+
+	if _, err := os.Stat(labDir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the lab directory not to be created, got %v", err)
+	}
+
+	unlock, err = c.LockLab(context.Background())
+	if err != nil {
+		t.Fatalf("failed to lock the lab: %v", err)
+	}
+
+	unlock()
+
+	if _, err := os.Stat(c.TopoPaths.LabLockFile()); err != nil {
+		t.Errorf("expected the lock file to be created: %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+// Package netbox defines the NetBox bulk import data of a lab.
+package netbox
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	StatusActive    = "active"
+	StatusPlanned   = "planned"
+	StatusConnected = "connected"
+
+	// DefaultRole is the device role of the nodes without a group
+	DefaultRole = "lab-node"
+
+	// InterfaceType is the type of the interfaces, the virtual interfaces can not be cabled
+	InterfaceType = "other"
+	// CableTerminationType is the type of the cable terminations
+	CableTerminationType = "dcim.interface"
+)
+
+// Data is the NetBox bulk import data, every model is imported in the order of the fields.
+type Data struct {
+	Sites         []Site         `json:"sites"`
+	Manufacturers []Manufacturer `json:"manufacturers"`
+	DeviceRoles   []DeviceRole   `json:"device_roles"`
+	DeviceTypes   []DeviceType   `json:"device_types"`
+	Devices       []Device       `json:"devices"`
+	Interfaces    []Interface    `json:"interfaces"`
+	Cables        []Cable        `json:"cables"`
+	IPAddresses   []IPAddress    `json:"ip_addresses"`
+}
+
+type Site struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Status      string `json:"status"`
+	Description string `json:"description,omitempty"`
+}
+
+type Manufacturer struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type DeviceRole struct {
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Color string `json:"color"`
+}
+
+type DeviceType struct {
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	Slug         string `json:"slug"`
+}
+
+type Device struct {
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	Manufacturer string `json:"manufacturer"`
+	DeviceType   string `json:"device_type"`
+	Site         string `json:"site"`
+	Status       string `json:"status"`
+}
+
+type Interface struct {
+	Device   string `json:"device"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	MgmtOnly bool   `json:"mgmt_only"`
+}
+
+type Cable struct {
+	SideADevice string `json:"side_a_device"`
+	SideAType   string `json:"side_a_type"`
+	SideAName   string `json:"side_a_name"`
+	SideBDevice string `json:"side_b_device"`
+	SideBType   string `json:"side_b_type"`
+	SideBName   string `json:"side_b_name"`
+	Status      string `json:"status"`
+}
+
+type IPAddress struct {
+	Address   string `json:"address"`
+	Status    string `json:"status"`
+	Device    string `json:"device"`
+	Interface string `json:"interface"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// slugRe matches the characters not allowed in the slugs.
+var slugRe = regexp.MustCompile(`[^a-z0-9_-]+`) //nolint:gochecknoglobals
+
+// Slug returns the NetBox slug of the name.
+func Slug(name string) string {
+	return strings.Trim(slugRe.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// Parse parses the NetBox data in JSON.
+func Parse(data []byte) (*Data, error) {
+	d := &Data{}
+
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// WriteJSON writes the data in JSON.
+func (d *Data) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(d)
+}
+
+// WriteCSV writes every model to the CSV file in the directory named after the model,
+// e.g. device_types.csv. The CSV columns are the NetBox import fields of the model.
+func (d *Data) WriteCSV(dir string) error {
+	v := reflect.ValueOf(d).Elem()
+
+	for i := range v.NumField() {
+		name := jsonName(v.Type().Field(i))
+
+		if err := writeCSVFile(filepath.Join(dir, name+".csv"), v.Field(i)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeCSVFile writes the slice of the model structs with the header of their field names.
+func writeCSVFile(path string, rows reflect.Value) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+
+	typ := rows.Type().Elem()
+
+	header := make([]string, 0, typ.NumField())
+	for i := range typ.NumField() {
+		header = append(header, jsonName(typ.Field(i)))
+	}
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for i := range rows.Len() {
+		row := rows.Index(i)
+		record := make([]string, 0, row.NumField())
+
+		for j := range row.NumField() {
+			switch field := row.Field(j); field.Kind() {
+			case reflect.Bool:
+				record = append(record, strconv.FormatBool(field.Bool()))
+			default:
+				record = append(record, field.String())
+			}
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+	return name
+}
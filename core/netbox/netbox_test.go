@@ -0,0 +1,90 @@
+package netbox
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSlug(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		want string
+	}{
+		"kind":          {name: "nokia_srlinux", want: "nokia_srlinux"},
+		"upper case":    {name: "DCS-7280SR", want: "dcs-7280sr"},
+		"special chars": {name: " Edge Router (1) ", want: "edge-router-1"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Slug(tt.name); got != tt.want {
+				t.Errorf("Slug(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func testData() *Data {
+	return &Data{
+		Devices: []Device{
+			{
+				Name: "srl1", Role: DefaultRole, Manufacturer: "nokia",
+				DeviceType: "nokia_srlinux", Site: "lab", Status: StatusActive,
+			},
+		},
+		Interfaces: []Interface{
+			{Device: "srl1", Name: "mgmt0", Type: InterfaceType, MgmtOnly: true},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := testData().WriteCSV(dir); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+
+	tests := map[string]string{
+		"sites.csv": "name,slug,status,description\n",
+		"devices.csv": "name,role,manufacturer,device_type,site,status\n" +
+			"srl1,lab-node,nokia,nokia_srlinux,lab,active\n",
+		"interfaces.csv": "device,name,type,mgmt_only\nsrl1,mgmt0,other,true\n",
+		"cables.csv": "side_a_device,side_a_type,side_a_name," +
+			"side_b_device,side_b_type,side_b_name,status\n",
+	}
+
+	for file, want := range tests {
+		t.Run(file, func(t *testing.T) {
+			got, err := os.ReadFile(filepath.Join(dir, file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(want, string(got)); diff != "" {
+				t.Errorf("unexpected CSV (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteJSONParse(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := testData().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+
+	got, err := Parse(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if diff := cmp.Diff(testData(), got); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+}
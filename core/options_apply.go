@@ -14,6 +14,8 @@ type ApplyOptions struct {
 	finalizeNoop       bool
 	maxWorkers         uint
 	exportTemplate     string
+	// resume recreates the nodes that failed in the previous deployment
+	resume bool
 }
 
 // NewApplyOptions creates a new ApplyOptions instance.
@@ -185,6 +185,49 @@ func WithTopologyVarsFiles(varsFiles []string) ClabOption {
 	}
 }
 
+// WithTopologyOverrides sets the overrides of the topology fields given as `path=value`
+// expressions and files with the override mappings. The overrides are applied when the
+// topology is loaded, so this option must precede WithTopoPath.
+func WithTopologyOverrides(sets, setFiles []string) ClabOption {
+	return func(c *CLab) error {
+		overrides, err := parseTopologyOverrides(sets, setFiles)
+		if err != nil {
+			return err
+		}
+
+		c.topologyOverrides = overrides
+
+		return nil
+	}
+}
+
+// WithTopologyRevision replaces the topology with the topology recorded in the state history
+// of the lab with the given revision. The revision is loaded with the topology, so this option
+// must precede WithTopoPath.
+func WithTopologyRevision(revision int) ClabOption {
+	return func(c *CLab) error {
+		if revision <= 0 {
+			return fmt.Errorf("invalid revision %d, the revisions are numbered from 1", revision)
+		}
+
+		c.topologyRevision = revision
+
+		return nil
+	}
+}
+
+// WithLabLock defines how the lab lock held by another containerlab process is handled.
+// With wait the lab operations wait for the lock to be released, with forceUnlock the lock
+// is removed and the holder loses it. Without the option the lab operations fail.
+func WithLabLock(wait, forceUnlock bool) ClabOption {
+	return func(c *CLab) error {
+		c.waitLabLock = wait
+		c.forceUnlockLab = forceUnlock
+
+		return nil
+	}
+}
+
 func WithTopoPath(path string, varsFiles []string) ClabOption {
 	return func(c *CLab) error {
 		file, err := c.ProcessTopoPath(path)
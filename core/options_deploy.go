@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/charmbracelet/log"
 	"github.com/tklauser/numcpus"
 )
@@ -16,6 +18,11 @@ type DeployOptions struct {
 	skipLabDirFileACLs   bool     // skip setting the extended File ACL entries on the lab directory.
 	restoreAll           string   // restoreAll specifies a directory to scan for snapshot files.
 	restoreNodeSnapshots []string // restoreNodeSnapshots maps node names to specific snapshot file paths.
+	profileTrace         string   // profileTrace is the path to write the Chrome trace of the deployment to.
+	resume               bool     // resume retries the failed and missing nodes of a failed deployment.
+	atomic               bool     // atomic rolls back the resources created by a failed deployment.
+	// timeout is the time an atomic deployment has to complete within, zero means no deadline.
+	timeout time.Duration
 }
 
 // NewDeployOptions creates a new DeployOptions instance with the specified maxWorkers value.
@@ -127,6 +134,55 @@ func (d *DeployOptions) RestoreNodeSnapshots() []string {
 	return d.restoreNodeSnapshots
 }
 
+// SetProfileTrace sets the profileTrace option and returns the updated DeployOptions instance.
+func (d *DeployOptions) SetProfileTrace(path string) *DeployOptions {
+	d.profileTrace = path
+
+	return d
+}
+
+// ProfileTrace returns the profileTrace option value.
+func (d *DeployOptions) ProfileTrace() string {
+	return d.profileTrace
+}
+
+// SetResume sets the resume option and returns the updated DeployOptions instance.
+func (d *DeployOptions) SetResume(b bool) *DeployOptions {
+	d.resume = b
+
+	return d
+}
+
+// Resume returns the resume option value.
+func (d *DeployOptions) Resume() bool {
+	return d.resume
+}
+
+// SetAtomic sets the atomic option and returns the updated DeployOptions instance.
+func (d *DeployOptions) SetAtomic(b bool) *DeployOptions {
+	d.atomic = b
+
+	return d
+}
+
+// Atomic returns the atomic option value.
+func (d *DeployOptions) Atomic() bool {
+	return d.atomic
+}
+
+// SetTimeout sets the deadline of an atomic deployment and returns the updated
+// DeployOptions instance.
+func (d *DeployOptions) SetTimeout(timeout time.Duration) *DeployOptions {
+	d.timeout = timeout
+
+	return d
+}
+
+// Timeout returns the timeout option value.
+func (d *DeployOptions) Timeout() time.Duration {
+	return d.timeout
+}
+
 // initWorkerCount calculates the number of workers used for node creation.
 // If maxWorkers is provided, it takes precedence.
 // If maxWorkers is not set, the number of workers is limited by the number of available CPUs
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+// parseTopologyOverrides reads the overrides from the override files followed by
+// the `path=value` override expressions, so that the latter take precedence.
+func parseTopologyOverrides(sets, setFiles []string) ([]*clabtypes.TopologyOverride, error) {
+	var overrides []*clabtypes.TopologyOverride
+
+	for _, f := range setFiles {
+		o, err := clabtypes.ReadTopologyOverridesFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, o...)
+	}
+
+	for _, s := range sets {
+		o, err := clabtypes.ParseTopologyOverride(s)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, o)
+	}
+
+	return overrides, nil
+}
+
+// applyTopologyOverrides patches the loaded topology with the overrides provided
+// on the command line. The overrides are applied in order.
+func (c *CLab) applyTopologyOverrides() error {
+	for _, o := range c.topologyOverrides {
+		if err := c.applyTopologyOverride(o); err != nil {
+			return fmt.Errorf("failed to apply override %q: %w", o.Path, err)
+		}
+
+		log.Debug("Applied topology override", "path", o.Path, "value", o.Value)
+	}
+
+	return nil
+}
+
+func (c *CLab) applyTopologyOverride(o *clabtypes.TopologyOverride) error {
+	path, err := clabtypes.SplitOverridePath(o.Path)
+	if err != nil {
+		return err
+	}
+
+	// nodes, kinds, groups and defaults may be prefixed with `topology.` as in the topology file
+	if path[0] == "topology" && len(path) > 1 {
+		path = path[1:]
+	}
+
+	topo := c.Config.Topology
+
+	switch section := path[0]; section {
+	case "nodes":
+		if len(path) < 2 { //nolint: mnd
+			return fmt.Errorf("node name is missing")
+		}
+
+		nodeDef, ok := topo.Nodes[path[1]]
+		if !ok {
+			return fmt.Errorf("node %q is not defined in the topology", path[1])
+		}
+
+		// nodes are replicated before the overrides are applied
+		if len(path) > 2 && path[2] == "count" { //nolint: mnd
+			return fmt.Errorf("count can not be overridden")
+		}
+
+		if nodeDef == nil {
+			nodeDef = &clabtypes.NodeDefinition{}
+			topo.Nodes[path[1]] = nodeDef
+		}
+
+		return clabtypes.PatchYAML(nodeDef, path[2:], o.Value)
+
+	case "kinds", "groups":
+		if len(path) < 2 { //nolint: mnd
+			return fmt.Errorf("%s name is missing", section[:len(section)-1])
+		}
+
+		defs := topo.Kinds
+		if section == "groups" {
+			defs = topo.Groups
+		}
+
+		if defs == nil {
+			defs = map[string]*clabtypes.NodeDefinition{}
+		}
+
+		// kinds and groups do not have to be present in the topology to be overridden
+		def := defs[path[1]]
+		if def == nil {
+			def = &clabtypes.NodeDefinition{}
+			defs[path[1]] = def
+		}
+
+		if section == "groups" {
+			topo.Groups = defs
+		} else {
+			topo.Kinds = defs
+		}
+
+		return clabtypes.PatchYAML(def, path[2:], o.Value)
+
+	case "defaults":
+		if topo.Defaults == nil {
+			topo.Defaults = &clabtypes.NodeDefinition{}
+		}
+
+		return clabtypes.PatchYAML(topo.Defaults, path[1:], o.Value)
+
+	case "mgmt":
+		if c.Config.Mgmt == nil {
+			c.Config.Mgmt = &clabtypes.MgmtNet{}
+		}
+
+		return clabtypes.PatchYAML(c.Config.Mgmt, path[1:], o.Value)
+
+	case "settings":
+		if c.Config.Settings == nil {
+			c.Config.Settings = &clabtypes.Settings{}
+		}
+
+		return clabtypes.PatchYAML(c.Config.Settings, path[1:], o.Value)
+
+	default:
+		return fmt.Errorf(
+			"unsupported override path, must start with one of nodes, kinds, groups, defaults, mgmt, settings",
+		)
+	}
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestTopologyOverrides(t *testing.T) {
+	c, err := NewContainerLab(
+		WithTopologyOverrides(
+			[]string{
+				"nodes.node1.image=ghcr.io/nokia/srlinux:24.10",
+				"nodes.node2.env.FOO=bar",
+				"kinds.nokia_srlinux.type=ixr-d3",
+				"topology.defaults.labels.matrix=yes",
+				"mgmt.ipv4-subnet=172.100.100.0/24",
+			},
+			nil,
+		),
+		WithTopoPath("test_data/topo1.yml", nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node1 := c.Nodes["node1"].Config()
+	if node1.Image != "ghcr.io/nokia/srlinux:24.10" {
+		t.Errorf("node1 image = %q, want overridden image", node1.Image)
+	}
+
+	if node1.NodeType != "ixr-d3" {
+		t.Errorf("node1 type = %q, want type from the overridden kind", node1.NodeType)
+	}
+
+	node2 := c.Nodes["node2"].Config()
+	if node2.Env["FOO"] != "bar" {
+		t.Errorf("node2 env FOO = %q, want bar", node2.Env["FOO"])
+	}
+
+	if node2.Labels["matrix"] != "yes" {
+		t.Errorf("node2 label matrix = %q, want yes", node2.Labels["matrix"])
+	}
+
+	if c.Config.Mgmt.IPv4Subnet != "172.100.100.0/24" {
+		t.Errorf("mgmt ipv4-subnet = %q, want overridden subnet", c.Config.Mgmt.IPv4Subnet)
+	}
+
+	if len(c.topologyOverrides) != 5 {
+		t.Errorf("got %d recorded overrides, want 5", len(c.topologyOverrides))
+	}
+}
+
+func TestTopologyOverridesErrors(t *testing.T) {
+	tests := map[string]string{
+		"undefined node":   "nodes.node3.image=alpine",
+		"unknown field":    "nodes.node1.imgae=alpine",
+		"unknown section":  "links.0.mtu=1500",
+		"count on a node":  "nodes.node1.count=2",
+		"missing kind key": "kinds=x",
+	}
+
+	for name, set := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewContainerLab(
+				WithTopologyOverrides([]string{set}, nil),
+				WithTopoPath("test_data/topo1.yml", nil),
+			)
+			if err == nil {
+				t.Fatalf("expected an error for override %q", set)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcoredependency_manager "github.com/srl-labs/containerlab/core/dependency_manager"
+	clabtypes "github.com/srl-labs/containerlab/types"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+// Deployment profile phases. The node work phases are named after the stage they complete,
+// the wait phases are the time a node waits for its dependencies to enter a stage.
+const (
+	profilePhaseMgmtNetwork     = "mgmt-network"
+	profilePhaseChecks          = "checks"
+	profilePhaseArtifacts       = "artifacts"
+	profilePhaseNodes           = "nodes"
+	profilePhaseLinksPostDeploy = "links-post-deploy"
+	profilePhaseFinalize        = "finalize"
+
+	profilePhaseImagePull    = "image-pull"
+	profilePhaseQueued       = "queued"
+	profilePhaseStartupDelay = "startup-delay"
+	profilePhaseExec         = "exec"
+	profilePhaseWaitPrefix   = "wait-"
+)
+
+// ProfileSpan is a timed phase of the lab deployment.
+type ProfileSpan struct {
+	// Node is empty for the lab-wide phases.
+	Node            string    `json:"node,omitempty"`
+	Phase           string    `json:"phase"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationSeconds float64   `json:"duration-seconds"`
+	// Critical marks the spans on the critical path of the deployment.
+	Critical bool `json:"critical,omitempty"`
+	// Failed marks the spans of the phases the node failed in.
+	Failed bool `json:"failed,omitempty"`
+}
+
+func (s *ProfileSpan) duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// DeployProfile is the timeline of a lab deployment.
+type DeployProfile struct {
+	Lab             string         `json:"lab"`
+	Start           time.Time      `json:"start"`
+	End             time.Time      `json:"end"`
+	DurationSeconds float64        `json:"duration-seconds"`
+	Spans           []*ProfileSpan `json:"spans"`
+	// CriticalPath is the chain of node phases and wait-for dependencies
+	// that determined when the last node finished its deployment.
+	CriticalPath []*ProfileSpan `json:"critical-path"`
+}
+
+// deployProfiler records the phases of a lab deployment.
+// A nil profiler records nothing, so that the deployment code can be shared with
+// the operations that are not profiled.
+type deployProfiler struct {
+	m     sync.Mutex
+	spans []*ProfileSpan
+}
+
+func newDeployProfiler() *deployProfiler {
+	return &deployProfiler{}
+}
+
+// start opens a span of the phase for the node.
+func (p *deployProfiler) start(node, phase string) {
+	if p == nil {
+		return
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.spans = append(p.spans, &ProfileSpan{
+		Node:  node,
+		Phase: phase,
+		Start: time.Now(),
+	})
+}
+
+// stop closes the last open span of the phase for the node.
+func (p *deployProfiler) stop(node, phase string) {
+	if p == nil {
+		return
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	for i := len(p.spans) - 1; i >= 0; i-- {
+		s := p.spans[i]
+		if s.Node == node && s.Phase == phase && s.End.IsZero() {
+			s.End = time.Now()
+			s.DurationSeconds = s.duration().Seconds()
+
+			return
+		}
+	}
+}
+
+// fail closes the open spans of the node marking them failed.
+func (p *deployProfiler) fail(node string) {
+	if p == nil {
+		return
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	for _, s := range p.spans {
+		if s.Node == node && s.End.IsZero() {
+			s.End = time.Now()
+			s.DurationSeconds = s.duration().Seconds()
+			s.Failed = true
+		}
+	}
+}
+
+// begin opens a span of the phase for the node and returns the function closing it.
+func (p *deployProfiler) begin(node, phase string) func() {
+	p.start(node, phase)
+
+	return func() { p.stop(node, phase) }
+}
+
+// profile returns the deployment profile with the critical path computed
+// from the given dependencies. Spans that were not closed are omitted.
+func (p *deployProfiler) profile(
+	lab string,
+	deps []*clabcoredependency_manager.Dependency,
+) *DeployProfile {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	prof := &DeployProfile{
+		Lab:          lab,
+		Spans:        []*ProfileSpan{},
+		CriticalPath: []*ProfileSpan{},
+	}
+
+	for _, s := range p.spans {
+		if s.End.IsZero() {
+			continue
+		}
+
+		if prof.Start.IsZero() || s.Start.Before(prof.Start) {
+			prof.Start = s.Start
+		}
+
+		if s.End.After(prof.End) {
+			prof.End = s.End
+		}
+
+		prof.Spans = append(prof.Spans, s)
+	}
+
+	slices.SortStableFunc(prof.Spans, func(a, b *ProfileSpan) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	prof.DurationSeconds = prof.End.Sub(prof.Start).Seconds()
+	prof.CriticalPath = criticalPath(prof.Spans, deps)
+
+	for _, s := range prof.CriticalPath {
+		s.Critical = true
+	}
+
+	return prof
+}
+
+// criticalPath walks back from the node that finished last. Whenever a node waited
+// for its dependencies to enter a stage, the walk continues with the dependee that
+// reached its stage last, as it is the one that held the node back.
+// The spans are sorted by time.
+func criticalPath(
+	spans []*ProfileSpan,
+	deps []*clabcoredependency_manager.Dependency,
+) []*ProfileSpan {
+	nodeSpans := map[string][]*ProfileSpan{}
+	// time a node completed a stage
+	stageDone := map[string]map[string]time.Time{}
+
+	var last *ProfileSpan
+
+	for _, s := range spans {
+		if s.Node == "" {
+			continue
+		}
+
+		nodeSpans[s.Node] = append(nodeSpans[s.Node], s)
+
+		if stageDone[s.Node] == nil {
+			stageDone[s.Node] = map[string]time.Time{}
+		}
+
+		stageDone[s.Node][s.Phase] = s.End
+
+		if last == nil || s.End.After(last.End) {
+			last = s
+		}
+	}
+
+	if last == nil {
+		return []*ProfileSpan{}
+	}
+
+	var path []*ProfileSpan
+
+	node, bound := last.Node, last.End
+
+	for range len(spans) {
+		next := ""
+
+		candidates := nodeSpans[node]
+		for i := len(candidates) - 1; i >= 0; i-- {
+			s := candidates[i]
+			if s.End.After(bound) {
+				continue
+			}
+
+			path = append(path, s)
+
+			stage, isWait := cutWaitPhase(s.Phase)
+			if !isWait || s.duration() <= 0 {
+				continue
+			}
+
+			// the dependee that reached its stage last while the node was waiting
+			var doneAt time.Time
+
+			for _, d := range deps {
+				if d.Depender != node || string(d.DependerStage) != stage {
+					continue
+				}
+
+				t, ok := stageDone[d.Dependee][string(d.DependeeStage)]
+				if !ok || t.After(s.End) || !t.After(s.Start) || t.Before(doneAt) {
+					continue
+				}
+
+				next, doneAt = d.Dependee, t
+			}
+
+			if next != "" {
+				bound = doneAt
+				break
+			}
+		}
+
+		if next == "" {
+			break
+		}
+
+		node = next
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+func cutWaitPhase(phase string) (string, bool) {
+	return strings.CutPrefix(phase, profilePhaseWaitPrefix)
+}
+
+// waitPhase returns the profile phase of waiting for the dependencies of a stage.
+func waitPhase(stage clabtypes.WaitForStage) string {
+	return profilePhaseWaitPrefix + string(stage)
+}
+
+// chromeTraceEvent is an event of the Chrome trace event format understood
+// by chrome://tracing and Perfetto.
+type chromeTraceEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat,omitempty"`
+	Ph   string         `json:"ph"`
+	Ts   int64          `json:"ts"`
+	Dur  int64          `json:"dur,omitempty"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// ChromeTrace returns the profile in the Chrome trace event format.
+// Every node is rendered as a thread, the lab-wide phases are rendered on the `lab` thread.
+func (p *DeployProfile) ChromeTrace() ([]byte, error) {
+	nodes := []string{}
+
+	for _, s := range p.Spans {
+		if s.Node != "" && !slices.Contains(nodes, s.Node) {
+			nodes = append(nodes, s.Node)
+		}
+	}
+
+	slices.SortFunc(nodes, clabutils.NaturalCompare)
+
+	events := []*chromeTraceEvent{
+		{Name: "process_name", Ph: "M", Pid: 1, Args: map[string]any{"name": p.Lab}},
+		{Name: "thread_name", Ph: "M", Pid: 1, Tid: 0, Args: map[string]any{"name": "lab"}},
+	}
+
+	for i, n := range nodes {
+		events = append(events, &chromeTraceEvent{
+			Name: "thread_name", Ph: "M", Pid: 1, Tid: i + 1,
+			Args: map[string]any{"name": n},
+		})
+	}
+
+	for _, s := range p.Spans {
+		e := &chromeTraceEvent{
+			Name: s.Phase,
+			Cat:  "deploy",
+			Ph:   "X",
+			Ts:   s.Start.Sub(p.Start).Microseconds(),
+			Dur:  s.duration().Microseconds(),
+			Pid:  1,
+		}
+
+		if s.Node != "" {
+			e.Tid = slices.Index(nodes, s.Node) + 1
+		}
+
+		if s.Critical {
+			e.Cat = "deploy,critical-path"
+			e.Args = map[string]any{"critical": true}
+		}
+
+		if s.Failed {
+			if e.Args == nil {
+				e.Args = map[string]any{}
+			}
+
+			e.Args["failed"] = true
+		}
+
+		events = append(events, e)
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	}, "", "  ")
+}
+
+// writeDeployProfile writes the deployment profile to the lab directory and,
+// when traceFile is set, the Chrome trace of the deployment to traceFile.
+func (c *CLab) writeDeployProfile(traceFile string) {
+	if c.profiler == nil {
+		return
+	}
+
+	prof := c.profiler.profile(c.Config.Name, c.dependencyManager.GetDependencies())
+
+	b, err := json.MarshalIndent(prof, "", "  ")
+	if err == nil {
+		err = os.WriteFile(c.TopoPaths.DeployProfileFile(), b, clabconstants.PermissionsFileDefault)
+	}
+
+	if err != nil {
+		log.Warnf("failed to write deployment profile: %v", err)
+	}
+
+	if traceFile == "" {
+		return
+	}
+
+	b, err = prof.ChromeTrace()
+	if err == nil {
+		err = os.WriteFile(traceFile, b, clabconstants.PermissionsFileDefault)
+	}
+
+	if err != nil {
+		log.Warnf("failed to write deployment trace: %v", err)
+
+		return
+	}
+
+	log.Info("Deployment trace saved", "path", traceFile)
+}
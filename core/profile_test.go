@@ -0,0 +1,145 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	clabcoredependency_manager "github.com/srl-labs/containerlab/core/dependency_manager"
+	clabtypes "github.com/srl-labs/containerlab/types"
+)
+
+func TestDeployProfileCriticalPath(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	span := func(node, phase string, start, end int) *ProfileSpan {
+		return &ProfileSpan{
+			Node:  node,
+			Phase: phase,
+			Start: base.Add(time.Duration(start) * time.Second),
+			End:   base.Add(time.Duration(end) * time.Second),
+		}
+	}
+
+	p := newDeployProfiler()
+	p.spans = []*ProfileSpan{
+		span("", profilePhaseNodes, 0, 12),
+		span("n1", "create", 0, 5),
+		span("n1", "create-links", 5, 6),
+		span("n1", "configure", 6, 7),
+		span("n2", "wait-create", 0, 5),
+		span("n2", "create", 5, 8),
+		span("n2", "create-links", 8, 9),
+		span("n2", "configure", 9, 12),
+		span("n3", "create", 0, 2),
+		span("n3", "configure", 2, 3),
+		// spans that were not closed are omitted
+		{Node: "n3", Phase: profilePhaseExec, Start: base.Add(3 * time.Second)},
+	}
+
+	deps := []*clabcoredependency_manager.Dependency{
+		{
+			Dependee:      "n1",
+			DependeeStage: clabtypes.WaitForCreate,
+			Depender:      "n2",
+			DependerStage: clabtypes.WaitForCreate,
+		},
+		{
+			Dependee:      "n3",
+			DependeeStage: clabtypes.WaitForCreate,
+			Depender:      "n2",
+			DependerStage: clabtypes.WaitForCreate,
+		},
+	}
+
+	prof := p.profile("test", deps)
+
+	if len(prof.Spans) != 10 {
+		t.Errorf("expected 10 spans, got %d", len(prof.Spans))
+	}
+
+	if prof.DurationSeconds != 12 {
+		t.Errorf("expected a 12s deployment, got %vs", prof.DurationSeconds)
+	}
+
+	var got []string
+	for _, s := range prof.CriticalPath {
+		got = append(got, s.Node+"/"+s.Phase)
+	}
+
+	want := []string{
+		"n1/create",
+		"n2/wait-create",
+		"n2/create",
+		"n2/create-links",
+		"n2/configure",
+	}
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("critical path mismatch (-want +got):\n%s", d)
+	}
+
+	b, err := prof.ChromeTrace()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var trace struct {
+		TraceEvents []*chromeTraceEvent `json:"traceEvents"`
+	}
+
+	if err := json.Unmarshal(b, &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	threads := map[int]string{}
+	critical := 0
+
+	for _, e := range trace.TraceEvents {
+		switch {
+		case e.Name == "thread_name":
+			threads[e.Tid] = e.Args["name"].(string)
+		case e.Cat == "deploy,critical-path":
+			critical++
+		}
+	}
+
+	wantThreads := map[int]string{0: "lab", 1: "n1", 2: "n2", 3: "n3"}
+	if d := cmp.Diff(wantThreads, threads); d != "" {
+		t.Errorf("trace threads mismatch (-want +got):\n%s", d)
+	}
+
+	if critical != len(want) {
+		t.Errorf("expected %d critical path events, got %d", len(want), critical)
+	}
+}
+
+func TestDeployProfilerFail(t *testing.T) {
+	p := newDeployProfiler()
+
+	p.start("n1", profilePhaseQueued)
+	p.stop("n1", profilePhaseQueued)
+	p.start("n1", "create")
+	p.start("n2", "create")
+
+	p.fail("n1")
+
+	prof := p.profile("test", nil)
+
+	got := map[string]bool{}
+	for _, s := range prof.Spans {
+		got[s.Node+"/"+s.Phase] = s.Failed
+	}
+
+	// the open span of the failing node is closed as failed,
+	// the span of the other node stays open and is omitted
+	want := map[string]bool{
+		"n1/" + profilePhaseQueued: false,
+		"n1/create":                true,
+	}
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("profile spans mismatch (-want +got):\n%s", d)
+	}
+}
@@ -6,6 +6,12 @@ import (
 
 // RestartNodes performs stop+start for each node, restoring parked interfaces.
 func (c *CLab) RestartNodes(ctx context.Context, nodeNames []string) error {
+	unlock, err := c.lockLab(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if err := c.ResolveLinks(); err != nil {
 		return err
 	}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+)
+
+// nodeIncompleteReason is the failure reason of the nodes whose deployment
+// was stopped by the failure of another node.
+const nodeIncompleteReason = "deployment did not complete"
+
+// nodeOutcomes tracks which nodes were deployed and why the others failed,
+// the failures are saved to the state file to resume the deployment.
+type nodeOutcomes struct {
+	m        sync.Mutex
+	deployed map[string]struct{}
+	failures map[string]string
+}
+
+// recordNodeDeployed records that the node went through all its deployment stages.
+func (c *CLab) recordNodeDeployed(nodeName string) {
+	c.nodeOutcomes.m.Lock()
+	defer c.nodeOutcomes.m.Unlock()
+
+	if c.nodeOutcomes.deployed == nil {
+		c.nodeOutcomes.deployed = map[string]struct{}{}
+	}
+
+	c.nodeOutcomes.deployed[nodeName] = struct{}{}
+}
+
+// recordNodeFailure records the reason of the node deployment failure,
+// the first failure of a node is kept.
+func (c *CLab) recordNodeFailure(nodeName string, err error) {
+	c.nodeOutcomes.m.Lock()
+	defer c.nodeOutcomes.m.Unlock()
+
+	if c.nodeOutcomes.failures == nil {
+		c.nodeOutcomes.failures = map[string]string{}
+	}
+
+	if _, exists := c.nodeOutcomes.failures[nodeName]; !exists {
+		c.nodeOutcomes.failures[nodeName] = err.Error()
+	}
+}
+
+// recordIncompleteNodes records the nodes of the lab that neither were deployed nor failed,
+// e.g. because the scheduling was aborted by the failure of another node.
+func (c *CLab) recordIncompleteNodes() {
+	c.nodeOutcomes.m.Lock()
+	defer c.nodeOutcomes.m.Unlock()
+
+	if c.nodeOutcomes.failures == nil {
+		c.nodeOutcomes.failures = map[string]string{}
+	}
+
+	for nodeName := range c.Nodes {
+		if _, deployed := c.nodeOutcomes.deployed[nodeName]; deployed {
+			continue
+		}
+
+		if _, failed := c.nodeOutcomes.failures[nodeName]; !failed {
+			c.nodeOutcomes.failures[nodeName] = nodeIncompleteReason
+		}
+	}
+}
+
+// nodeFailures returns a copy of the recorded node failure reasons.
+func (c *CLab) nodeFailures() map[string]string {
+	c.nodeOutcomes.m.Lock()
+	defer c.nodeOutcomes.m.Unlock()
+
+	if len(c.nodeOutcomes.failures) == 0 {
+		return nil
+	}
+
+	failures := make(map[string]string, len(c.nodeOutcomes.failures))
+	for k, v := range c.nodeOutcomes.failures {
+		failures[k] = v
+	}
+
+	return failures
+}
+
+// writeFailedState saves the recorded node failures to the state file, so that
+// the deployment can be resumed with deploy --resume.
+// The state is the lab state the failed operation started from, nil for a fresh deployment
+// in which case the state of the current topology is saved.
+func (c *CLab) writeFailedState(state *LabState) {
+	failures := c.nodeFailures()
+	if len(failures) == 0 {
+		return
+	}
+
+	if state == nil {
+		state = c.labState()
+	}
+
+	state.NodeFailures = failures
+
+	if err := c.writeStateFile(state); err != nil {
+		log.Warnf("failed to write state file: %v", err)
+
+		return
+	}
+
+	log.Warn("Deployment failed, run deploy --resume to retry the failed nodes",
+		"failed-nodes", len(failures))
+}
+
+// planResumedNodes plans the recreation of the nodes that failed in the previous deployment
+// and still have a container. The failed nodes without a container are deployed as added nodes.
+func (c *CLab) planResumedNodes(plan *applyPlan) {
+	if plan.state == nil {
+		return
+	}
+
+	nodeNames := make([]string, 0, len(plan.state.NodeFailures))
+	for nodeName := range plan.state.NodeFailures {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		if _, exists := c.Nodes[nodeName]; !exists {
+			continue
+		}
+		if _, exists := plan.currentNodes[nodeName]; !exists {
+			continue
+		}
+		if plan.isNonContainerNode(nodeName) {
+			continue
+		}
+
+		reason := plan.state.NodeFailures[nodeName]
+
+		plan.recreatedNodeSet[nodeName] = struct{}{}
+		delete(plan.restartNodeSet, nodeName)
+		plan.nodeChangeReasons[nodeName] = "failed deployment: " + reason
+
+		log.Info("Node will be recreated to resume the failed deployment",
+			"node", nodeName, "reason", reason)
+	}
+}
+
+// warnFailedDeployment warns when the state file records a failed deployment,
+// as reconciling the lab without resume keeps the containers of the failed nodes.
+func (c *CLab) warnFailedDeployment() {
+	state, err := c.LoadState()
+	if err != nil || state == nil || len(state.NodeFailures) == 0 {
+		return
+	}
+
+	nodeNames := make([]string, 0, len(state.NodeFailures))
+	for nodeName := range state.NodeFailures {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	log.Warn("The previous deployment of the lab failed, use deploy --resume to retry the failed nodes",
+		"failed-nodes", strings.Join(nodeNames, ","))
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clabnodes "github.com/srl-labs/containerlab/nodes"
+)
+
+func TestRecordNodeOutcomes(t *testing.T) {
+	c := &CLab{
+		Nodes: map[string]clabnodes.Node{
+			"deployed":   nil,
+			"failed":     nil,
+			"incomplete": nil,
+		},
+	}
+
+	c.recordNodeDeployed("deployed")
+	c.recordNodeFailure("failed", errors.New("image pull failed"))
+	// only the first failure of a node is kept
+	c.recordNodeFailure("failed", errors.New("deployment did not complete"))
+	c.recordIncompleteNodes()
+
+	want := map[string]string{
+		"failed":     "image pull failed",
+		"incomplete": nodeIncompleteReason,
+	}
+	if d := cmp.Diff(want, c.nodeFailures()); d != "" {
+		t.Errorf("node failures mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestPlanResumedNodes(t *testing.T) {
+	tests := map[string]struct {
+		failures      map[string]string
+		want          []string
+		wantRestarted []string
+	}{
+		"failed nodes with containers are recreated": {
+			failures: map[string]string{
+				"n1": "image pull failed",
+				"n2": nodeIncompleteReason,
+			},
+			want:          []string{"n1", "n2"},
+			wantRestarted: []string{"n3"},
+		},
+		"failed nodes without containers are skipped": {
+			failures:      map[string]string{"missing": "image pull failed"},
+			want:          []string{},
+			wantRestarted: []string{"n2", "n3"},
+		},
+		"failed nodes removed from the topology are skipped": {
+			failures:      map[string]string{"removed": "image pull failed"},
+			want:          []string{},
+			wantRestarted: []string{"n2", "n3"},
+		},
+		"non container nodes are skipped": {
+			failures:      map[string]string{"ext": "image pull failed"},
+			want:          []string{},
+			wantRestarted: []string{"n2", "n3"},
+		},
+		"no failures": {
+			want:          []string{},
+			wantRestarted: []string{"n2", "n3"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &CLab{
+				Nodes: map[string]clabnodes.Node{
+					"n1":      nil,
+					"n2":      nil,
+					"n3":      nil,
+					"ext":     nil,
+					"missing": nil,
+				},
+			}
+
+			plan := newApplyPlan(map[string]*runtimeNodeGroup{
+				"n1":      {},
+				"n2":      {},
+				"n3":      {},
+				"ext":     {external: true},
+				"removed": {},
+			}, &LabState{NodeFailures: tt.failures})
+			plan.restartNodeSet = map[string]struct{}{"n2": {}, "n3": {}}
+
+			c.planResumedNodes(plan)
+
+			if d := cmp.Diff(tt.want, sortedStringSet(plan.recreatedNodeSet)); d != "" {
+				t.Errorf("recreated nodes mismatch (-want +got):\n%s", d)
+			}
+
+			if d := cmp.Diff(tt.wantRestarted, sortedStringSet(plan.restartNodeSet)); d != "" {
+				t.Errorf("restarted nodes mismatch (-want +got):\n%s", d)
+			}
+
+			for _, nodeName := range tt.want {
+				want := "failed deployment: " + tt.failures[nodeName]
+				if got := plan.nodeChangeReasons[nodeName]; got != want {
+					t.Errorf("node %s change reason = %q, want %q", nodeName, got, want)
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	clabconstants "github.com/srl-labs/containerlab/constants"
+	clabcorebus "github.com/srl-labs/containerlab/core/bus"
 	clablinks "github.com/srl-labs/containerlab/links"
 	clabnodes "github.com/srl-labs/containerlab/nodes"
 	clabutils "github.com/srl-labs/containerlab/utils"
@@ -25,7 +26,13 @@ func (c *CLab) Save(
 		opt(opts)
 	}
 
-	err := clablinks.SetMgmtNetUnderlyingBridge(c.Config.Mgmt.Bridge)
+	unlock, err := c.lockLab(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = clablinks.SetMgmtNetUnderlyingBridge(c.Config.Mgmt.Bridge)
 	if err != nil {
 		return err
 	}
@@ -49,15 +56,25 @@ func (c *CLab) Save(
 			result, err := node.SaveConfig(ctx)
 			if err != nil {
 				log.Errorf("node %q save failed: %v", node.GetShortName(), err)
+				c.publishNodeEvent(node, clabcorebus.ActionSaveFail,
+					map[string]string{"error": err.Error()})
+
 				return
 			}
 
-			if opts.copyDst == "" {
-				return
+			if opts.copyDst != "" {
+				if err := c.copySavedConfig(ctx, result, node, opts.copyDst); err != nil {
+					log.Errorf("node %q save copy failed: %v", node.GetShortName(), err)
+					c.publishNodeEvent(node, clabcorebus.ActionSaveFail,
+						map[string]string{"error": err.Error()})
+
+					return
+				}
 			}
 
-			if err := c.copySavedConfig(ctx, result, node, opts.copyDst); err != nil {
-				log.Errorf("node %q save copy failed: %v", node.GetShortName(), err)
+			// the kinds not supporting the save operation return no result
+			if result != nil {
+				c.publishNodeEvent(node, clabcorebus.ActionSaveDone, nil)
 			}
 		}(node)
 	}
@@ -7,6 +7,12 @@ import (
 // StartNodes starts one or more stopped nodes and restores their parked interfaces back into the
 // container network namespace.
 func (c *CLab) StartNodes(ctx context.Context, nodeNames []string) error {
+	unlock, err := c.lockLab(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if err := c.ResolveLinks(); err != nil {
 		return err
 	}
@@ -12,14 +12,31 @@ import (
 
 type LabState struct {
 	Topology *clabtypes.Topology `yaml:"topology"`
+	// addresses allocated by the topology IPAM, reused on subsequent deployments
+	IPAM *clabtypes.IPAMAllocations `yaml:"ipam,omitempty"`
+	// overrides of the topology fields the lab was deployed with
+	Overrides []*clabtypes.TopologyOverride `yaml:"overrides,omitempty"`
+	// NodeFailures are the reasons of the node failures of a failed deployment
+	// indexed by the node name, the failed nodes are retried by deploy --resume
+	NodeFailures map[string]string `yaml:"node-failures,omitempty"`
 }
 
 // WriteState saves the topology to the state file.
 func (c *CLab) WriteState() error {
-	state := &LabState{
-		Topology: c.Config.Topology,
+	return c.writeStateFile(c.labState())
+}
+
+// labState returns the state of the lab deployed with the current topology.
+func (c *CLab) labState() *LabState {
+	return &LabState{
+		Topology:  c.Config.Topology,
+		IPAM:      c.ipamAllocations,
+		Overrides: c.topologyOverrides,
 	}
+}
 
+// writeStateFile saves the state to the state file.
+func (c *CLab) writeStateFile(state *LabState) error {
 	data, err := yaml.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("failed to marshal state: %w", err)
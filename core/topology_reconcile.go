@@ -105,15 +105,30 @@ func (p *applyPlan) isNonContainerNode(nodeName string) bool {
 	return p.isExternallyManaged(nodeName) || p.isRootNamespaceNode(nodeName)
 }
 
+// planApply plans the changes reconciling the runtime lab with the topology.
+// With resume the nodes that failed in the previous deployment are recreated.
 func (c *CLab) planApply(
 	ctx context.Context,
 	currentNodes map[string]*runtimeNodeGroup,
+	resume bool,
 ) (*applyPlan, error) {
 	state, err := c.LoadState()
 	if err != nil {
 		log.Warn("Failed to load state file", "error", err)
 	}
 
+	return c.planApplyWithState(ctx, currentNodes, state, resume)
+}
+
+// planApplyWithState plans the changes reconciling the runtime lab with the topology,
+// the node configuration changes are computed against the given lab state.
+// Without the state the node configuration changes are not planned.
+func (c *CLab) planApplyWithState(
+	ctx context.Context,
+	currentNodes map[string]*runtimeNodeGroup,
+	state *LabState,
+	resume bool,
+) (*applyPlan, error) {
 	plan := newApplyPlan(currentNodes, state)
 
 	for _, nodeName := range sortedNodeNames(c.Nodes) {
@@ -141,6 +156,10 @@ func (c *CLab) planApply(
 		return nil, err
 	}
 
+	if resume {
+		c.planResumedNodes(plan)
+	}
+
 	c.planParkedNodes(ctx, plan)
 	c.planStoppedNodes(ctx, plan)
 
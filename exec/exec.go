@@ -208,6 +208,14 @@ func (ec *ExecCollection) AddAll(cId string, e []*ExecResult) {
 	}
 }
 
+// Results returns the execution results stored for the container.
+func (ec *ExecCollection) Results(cId string) []*ExecResult {
+	ec.m.RLock()
+	defer ec.m.RUnlock()
+
+	return append([]*ExecResult(nil), ec.execEntries[cId]...)
+}
+
 // Dump dumps the contents of ExecCollection as a string in one of the provided formats.
 func (ec *ExecCollection) Dump(format string) (string, error) {
 	ec.m.RLock()
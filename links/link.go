@@ -266,16 +266,12 @@ func (ld *LinkDefinition) UnmarshalYAML( //nolint: funlen
 			return err
 		}
 
-		ld.Type = string(LinkTypeBrief)
-
-		ld.Link, err = l.LinkBriefRaw.ToTypeSpecificRawLink()
+		briefLd, err := NewLinkDefinitionFromBrief(&l.LinkBriefRaw)
 		if err != nil {
 			return err
 		}
 
-		if veth, ok := ld.Link.(*LinkVEthRaw); ok {
-			veth.fromBrief = true
-		}
+		*ld = *briefLd
 
 	default:
 		return fmt.Errorf("unknown link type %q", lt)
@@ -284,6 +280,24 @@ func (ld *LinkDefinition) UnmarshalYAML( //nolint: funlen
 	return nil
 }
 
+// NewLinkDefinitionFromBrief creates a link definition out of the link in the brief format
+// the same way it is done when the brief link is read from the topology file.
+func NewLinkDefinitionFromBrief(lb *LinkBriefRaw) (*LinkDefinition, error) {
+	l, err := lb.ToTypeSpecificRawLink()
+	if err != nil {
+		return nil, err
+	}
+
+	if veth, ok := l.(*LinkVEthRaw); ok {
+		veth.fromBrief = true
+	}
+
+	return &LinkDefinition{
+		Type: string(LinkTypeBrief),
+		Link: l,
+	}, nil
+}
+
 // MarshalYAML serializes LinkDefinition (e.g when used with generate command).
 // As of now it falls back to converting the LinkConfig into a
 // RawVEthLink, such that the generated LinkConfigs adhere to the new LinkDefinition
@@ -362,6 +376,32 @@ func (r *LinkDefinition) MarshalYAML() (any, error) {
 	return nil, fmt.Errorf("unable to marshall")
 }
 
+// RawEndpoints returns the raw endpoints of the link definition that belong to the topology nodes.
+func (ld *LinkDefinition) RawEndpoints() []*EndpointRaw {
+	if ld == nil {
+		return nil
+	}
+
+	switch l := ld.Link.(type) {
+	case *LinkVEthRaw:
+		return l.Endpoints
+	case *LinkVEthStitchedRaw:
+		return l.Endpoints
+	case *LinkHostRaw:
+		return []*EndpointRaw{l.Endpoint}
+	case *LinkMacVlanRaw:
+		return []*EndpointRaw{l.Endpoint}
+	case *LinkMgmtNetRaw:
+		return []*EndpointRaw{l.Endpoint}
+	case *LinkDummyRaw:
+		return []*EndpointRaw{l.Endpoint}
+	case *LinkVxlanRaw:
+		return []*EndpointRaw{&l.Endpoint}
+	}
+
+	return nil
+}
+
 // RawLink is an interface that all raw link types must implement.
 // Raw link types define the links as they are defined in the topology file
 // and solely a product of unmarshaling.
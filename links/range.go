@@ -0,0 +1,137 @@
+package links
+
+import (
+	"fmt"
+	"maps"
+
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+// ExpandLinkRanges expands veth link definitions which use range expressions
+// in the endpoint node or interface names (e.g. `leaf[1-4]:e1-49` - `spine1:e1-[1-4]`)
+// into the individual link definitions.
+// All range expressions used in a single link must produce the same number of items,
+// they are expanded in lockstep.
+func ExpandLinkRanges(linkDefs []*LinkDefinition) ([]*LinkDefinition, error) {
+	var expanded []*LinkDefinition
+
+	for i, linkDef := range linkDefs {
+		veth, ok := linkDefinitionVeth(linkDef)
+		if !ok || !vethHasRanges(veth) {
+			expanded = append(expanded, linkDef)
+			continue
+		}
+
+		links, err := expandVethRanges(veth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand link %d: %w", i, err)
+		}
+
+		for _, l := range links {
+			expanded = append(expanded, &LinkDefinition{
+				Type: linkDef.Type,
+				Link: l,
+			})
+		}
+	}
+
+	return expanded, nil
+}
+
+func linkDefinitionVeth(linkDef *LinkDefinition) (*LinkVEthRaw, bool) {
+	if linkDef == nil {
+		return nil, false
+	}
+
+	veth, ok := linkDef.Link.(*LinkVEthRaw)
+
+	return veth, ok
+}
+
+func vethHasRanges(veth *LinkVEthRaw) bool {
+	for _, ep := range veth.Endpoints {
+		if clabutils.HasNameRange(ep.Node) || clabutils.HasNameRange(ep.Iface) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandVethRanges expands a single veth link with range expressions in its endpoints.
+func expandVethRanges(veth *LinkVEthRaw) ([]*LinkVEthRaw, error) {
+	// nodes and interfaces of every endpoint, expanded
+	nodes := make([][]clabutils.NameRangeItem, len(veth.Endpoints))
+	ifaces := make([][]clabutils.NameRangeItem, len(veth.Endpoints))
+
+	count := 0
+
+	checkLen := func(s string, items []clabutils.NameRangeItem) error {
+		if !clabutils.HasNameRange(s) {
+			return nil
+		}
+
+		if count != 0 && len(items) != count {
+			return fmt.Errorf("range %q produces %d items, expected %d", s, len(items), count)
+		}
+
+		count = len(items)
+
+		return nil
+	}
+
+	for i, ep := range veth.Endpoints {
+		var err error
+
+		nodes[i], err = clabutils.ExpandNameRange(ep.Node)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkLen(ep.Node, nodes[i]); err != nil {
+			return nil, err
+		}
+
+		ifaces[i], err = clabutils.ExpandNameRange(ep.Iface)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkLen(ep.Iface, ifaces[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// pick the n-th item of a range, or the only item for the names without a range
+	pick := func(items []clabutils.NameRangeItem, n int) string {
+		if len(items) == 1 {
+			return items[0].Name
+		}
+
+		return items[n].Name
+	}
+
+	result := make([]*LinkVEthRaw, 0, count)
+
+	for n := range count {
+		l := &LinkVEthRaw{
+			LinkCommonParams: veth.LinkCommonParams,
+			fromBrief:        veth.fromBrief,
+		}
+
+		l.Labels = maps.Clone(veth.Labels)
+		l.Vars = maps.Clone(veth.Vars)
+
+		for i, ep := range veth.Endpoints {
+			newEp := *ep
+			newEp.Node = pick(nodes[i], n)
+			newEp.Iface = pick(ifaces[i], n)
+
+			l.Endpoints = append(l.Endpoints, &newEp)
+		}
+
+		result = append(result, l)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package links
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+)
+
+func TestExpandLinkRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "no ranges",
+			input: `
+- endpoints: ["leaf1:e1-1", "spine1:e1-1"]
+`,
+			want: []string{"leaf1:e1-1 spine1:e1-1"},
+		},
+		{
+			name: "node and interface ranges in lockstep",
+			input: `
+- endpoints: ["leaf[1-3]:e1-49", "spine1:e1-[1-3]"]
+`,
+			want: []string{
+				"leaf1:e1-49 spine1:e1-1",
+				"leaf2:e1-49 spine1:e1-2",
+				"leaf3:e1-49 spine1:e1-3",
+			},
+		},
+		{
+			name: "extended veth format",
+			input: `
+- type: veth
+  endpoints:
+    - node: srv[1-2]
+      interface: eth1
+    - node: leaf1
+      interface: e1-[10-11]
+`,
+			want: []string{
+				"srv1:eth1 leaf1:e1-10",
+				"srv2:eth1 leaf1:e1-11",
+			},
+		},
+		{
+			name: "mismatched range lengths",
+			input: `
+- endpoints: ["leaf[1-3]:e1-49", "spine1:e1-[1-2]"]
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var linkDefs []*LinkDefinition
+			if err := yaml.Unmarshal([]byte(tt.input), &linkDefs); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ExpandLinkRanges(linkDefs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandLinkRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotEps []string
+			for _, ld := range got {
+				eps := ld.Link.(*LinkVEthRaw).ToLinkBriefRaw().Endpoints
+				gotEps = append(gotEps, eps[0]+" "+eps[1])
+			}
+
+			if d := cmp.Diff(tt.want, gotEps); d != "" {
+				t.Errorf("ExpandLinkRanges() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
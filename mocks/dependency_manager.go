@@ -67,6 +67,20 @@ func (mr *MockDependencyManagerMockRecorder) CheckAcyclicity() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAcyclicity", reflect.TypeOf((*MockDependencyManager)(nil).CheckAcyclicity))
 }
 
+// GetDependencies mocks base method.
+func (m *MockDependencyManager) GetDependencies() []*dependency_manager.Dependency {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencies")
+	ret0, _ := ret[0].([]*dependency_manager.Dependency)
+	return ret0
+}
+
+// GetDependencies indicates an expected call of GetDependencies.
+func (mr *MockDependencyManagerMockRecorder) GetDependencies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencies", reflect.TypeOf((*MockDependencyManager)(nil).GetDependencies))
+}
+
 // GetNode mocks base method.
 func (m *MockDependencyManager) GetNode(name string) (*dependency_manager.DependencyNode, error) {
 	m.ctrl.T.Helper()
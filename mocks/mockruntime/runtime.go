@@ -406,6 +406,21 @@ func (mr *MockContainerRuntimeMockRecorder) StopContainer(ctx, name, stopSignal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopContainer", reflect.TypeOf((*MockContainerRuntime)(nil).StopContainer), ctx, name, stopSignal)
 }
 
+// StreamAllLogs mocks base method.
+func (m *MockContainerRuntime) StreamAllLogs(ctx context.Context, containerName string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamAllLogs", ctx, containerName)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamAllLogs indicates an expected call of StreamAllLogs.
+func (mr *MockContainerRuntimeMockRecorder) StreamAllLogs(ctx, containerName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamAllLogs", reflect.TypeOf((*MockContainerRuntime)(nil).StreamAllLogs), ctx, containerName)
+}
+
 // StreamEvents mocks base method.
 func (m *MockContainerRuntime) StreamEvents(ctx context.Context, opts runtime.EventStreamOptions) (<-chan runtime.ContainerEvent, <-chan error, error) {
 	m.ctrl.T.Helper()
@@ -501,6 +516,44 @@ func (mr *MockContainerRuntimeMockRecorder) WriteToStdinNoWait(ctx, cID, data an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteToStdinNoWait", reflect.TypeOf((*MockContainerRuntime)(nil).WriteToStdinNoWait), ctx, cID, data)
 }
 
+// MockMgmtNetCreator is a mock of MgmtNetCreator interface.
+type MockMgmtNetCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockMgmtNetCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockMgmtNetCreatorMockRecorder is the mock recorder for MockMgmtNetCreator.
+type MockMgmtNetCreatorMockRecorder struct {
+	mock *MockMgmtNetCreator
+}
+
+// NewMockMgmtNetCreator creates a new mock instance.
+func NewMockMgmtNetCreator(ctrl *gomock.Controller) *MockMgmtNetCreator {
+	mock := &MockMgmtNetCreator{ctrl: ctrl}
+	mock.recorder = &MockMgmtNetCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMgmtNetCreator) EXPECT() *MockMgmtNetCreatorMockRecorder {
+	return m.recorder
+}
+
+// MgmtNetCreated mocks base method.
+func (m *MockMgmtNetCreator) MgmtNetCreated() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MgmtNetCreated")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// MgmtNetCreated indicates an expected call of MgmtNetCreated.
+func (mr *MockMgmtNetCreatorMockRecorder) MgmtNetCreated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MgmtNetCreated", reflect.TypeOf((*MockMgmtNetCreator)(nil).MgmtNetCreated))
+}
+
 // MockNode is a mock of Node interface.
 type MockNode struct {
 	ctrl     *gomock.Controller
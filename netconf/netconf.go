@@ -6,7 +6,9 @@
 package netconf
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/go-xmlfmt/xmlfmt"
@@ -54,6 +56,52 @@ func SaveRunningConfig(addr, username, password, _ string) error {
 	return nil
 }
 
+// Hello opens a netconf session to the given port, which completes the hello exchange,
+// and closes it. The deadline of the context bounds the session timeouts and Hello returns
+// once the context is done, even if the ssh handshake of the node stalls.
+func Hello(ctx context.Context, addr, username, password string, port int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	opts := []util.Option{
+		options.WithAuthNoStrictKey(),
+		options.WithAuthUsername(username),
+		options.WithAuthPassword(password),
+		options.WithTransportType(transport.StandardTransport),
+		options.WithPort(port),
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		opts = append(opts, options.WithTimeoutSocket(timeout), options.WithTimeoutOps(timeout))
+	}
+
+	d, err := netconf.NewDriver(addr, opts...)
+	if err != nil {
+		return fmt.Errorf("could not create netconf driver for %s: %+v", addr, err)
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		if err := d.Open(); err != nil {
+			errCh <- fmt.Errorf("failed to open netconf driver for %s: %+v", addr, err)
+
+			return
+		}
+
+		errCh <- d.Close()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
+
 // GetConfig retrieves the running configuration and returns it as a string. It automatically picks
 // the appropriate network driver for the provided Scrapli Platform.
 func GetConfig(addr, username, password, scrapliPlatform string) (string, error) {
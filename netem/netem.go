@@ -9,6 +9,7 @@ import (
 	"github.com/florianl/go-tc"
 	"github.com/florianl/go-tc/core"
 	"github.com/mdlayher/netlink"
+	clabtypes "github.com/srl-labs/containerlab/types"
 	"golang.org/x/sys/unix"
 )
 
@@ -162,3 +163,39 @@ func Impairments(tcnl *tc.Tc) ([]tc.Object, error) {
 
 	return qdiscs, nil
 }
+
+// ImpairmentData returns the impairments set by the netem qdisc,
+// the interface of the returned impairment data is left empty.
+func ImpairmentData(qdisc *tc.Object) clabtypes.ImpairmentData {
+	var data clabtypes.ImpairmentData
+
+	if qdisc.Netem == nil {
+		return data
+	}
+
+	if qdisc.Netem.Latency64 != nil && *qdisc.Netem.Latency64 != 0 {
+		data.Delay = (time.Duration(*qdisc.Netem.Latency64) * time.Nanosecond).String()
+	}
+
+	if qdisc.Netem.Jitter64 != nil && *qdisc.Netem.Jitter64 != 0 {
+		data.Jitter = (time.Duration(*qdisc.Netem.Jitter64) * time.Nanosecond).String()
+	}
+
+	// the rate is set in bytes per second, reported in kbit
+	if qdisc.Netem.Rate != nil && qdisc.Netem.Rate.Rate != 0 {
+		data.Rate = int(qdisc.Netem.Rate.Rate * 8 / 1000)
+	}
+
+	// the probabilities are rounded to 2 decimal places
+	if qdisc.Netem.Corrupt != nil && qdisc.Netem.Corrupt.Probability != 0 {
+		data.Corruption = math.Round((float64(qdisc.Netem.Corrupt.Probability)/
+			float64(math.MaxUint32)*100)*100) / 100
+	}
+
+	if qdisc.Netem.Qopt.Loss != 0 {
+		data.PacketLoss = math.Round(
+			(float64(qdisc.Netem.Qopt.Loss)/float64(math.MaxUint32)*100)*100) / 100
+	}
+
+	return data
+}
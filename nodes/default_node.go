@@ -165,6 +165,11 @@ func (d *DefaultNode) PullImage(ctx context.Context) error {
 	return nil
 }
 
+// GetHostRequirements returns the host requirements of the node.
+func (d *DefaultNode) GetHostRequirements() *clabtypes.HostRequirements {
+	return d.HostRequirements
+}
+
 func (d *DefaultNode) VerifyHostRequirements() error {
 	return d.HostRequirements.Verify(d.Cfg.Kind, d.Cfg.ShortName)
 }
@@ -81,6 +81,8 @@ type DockerRuntime struct {
 	Client  *dockerC.Client
 	mgmt    *clabtypes.MgmtNet
 	version string
+	// mgmtNetCreated is set when CreateNet created the management network
+	mgmtNetCreated bool
 }
 
 func (d *DockerRuntime) Init(opts ...clabruntime.RuntimeOption) error {
@@ -196,11 +198,13 @@ func (d *DockerRuntime) CreateNet(ctx context.Context) (err error) {
 	// linux bridge name that is used by docker network
 	bridgeName := d.mgmt.Bridge
 
+	d.mgmtNetCreated = false
+
 	log.Debugf("Checking if docker network %q exists", d.mgmt.Network)
 	netResource, err := d.Client.NetworkInspect(nctx, d.mgmt.Network, networkapi.InspectOptions{})
 	switch {
 	case cerrdefs.IsNotFound(err):
-		bridgeName, err = d.createMgmtBridge(nctx, bridgeName)
+		bridgeName, d.mgmtNetCreated, err = d.createMgmtBridge(nctx, bridgeName)
 		if err != nil {
 			return err
 		}
@@ -233,11 +237,18 @@ func (d *DockerRuntime) CreateNet(ctx context.Context) (err error) {
 	return d.postCreateNetActions()
 }
 
+// MgmtNetCreated returns true when the last CreateNet call created the management network.
+func (d *DockerRuntime) MgmtNetCreated() bool {
+	return d.mgmtNetCreated
+}
+
+// createMgmtBridge creates the management network and returns its bridge name and whether
+// the network was created, rather than created concurrently by another containerlab process.
 // skipcq: GO-R1005
 func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 	nctx context.Context,
 	bridgeName string,
-) (string, error) {
+) (string, bool, error) {
 	var err error
 	log.Debug("Network does not exist", "name", d.mgmt.Network)
 	log.Info("Creating docker network",
@@ -257,7 +268,7 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 			// only return error if the error is not about link not found
 			// we will create the bridge if it doesn't exist
 			if !errors.As(err, &netlink.LinkNotFoundError{}) {
-				return "", err
+				return "", false, err
 			}
 		}
 		log.Debugf("bridge %q has ipv4 addr of %q and ipv6 addr of %q", d.mgmt.Bridge, v4gw, v6gw)
@@ -281,7 +292,7 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 	if d.mgmt.IPv6Subnet == "auto" {
 		ipv6_subnet, err = clabutils.GenerateIPv6ULASubnet()
 		if err != nil {
-			return "", err
+			return "", false, err
 		}
 	} else {
 		ipv6_subnet = d.mgmt.IPv6Subnet
@@ -354,12 +365,14 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 				nctx, d.mgmt.Network, networkapi.InspectOptions{},
 			)
 			if ierr != nil {
-				return "", fmt.Errorf(
+				return "", false, fmt.Errorf(
 					"re-inspect %q after concurrent create: %w", d.mgmt.Network, ierr,
 				)
 			}
 
-			return bridgeNameFromInspect(&netResource, d.mgmt.Network)
+			bridgeName, err = bridgeNameFromInspect(&netResource, d.mgmt.Network)
+
+			return bridgeName, false, err
 		}
 
 		// Handle subnet overlap error
@@ -368,7 +381,7 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 			networksAndAddresses := map[string][]string{}
 			nets, listErr := d.Client.NetworkList(nctx, networkapi.ListOptions{})
 			if listErr != nil {
-				return "", fmt.Errorf(
+				return "", false, fmt.Errorf(
 					"failed to list Docker networks while handling subnet overlap error: %w (original error: %v)",
 					listErr,
 					err,
@@ -379,7 +392,7 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 					// store existing networks and their subnets
 					networksAndAddresses[n.Name] = append(networksAndAddresses[n.Name], cfg.Subnet)
 					if cfg.Subnet == d.mgmt.IPv4Subnet || cfg.Subnet == d.mgmt.IPv6Subnet {
-						return "", fmt.Errorf(
+						return "", false, fmt.Errorf(
 							"subnet %s already in use by Docker network %q. See https://containerlab.dev/manual/network/",
 							cfg.Subnet,
 							n.Name,
@@ -396,7 +409,7 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 				}
 				requestedSubnets += d.mgmt.IPv6Subnet
 			}
-			return "", fmt.Errorf(
+			return "", false, fmt.Errorf(
 				"requested subnet(s) %s overlap an existing Docker network. Existing networks: %v. Original error: %w. See https://containerlab.dev/manual/network/",
 				requestedSubnets,
 				networksAndAddresses,
@@ -404,18 +417,18 @@ func (d *DockerRuntime) createMgmtBridge( //nolint: funlen
 			)
 		}
 
-		return "", err
+		return "", false, err
 	}
 
 	if len(netCreateResponse.ID) < 12 {
-		return "", fmt.Errorf("could not get bridge ID")
+		return "", false, fmt.Errorf("could not get bridge ID")
 	}
 	// when bridge is not set by a user explicitly
 	// we use the 12 chars of docker net as its name
 	if bridgeName == "" {
 		bridgeName = "br-" + netCreateResponse.ID[:12]
 	}
-	return bridgeName, nil
+	return bridgeName, true, nil
 }
 
 // bridgeNameFromInspect resolves the underlying linux bridge name from a docker network inspect
@@ -1285,14 +1298,24 @@ func (d *DockerRuntime) produceGenericContainerList(
 
 		bridgeName := d.mgmt.Network
 
-		var err error
-		ctr.Pid, err = d.containerPid(ctx, i.ID)
+		inspect, err := d.Client.ContainerInspect(ctx, i.ID)
 		if errdefs.IsNotFound(err) {
 			// Concurrent destroy removed it between List and Inspect.
 			continue
 		}
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("container %q cannot be found: %w", i.ID, err)
+		}
+
+		ctr.Pid = inspect.State.Pid
+
+		ctr.Config = &clabruntime.ContainerConfig{}
+		if inspect.Config != nil {
+			ctr.Config.Image = inspect.Config.Image
+			ctr.Config.Env = inspect.Config.Env
+		}
+		if inspect.HostConfig != nil {
+			ctr.Config.Binds = inspect.HostConfig.Binds
 		}
 
 		// if bridgeName is empty, try to find a network created by clab that the container is
@@ -1659,15 +1682,6 @@ func (d *DockerRuntime) GetContainerStatus(
 	}
 }
 
-// containerPid returns the pid of a container by its ID using inspect.
-func (d *DockerRuntime) containerPid(ctx context.Context, cID string) (int, error) {
-	inspect, err := d.Client.ContainerInspect(ctx, cID)
-	if err != nil {
-		return 0, fmt.Errorf("container %q cannot be found: %w", cID, err)
-	}
-	return inspect.State.Pid, nil
-}
-
 // IsHealthy returns true is the container is reported as being healthy, false otherwise.
 func (d *DockerRuntime) IsHealthy(ctx context.Context, cID string) (bool, error) {
 	inspect, err := d.Client.ContainerInspect(ctx, cID)
@@ -1732,13 +1746,29 @@ func (*DockerRuntime) GetCooCBindMounts() clabtypes.Binds {
 func (d *DockerRuntime) StreamLogs(
 	ctx context.Context,
 	containerName string,
+) (io.ReadCloser, error) {
+	return d.streamLogs(ctx, containerName, time.Now().Format(time.RFC3339))
+}
+
+// StreamAllLogs returns a reader for the container's logs from the container start.
+func (d *DockerRuntime) StreamAllLogs(
+	ctx context.Context,
+	containerName string,
+) (io.ReadCloser, error) {
+	return d.streamLogs(ctx, containerName, "")
+}
+
+// streamLogs follows the container's logs since the given time, all logs when since is empty.
+func (d *DockerRuntime) streamLogs(
+	ctx context.Context,
+	containerName, since string,
 ) (io.ReadCloser, error) {
 	logOptions := container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
 		Follow:     true,
 		Timestamps: false,
-		Since:      time.Now().Format(time.RFC3339),
+		Since:      since,
 	}
 
 	logStream, err := d.Client.ContainerLogs(ctx, containerName, logOptions)
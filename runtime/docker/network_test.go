@@ -240,6 +240,23 @@ func TestCreateNetReusesNonBridgeNetwork(t *testing.T) {
 	if rt.mgmt.IPv4Gw != "10.40.40.1" {
 		t.Errorf("CreateNet() IPv4 gateway = %q, want %q", rt.mgmt.IPv4Gw, "10.40.40.1")
 	}
+	if rt.MgmtNetCreated() {
+		t.Error("MgmtNetCreated() = true for a reused network")
+	}
+}
+
+func TestCreateMgmtBridgeReportsCreatedNetwork(t *testing.T) {
+	rt, _, cleanup := newFakeDockerRuntime(t, "clab")
+	defer cleanup()
+
+	if _, created, err := rt.createMgmtBridge(context.Background(), ""); err != nil || !created {
+		t.Fatalf("createMgmtBridge() created = %v, error = %v, want created", created, err)
+	}
+
+	// the network created by another process in the meantime is reused
+	if _, created, err := rt.createMgmtBridge(context.Background(), ""); err != nil || created {
+		t.Fatalf("createMgmtBridge() created = %v, error = %v, want reused", created, err)
+	}
 }
 
 func TestWithMgmtNetDoesNotInferBridgeForNonBridgeNetwork(t *testing.T) {
@@ -353,7 +370,7 @@ func TestCreateMgmtBridge_ConcurrentSafe(t *testing.T) {
 		go func(i int) {
 			defer wg.Done()
 			<-start
-			names[i], errs[i] = rt.createMgmtBridge(context.Background(), "")
+			names[i], _, errs[i] = rt.createMgmtBridge(context.Background(), "")
 		}(i)
 	}
 
@@ -25,6 +25,9 @@ type GenericContainer struct {
 	Mounts          []ContainerMount
 	Runtime         ContainerRuntime
 	Ports           []*clabtypes.GenericPortBinding
+	// Config is the configuration the container was created with,
+	// nil when the runtime does not report it
+	Config *ContainerConfig
 }
 
 type ContainerMount struct {
@@ -32,6 +35,17 @@ type ContainerMount struct {
 	Destination string
 }
 
+// ContainerConfig is the part of the container configuration
+// containerlab compares with the topology.
+type ContainerConfig struct {
+	// Image is the image reference the container was created from
+	Image string
+	// Env is the list of the container environment variables in the KEY=VALUE format
+	Env []string
+	// Binds is the list of the container bind mounts in the source:destination[:options] format
+	Binds []string
+}
+
 // SetRuntime sets the runtime for this GenericContainer.
 func (ctr *GenericContainer) SetRuntime(r ContainerRuntime) {
 	ctr.Runtime = r
@@ -33,6 +33,8 @@ const (
 type PodmanRuntime struct {
 	config *runtime.RuntimeConfig
 	mgmt   *types.MgmtNet
+	// mgmtNetCreated is set when CreateNet created the management network
+	mgmtNetCreated bool
 }
 
 func init() {
@@ -93,6 +95,8 @@ func (r *PodmanRuntime) CreateNet(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	r.mgmtNetCreated = false
+
 	log.Debugf("Trying to create a management network with params %+v", r.mgmt)
 	// check the network existence first
 	b, err := network.Exists(ctx, r.mgmt.Network, &network.ExistsOptions{})
@@ -111,6 +115,8 @@ func (r *PodmanRuntime) CreateNet(ctx context.Context) error {
 			return err
 		}
 		log.Debugf("Create network response was: %+v", resp)
+
+		r.mgmtNetCreated = true
 	}
 	// set bridge name = network name if explicit name was not provided
 	if r.mgmt.Bridge == "" && r.mgmt.Network != "" {
@@ -123,6 +129,11 @@ func (r *PodmanRuntime) CreateNet(ctx context.Context) error {
 	return err
 }
 
+// MgmtNetCreated returns true when the last CreateNet call created the management network.
+func (r *PodmanRuntime) MgmtNetCreated() bool {
+	return r.mgmtNetCreated
+}
+
 // DeleteNet deletes a clab mgmt bridge.
 func (r *PodmanRuntime) DeleteNet(ctx context.Context) error {
 	// Skip if "keep mgmt" is set
@@ -590,6 +601,13 @@ func (*PodmanRuntime) StreamLogs(ctx context.Context, containerName string) (io.
 	return nil, fmt.Errorf("StreamLogs not implemented for Podman runtime")
 }
 
+func (*PodmanRuntime) StreamAllLogs(
+	ctx context.Context,
+	containerName string,
+) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("StreamAllLogs not implemented for Podman runtime")
+}
+
 func (*PodmanRuntime) StreamEvents(
 	context.Context,
 	runtime.EventStreamOptions,
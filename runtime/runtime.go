@@ -82,6 +82,9 @@ type ContainerRuntime interface {
 	// StreamLogs returns a reader for the container's logs
 	// The caller needs to close the returned ReadCloser.
 	StreamLogs(ctx context.Context, containerName string) (io.ReadCloser, error)
+	// StreamAllLogs returns a reader for the container's logs from the container start
+	// followed by the new log lines. The caller needs to close the returned ReadCloser.
+	StreamAllLogs(ctx context.Context, containerName string) (io.ReadCloser, error)
 	// StreamEvents streams runtime events that match provided options.
 	StreamEvents(
 		ctx context.Context,
@@ -95,6 +98,12 @@ type ContainerRuntime interface {
 	CopyToContainer(ctx context.Context, cID string, dstPath string, srcPath string) error
 }
 
+// MgmtNetCreator is implemented by the runtimes that report whether the last CreateNet call
+// created the management network rather than reusing an existing one.
+type MgmtNetCreator interface {
+	MgmtNetCreated() bool
+}
+
 // ContainerStatus summarizes container lifecycle as seen by the runtime.
 // Running and Paused imply a joinable network namespace for typical Linux containers;
 // use ContainerHasJoinableNetns for that check. Stopped means exited or dead
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// HookEvent is the lab lifecycle event a hook runs at.
+type HookEvent string
+
+const (
+	// HookPreDeploy runs before the lab is deployed.
+	HookPreDeploy HookEvent = "pre-deploy"
+	// HookPostDeploy runs after the lab is deployed.
+	HookPostDeploy HookEvent = "post-deploy"
+	// HookPreDestroy runs before the lab is destroyed.
+	HookPreDestroy HookEvent = "pre-destroy"
+	// HookPostDestroy runs after the lab is destroyed.
+	HookPostDestroy HookEvent = "post-destroy"
+	// HookPostApply runs after the changes of the topology are applied to the deployed lab.
+	HookPostApply HookEvent = "post-apply"
+)
+
+// HookOnFailure is the action taken when a hook failed.
+type HookOnFailure string
+
+const (
+	// HookOnFailureFail fails the lab operation the hook runs for.
+	// A failed pre- hook stops the operation before it starts.
+	HookOnFailureFail HookOnFailure = "fail"
+	// HookOnFailureContinue logs the failure and continues.
+	HookOnFailureContinue HookOnFailure = "continue"
+)
+
+// Hooks are the lab lifecycle hooks, the commands run on the host around
+// the deploy, destroy and apply of the lab.
+type Hooks struct {
+	PreDeploy   []*Hook `yaml:"pre-deploy,omitempty"`
+	PostDeploy  []*Hook `yaml:"post-deploy,omitempty"`
+	PreDestroy  []*Hook `yaml:"pre-destroy,omitempty"`
+	PostDestroy []*Hook `yaml:"post-destroy,omitempty"`
+	PostApply   []*Hook `yaml:"post-apply,omitempty"`
+}
+
+// Hook is a command run by the shell on the host.
+type Hook struct {
+	Command string `yaml:"command"`
+	// Timeout is the time in seconds the command has to complete within, no timeout by default
+	Timeout int `yaml:"timeout,omitempty"`
+	// OnFailure is the action taken when the command failed or timed out, fail by default
+	OnFailure HookOnFailure `yaml:"on-failure,omitempty"`
+}
+
+// Get returns the hooks of the event.
+func (h *Hooks) Get(event HookEvent) []*Hook {
+	if h == nil {
+		return nil
+	}
+
+	switch event {
+	case HookPreDeploy:
+		return h.PreDeploy
+	case HookPostDeploy:
+		return h.PostDeploy
+	case HookPreDestroy:
+		return h.PreDestroy
+	case HookPostDestroy:
+		return h.PostDestroy
+	case HookPostApply:
+		return h.PostApply
+	}
+
+	return nil
+}
+
+// Validate checks the hooks of all events.
+func (h *Hooks) Validate() error {
+	for _, event := range []HookEvent{
+		HookPreDeploy, HookPostDeploy, HookPreDestroy, HookPostDestroy, HookPostApply,
+	} {
+		for i, hook := range h.Get(event) {
+			if err := hook.Validate(); err != nil {
+				return fmt.Errorf("%s hook #%d: %w", event, i+1, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the command, timeout and failure policy of the hook.
+func (h *Hook) Validate() error {
+	if h == nil || h.Command == "" {
+		return fmt.Errorf("command is missing")
+	}
+
+	if h.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+
+	switch h.OnFailure {
+	case "", HookOnFailureFail, HookOnFailureContinue:
+	default:
+		return fmt.Errorf("on-failure %q is invalid, expected %s or %s",
+			h.OnFailure, HookOnFailureFail, HookOnFailureContinue)
+	}
+
+	return nil
+}
+
+// GetTimeoutDuration returns the time the command has to complete within, zero means no timeout.
+func (h *Hook) GetTimeoutDuration() time.Duration {
+	return time.Duration(h.Timeout) * time.Second
+}
+
+// GetOnFailure returns the action taken when the command failed.
+func (h *Hook) GetOnFailure() HookOnFailure {
+	if h.OnFailure == "" {
+		return HookOnFailureFail
+	}
+
+	return h.OnFailure
+}
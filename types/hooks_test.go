@@ -0,0 +1,40 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestHooksValidate(t *testing.T) {
+	tests := map[string]struct {
+		hooks   *Hooks
+		wantErr bool
+	}{
+		"valid": {
+			hooks: &Hooks{
+				PreDeploy:  []*Hook{{Command: "./register.sh", Timeout: 30}},
+				PostDeploy: []*Hook{{Command: "./notify.sh", OnFailure: HookOnFailureContinue}},
+			},
+		},
+		"missing command": {
+			hooks:   &Hooks{PostDestroy: []*Hook{{Timeout: 10}}},
+			wantErr: true,
+		},
+		"negative timeout": {
+			hooks:   &Hooks{PreDestroy: []*Hook{{Command: "true", Timeout: -1}}},
+			wantErr: true,
+		},
+		"invalid on-failure": {
+			hooks:   &Hooks{PostApply: []*Hook{{Command: "true", OnFailure: "ignore"}}},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.hooks.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -36,57 +36,85 @@ func NewHostRequirements() *HostRequirements {
 	}
 }
 
-// Verify runs verification checks against the host requirements set for a node.
-func (h *HostRequirements) Verify(kindName, nodeName string) error {
+// HostRequirementIssue is a host requirement of a node that the host does not satisfy.
+type HostRequirementIssue struct {
+	Message string
+	// FailAction is the fail action the node declares for the requirement.
+	FailAction FailBehaviour
+	// Fatal reports whether the issue prevents the node from being deployed.
+	Fatal bool
+}
+
+// Check returns the host requirements set for a node that the host does not satisfy.
+func (h *HostRequirements) Check(kindName, nodeName string) []*HostRequirementIssue {
+	var issues []*HostRequirementIssue
+
 	// check virtualization Support
 	if h.VirtRequired && !clabvirt.VerifyVirtSupport() {
-		return fmt.Errorf(
-			"CPU virtualization support is required for node %q (%s)",
-			nodeName,
-			kindName,
-		)
+		issues = append(issues, &HostRequirementIssue{
+			Message: fmt.Sprintf(
+				"CPU virtualization support is required for node %q (%s)",
+				nodeName,
+				kindName,
+			),
+			FailAction: FailBehaviourError,
+			Fatal:      true,
+		})
 	}
 
 	// check SSSE3 support on amd64 arch only as it is an x86_64 instruction
 	if runtime.GOARCH == "amd64" && h.SSSE3 && !clabvirt.VerifySSSE3Support() {
-		return fmt.Errorf("SSSE3 CPU feature is required for node %q (%s)", nodeName, kindName)
+		issues = append(issues, &HostRequirementIssue{
+			Message:    fmt.Sprintf("SSSE3 CPU feature is required for node %q (%s)", nodeName, kindName),
+			FailAction: FailBehaviourError,
+			Fatal:      true,
+		})
 	}
 
 	// check minimum vCPUs
 	if valid, num := h.verifyMinVCpu(); !valid {
-		message := fmt.Sprintf(
-			"node %q (%s) requires minimum %d vCPUs, but the host only has %d vCPUs",
-			nodeName,
-			kindName,
-			h.MinVCPU,
-			num,
-		)
-
-		switch h.MinAvailMemoryGbFailAction {
-		case FailBehaviourError:
-			return errors.New(message)
-		case FailBehaviourLog:
-			log.Error(message)
-		}
+		issues = append(issues, &HostRequirementIssue{
+			Message: fmt.Sprintf(
+				"node %q (%s) requires minimum %d vCPUs, but the host only has %d vCPUs",
+				nodeName,
+				kindName,
+				h.MinVCPU,
+				num,
+			),
+			FailAction: h.MinVCPUFailAction,
+			// the deployment fails on the vCPU shortfall by the memory fail action
+			Fatal: h.MinAvailMemoryGbFailAction == FailBehaviourError,
+		})
 	}
 
 	// check minimum FreeMemory
 	if valid, num := h.verifyMinAvailMemory(); !valid {
-		message := fmt.Sprintf(
-			"node %q (%s) has a minimum available memory requirement of "+
-				"%d GB whilst only %d GB memory is available",
-			nodeName,
-			kindName,
-			h.MinAvailMemoryGb,
-			num,
-		)
-
-		switch h.MinAvailMemoryGbFailAction {
-		case FailBehaviourError:
-			return errors.New(message)
-		case FailBehaviourLog:
-			log.Error(message)
+		issues = append(issues, &HostRequirementIssue{
+			Message: fmt.Sprintf(
+				"node %q (%s) has a minimum available memory requirement of "+
+					"%d GB whilst only %d GB memory is available",
+				nodeName,
+				kindName,
+				h.MinAvailMemoryGb,
+				num,
+			),
+			FailAction: h.MinAvailMemoryGbFailAction,
+			Fatal:      h.MinAvailMemoryGbFailAction == FailBehaviourError,
+		})
+	}
+
+	return issues
+}
+
+// Verify runs verification checks against the host requirements set for a node.
+// The first fatal issue is returned as an error, the non-fatal issues are logged.
+func (h *HostRequirements) Verify(kindName, nodeName string) error {
+	for _, issue := range h.Check(kindName, nodeName) {
+		if issue.Fatal {
+			return errors.New(issue.Message)
 		}
+
+		log.Error(issue.Message)
 	}
 
 	return nil
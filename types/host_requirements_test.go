@@ -0,0 +1,30 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHostRequirementsCheckMinVCPU(t *testing.T) {
+	h := NewHostRequirements()
+	h.MinVCPU = math.MaxInt32
+	h.MinVCPUFailAction = FailBehaviourError
+
+	issues := h.Check("nokia_srlinux", "srl1")
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1", len(issues))
+	}
+
+	if issues[0].FailAction != FailBehaviourError {
+		t.Errorf("FailAction = %v, want %v", issues[0].FailAction, FailBehaviourError)
+	}
+
+	// the vCPU shortfall does not fail the deployment unless the memory fail action is error
+	if issues[0].Fatal {
+		t.Error("vCPU shortfall is fatal with the log memory fail action")
+	}
+
+	if err := h.Verify("nokia_srlinux", "srl1"); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+}
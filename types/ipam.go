@@ -0,0 +1,438 @@
+package types
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+const (
+	ipamP2PIPv4Bits = 31
+	ipamP2PIPv6Bits = 127
+)
+
+// IPAM defines the address pools used to automatically allocate addresses
+// to the link endpoints and nodes of the topology.
+type IPAM struct {
+	// pool the IPv4 point-to-point link subnets (/31) are allocated from
+	P2PIPv4 string `json:"p2p-ipv4,omitempty" yaml:"p2p-ipv4,omitempty"`
+	// pool the IPv6 point-to-point link subnets (/127) are allocated from
+	P2PIPv6 string `json:"p2p-ipv6,omitempty" yaml:"p2p-ipv6,omitempty"`
+	// pool the IPv4 node loopback addresses are allocated from
+	LoopbackIPv4 string `json:"loopback-ipv4,omitempty" yaml:"loopback-ipv4,omitempty"`
+	// pool the IPv6 node loopback addresses are allocated from
+	LoopbackIPv6 string `json:"loopback-ipv6,omitempty" yaml:"loopback-ipv6,omitempty"`
+}
+
+// IPAMAllocations are the addresses allocated by IPAM.
+// They are saved in the lab state so that the allocations stay stable across deployments.
+type IPAMAllocations struct {
+	// link subnets keyed by the link id, see IPAMLinkID
+	Links map[string]*IPAMLinkAllocation `json:"links,omitempty" yaml:"links,omitempty"`
+	// node loopbacks keyed by the node name
+	Nodes map[string]*IPAMNodeAllocation `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+}
+
+// IPAMLinkAllocation holds the subnets allocated to a point-to-point link.
+// The first address of a subnet is assigned to the endpoint sorting first in the link id.
+type IPAMLinkAllocation struct {
+	IPv4 string `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty" yaml:"ipv6,omitempty"`
+}
+
+// IPAMNodeAllocation holds the loopback addresses allocated to a node.
+type IPAMNodeAllocation struct {
+	LoopbackIPv4 string `json:"loopback-ipv4,omitempty" yaml:"loopback-ipv4,omitempty"`
+	LoopbackIPv6 string `json:"loopback-ipv6,omitempty" yaml:"loopback-ipv6,omitempty"`
+}
+
+// NodeIPAMAddresses are the IPAM addresses of a node
+// as exposed to the startup-config templates, config vars and exports.
+type NodeIPAMAddresses struct {
+	LoopbackIPv4 string `json:"loopback-ipv4,omitempty"`
+	LoopbackIPv6 string `json:"loopback-ipv6,omitempty"`
+	// interface addresses in CIDR notation keyed by the interface name
+	Interfaces map[string]*IPAMInterfaceAddresses `json:"interfaces,omitempty"`
+}
+
+// IPAMInterfaceAddresses are the addresses of a node interface in CIDR notation.
+type IPAMInterfaceAddresses struct {
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+// ToVars returns the node addresses in a form suitable for the config vars.
+func (a *NodeIPAMAddresses) ToVars() map[string]any {
+	ifaces := map[string]any{}
+	for name, addrs := range a.Interfaces {
+		ifaces[name] = map[string]any{
+			"ipv4": addrs.IPv4,
+			"ipv6": addrs.IPv6,
+		}
+	}
+
+	return map[string]any{
+		"loopback-ipv4": a.LoopbackIPv4,
+		"loopback-ipv6": a.LoopbackIPv6,
+		"interfaces":    ifaces,
+	}
+}
+
+// IPAMLinkID returns the id of a point-to-point link between the two endpoints
+// which does not depend on the order the endpoints are listed in the topology.
+func IPAMLinkID(a, b *clablinks.EndpointRaw) string {
+	ids := []string{a.Node + ":" + a.Iface, b.Node + ":" + b.Iface}
+	slices.Sort(ids)
+
+	return strings.Join(ids, "--")
+}
+
+// AllocateAddresses assigns addresses from the IPAM pools to the endpoints of the
+// point-to-point links and to the nodes.
+// Allocations found in prev are reused when they still belong to the respective pool,
+// so that adding or removing links and nodes does not renumber the rest of the lab.
+// Endpoints with user-defined addresses are left intact.
+// The endpoint addresses are written into the topology links.
+func (t *Topology) AllocateAddresses(prev *IPAMAllocations) (*IPAMAllocations, error) {
+	if t.IPAM == nil {
+		return nil, nil
+	}
+
+	if prev == nil {
+		prev = &IPAMAllocations{}
+	}
+
+	alloc := &IPAMAllocations{
+		Links: map[string]*IPAMLinkAllocation{},
+		Nodes: map[string]*IPAMNodeAllocation{},
+	}
+
+	if err := t.allocateLinkAddresses(prev, alloc); err != nil {
+		return nil, err
+	}
+
+	if err := t.allocateNodeAddresses(prev, alloc); err != nil {
+		return nil, err
+	}
+
+	return alloc, nil
+}
+
+// NodeIPAMAddresses returns the IPAM addresses of a given node out of the allocations.
+func (t *Topology) NodeIPAMAddresses(alloc *IPAMAllocations, nodeName string) *NodeIPAMAddresses {
+	if alloc == nil {
+		return nil
+	}
+
+	addrs := &NodeIPAMAddresses{
+		Interfaces: map[string]*IPAMInterfaceAddresses{},
+	}
+
+	if n, ok := alloc.Nodes[nodeName]; ok {
+		addrs.LoopbackIPv4 = n.LoopbackIPv4
+		addrs.LoopbackIPv6 = n.LoopbackIPv6
+	}
+
+	for _, ld := range t.Links {
+		for _, ep := range ld.RawEndpoints() {
+			if ep == nil || ep.Node != nodeName || (ep.IPv4 == "" && ep.IPv6 == "") {
+				continue
+			}
+
+			addrs.Interfaces[ep.Iface] = &IPAMInterfaceAddresses{
+				IPv4: ep.IPv4,
+				IPv6: ep.IPv6,
+			}
+		}
+	}
+
+	return addrs
+}
+
+// p2pLink is a point-to-point link eligible for the address allocation.
+type p2pLink struct {
+	id string
+	// endpoints sorted in the same order as in the link id
+	eps [2]*clablinks.EndpointRaw
+}
+
+func (t *Topology) p2pLinks() []*p2pLink {
+	var links []*p2pLink
+
+	for _, ld := range t.Links {
+		var eps []*clablinks.EndpointRaw
+
+		switch l := ld.Link.(type) {
+		case *clablinks.LinkVEthRaw:
+			eps = l.Endpoints
+		case *clablinks.LinkVEthStitchedRaw:
+			eps = l.Endpoints
+		}
+
+		if len(eps) != 2 { //nolint: mnd
+			continue
+		}
+
+		l := &p2pLink{
+			id:  IPAMLinkID(eps[0], eps[1]),
+			eps: [2]*clablinks.EndpointRaw{eps[0], eps[1]},
+		}
+
+		if eps[0].Node+":"+eps[0].Iface > eps[1].Node+":"+eps[1].Iface {
+			l.eps = [2]*clablinks.EndpointRaw{eps[1], eps[0]}
+		}
+
+		links = append(links, l)
+	}
+
+	slices.SortFunc(links, func(a, b *p2pLink) int {
+		return clabutils.NaturalCompare(a.id, b.id)
+	})
+
+	return links
+}
+
+func (t *Topology) allocateLinkAddresses(prev, alloc *IPAMAllocations) error {
+	links := t.p2pLinks()
+
+	v4Pool, err := newPrefixPool(t.IPAM.P2PIPv4, ipamP2PIPv4Bits, false)
+	if err != nil {
+		return fmt.Errorf("ipam p2p-ipv4: %w", err)
+	}
+
+	v6Pool, err := newPrefixPool(t.IPAM.P2PIPv6, ipamP2PIPv6Bits, false)
+	if err != nil {
+		return fmt.Errorf("ipam p2p-ipv6: %w", err)
+	}
+
+	// subnets of the user-defined endpoint addresses are not allocated
+	for _, l := range links {
+		for _, ep := range l.eps {
+			v4Pool.reserveAddr(ep.IPv4)
+			v6Pool.reserveAddr(ep.IPv6)
+		}
+	}
+
+	// previous allocations are reserved first to keep them stable
+	for _, l := range links {
+		if p, ok := prev.Links[l.id]; ok {
+			v4Pool.reservePrefix(p.IPv4)
+			v6Pool.reservePrefix(p.IPv6)
+		}
+	}
+
+	for _, l := range links {
+		la := &IPAMLinkAllocation{}
+
+		var prevAlloc IPAMLinkAllocation
+		if p, ok := prev.Links[l.id]; ok {
+			prevAlloc = *p
+		}
+
+		if l.eps[0].IPv4 == "" && l.eps[1].IPv4 == "" && v4Pool != nil {
+			p, err := v4Pool.get(prevAlloc.IPv4)
+			if err != nil {
+				return fmt.Errorf("ipam p2p-ipv4: %w", err)
+			}
+
+			la.IPv4 = p.String()
+			l.eps[0].IPv4, l.eps[1].IPv4 = p2pAddresses(p)
+		}
+
+		if l.eps[0].IPv6 == "" && l.eps[1].IPv6 == "" && v6Pool != nil {
+			p, err := v6Pool.get(prevAlloc.IPv6)
+			if err != nil {
+				return fmt.Errorf("ipam p2p-ipv6: %w", err)
+			}
+
+			la.IPv6 = p.String()
+			l.eps[0].IPv6, l.eps[1].IPv6 = p2pAddresses(p)
+		}
+
+		if la.IPv4 != "" || la.IPv6 != "" {
+			alloc.Links[l.id] = la
+		}
+	}
+
+	return nil
+}
+
+func (t *Topology) allocateNodeAddresses(prev, alloc *IPAMAllocations) error {
+	v4Pool, err := newPrefixPool(t.IPAM.LoopbackIPv4, 32, true) //nolint: mnd
+	if err != nil {
+		return fmt.Errorf("ipam loopback-ipv4: %w", err)
+	}
+
+	v6Pool, err := newPrefixPool(t.IPAM.LoopbackIPv6, 128, true) //nolint: mnd
+	if err != nil {
+		return fmt.Errorf("ipam loopback-ipv6: %w", err)
+	}
+
+	if v4Pool == nil && v6Pool == nil {
+		return nil
+	}
+
+	nodeNames := make([]string, 0, len(t.Nodes))
+	for nodeName := range t.Nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	slices.SortFunc(nodeNames, clabutils.NaturalCompare)
+
+	for _, nodeName := range nodeNames {
+		if p, ok := prev.Nodes[nodeName]; ok {
+			v4Pool.reservePrefix(p.LoopbackIPv4)
+			v6Pool.reservePrefix(p.LoopbackIPv6)
+		}
+	}
+
+	for _, nodeName := range nodeNames {
+		var prevAlloc IPAMNodeAllocation
+		if p, ok := prev.Nodes[nodeName]; ok {
+			prevAlloc = *p
+		}
+
+		na := &IPAMNodeAllocation{}
+
+		if v4Pool != nil {
+			p, err := v4Pool.get(prevAlloc.LoopbackIPv4)
+			if err != nil {
+				return fmt.Errorf("ipam loopback-ipv4: %w", err)
+			}
+
+			na.LoopbackIPv4 = p.String()
+		}
+
+		if v6Pool != nil {
+			p, err := v6Pool.get(prevAlloc.LoopbackIPv6)
+			if err != nil {
+				return fmt.Errorf("ipam loopback-ipv6: %w", err)
+			}
+
+			na.LoopbackIPv6 = p.String()
+		}
+
+		alloc.Nodes[nodeName] = na
+	}
+
+	return nil
+}
+
+// p2pAddresses returns the two addresses of a point-to-point subnet in CIDR notation.
+func p2pAddresses(p netip.Prefix) (string, string) {
+	a := p.Addr()
+	b := a.Next()
+
+	return netip.PrefixFrom(a, p.Bits()).String(), netip.PrefixFrom(b, p.Bits()).String()
+}
+
+// prefixPool hands out the subnets of a given size from a pool prefix.
+type prefixPool struct {
+	pool netip.Prefix
+	bits int
+	used map[netip.Prefix]struct{}
+	// reserved are the subnets that are never allocated, not even to keep a previous allocation
+	reserved map[netip.Prefix]struct{}
+}
+
+// newPrefixPool creates a pool of subnets of a given size out of the pool prefix.
+// When skipFirst is set the first subnet of the pool (e.g. the network address
+// of a loopback pool) is never allocated.
+// A nil pool is returned when the pool prefix is empty.
+func newPrefixPool(pool string, bits int, skipFirst bool) (*prefixPool, error) {
+	if pool == "" {
+		return nil, nil
+	}
+
+	p, err := netip.ParsePrefix(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Bits() > bits {
+		return nil, fmt.Errorf("pool %s is smaller than a /%d subnet", p, bits)
+	}
+
+	if p.Addr().Is4() != (bits <= 32) { //nolint: mnd
+		return nil, fmt.Errorf("pool %s is not of the expected address family", p)
+	}
+
+	pp := &prefixPool{
+		pool:     p.Masked(),
+		bits:     bits,
+		used:     map[netip.Prefix]struct{}{},
+		reserved: map[netip.Prefix]struct{}{},
+	}
+
+	if skipFirst && p.Bits() < bits {
+		pp.reserved[netip.PrefixFrom(pp.pool.Addr(), bits)] = struct{}{}
+	}
+
+	return pp, nil
+}
+
+// reserveAddr marks the subnet containing the address in CIDR notation as reserved.
+func (pp *prefixPool) reserveAddr(cidr string) {
+	if pp == nil || cidr == "" {
+		return
+	}
+
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil || !pp.pool.Contains(p.Addr()) {
+		return
+	}
+
+	pp.reserved[netip.PrefixFrom(p.Addr(), pp.bits).Masked()] = struct{}{}
+}
+
+// reservePrefix marks a previously allocated subnet as used.
+func (pp *prefixPool) reservePrefix(prefix string) {
+	if pp == nil || prefix == "" {
+		return
+	}
+
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil || p.Bits() != pp.bits || !pp.pool.Contains(p.Addr()) {
+		return
+	}
+
+	pp.used[p] = struct{}{}
+}
+
+// get returns the previously allocated subnet if it belongs to the pool and is not reserved,
+// or the next free subnet of the pool.
+func (pp *prefixPool) get(prevPrefix string) (netip.Prefix, error) {
+	if p, err := netip.ParsePrefix(prevPrefix); err == nil &&
+		p.Bits() == pp.bits && pp.pool.Contains(p.Addr()) && !pp.isReserved(p) {
+		pp.used[p] = struct{}{}
+
+		return p, nil
+	}
+
+	for a := pp.pool.Addr(); a.IsValid() && pp.pool.Contains(a); {
+		p := netip.PrefixFrom(a, pp.bits)
+
+		if _, used := pp.used[p]; !used && !pp.isReserved(p) {
+			pp.used[p] = struct{}{}
+
+			return p, nil
+		}
+
+		// move to the first address of the next subnet
+		for range 1 << (a.BitLen() - pp.bits) {
+			a = a.Next()
+		}
+	}
+
+	return netip.Prefix{}, fmt.Errorf("pool %s is exhausted", pp.pool)
+}
+
+func (pp *prefixPool) isReserved(p netip.Prefix) bool {
+	_, reserved := pp.reserved[p]
+
+	return reserved
+}
@@ -0,0 +1,213 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clablinks "github.com/srl-labs/containerlab/links"
+	"gopkg.in/yaml.v2"
+)
+
+func loadIPAMTestTopology(t *testing.T, input string) *Topology {
+	t.Helper()
+
+	topo := NewTopology()
+	if err := yaml.UnmarshalStrict([]byte(input), topo); err != nil {
+		t.Fatal(err)
+	}
+
+	return topo
+}
+
+func linkEndpointAddrs(topo *Topology) []string {
+	var addrs []string
+
+	for _, ld := range topo.Links {
+		for _, ep := range ld.Link.(*clablinks.LinkVEthRaw).Endpoints {
+			addrs = append(addrs, ep.Node+":"+ep.Iface+" "+ep.IPv4+" "+ep.IPv6)
+		}
+	}
+
+	return addrs
+}
+
+func TestAllocateAddresses(t *testing.T) {
+	const topoDef = `
+ipam:
+  p2p-ipv4: 10.0.0.0/30
+  p2p-ipv6: 3fff:10::/125
+  loopback-ipv4: 10.255.0.0/24
+nodes:
+  srl1: {}
+  srl2: {}
+  srl3: {}
+links:
+  - endpoints: ["srl2:e1-1", "srl1:e1-1"]
+  - endpoints: ["srl2:e1-2", "srl3:e1-1"]
+  - endpoints: ["srl1:e1-2", "srl3:e1-2"]
+    ipv4: ["192.168.0.1/24", "192.168.0.2/24"]
+`
+
+	topo := loadIPAMTestTopology(t, topoDef)
+
+	alloc, err := topo.AllocateAddresses(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAddrs := []string{
+		"srl2:e1-1 10.0.0.1/31 3fff:10::1/127",
+		"srl1:e1-1 10.0.0.0/31 3fff:10::/127",
+		"srl2:e1-2 10.0.0.2/31 3fff:10::4/127",
+		"srl3:e1-1 10.0.0.3/31 3fff:10::5/127",
+		"srl1:e1-2 192.168.0.1/24 3fff:10::2/127",
+		"srl3:e1-2 192.168.0.2/24 3fff:10::3/127",
+	}
+
+	if d := cmp.Diff(wantAddrs, linkEndpointAddrs(topo)); d != "" {
+		t.Errorf("endpoint addresses mismatch (-want +got):\n%s", d)
+	}
+
+	wantAlloc := &IPAMAllocations{
+		Links: map[string]*IPAMLinkAllocation{
+			"srl1:e1-1--srl2:e1-1": {IPv4: "10.0.0.0/31", IPv6: "3fff:10::/127"},
+			"srl1:e1-2--srl3:e1-2": {IPv6: "3fff:10::2/127"},
+			"srl2:e1-2--srl3:e1-1": {IPv4: "10.0.0.2/31", IPv6: "3fff:10::4/127"},
+		},
+		Nodes: map[string]*IPAMNodeAllocation{
+			"srl1": {LoopbackIPv4: "10.255.0.1/32"},
+			"srl2": {LoopbackIPv4: "10.255.0.2/32"},
+			"srl3": {LoopbackIPv4: "10.255.0.3/32"},
+		},
+	}
+
+	if d := cmp.Diff(wantAlloc, alloc); d != "" {
+		t.Errorf("allocations mismatch (-want +got):\n%s", d)
+	}
+
+	// the previous allocations are kept when the first link is removed
+	// and the freed subnet is allocated to a new link
+	topo = loadIPAMTestTopology(t, `
+ipam:
+  p2p-ipv4: 10.0.0.0/30
+  loopback-ipv4: 10.255.0.0/24
+nodes:
+  srl0: {}
+  srl2: {}
+  srl3: {}
+links:
+  - endpoints: ["srl2:e1-2", "srl3:e1-1"]
+  - endpoints: ["srl0:e1-1", "srl3:e1-3"]
+`)
+
+	alloc, err = topo.AllocateAddresses(alloc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAddrs = []string{
+		"srl2:e1-2 10.0.0.2/31 ",
+		"srl3:e1-1 10.0.0.3/31 ",
+		"srl0:e1-1 10.0.0.0/31 ",
+		"srl3:e1-3 10.0.0.1/31 ",
+	}
+
+	if d := cmp.Diff(wantAddrs, linkEndpointAddrs(topo)); d != "" {
+		t.Errorf("endpoint addresses mismatch (-want +got):\n%s", d)
+	}
+
+	wantNodes := map[string]*IPAMNodeAllocation{
+		"srl0": {LoopbackIPv4: "10.255.0.1/32"},
+		"srl2": {LoopbackIPv4: "10.255.0.2/32"},
+		"srl3": {LoopbackIPv4: "10.255.0.3/32"},
+	}
+
+	if d := cmp.Diff(wantNodes, alloc.Nodes); d != "" {
+		t.Errorf("node allocations mismatch (-want +got):\n%s", d)
+	}
+
+	addrs := topo.NodeIPAMAddresses(alloc, "srl3")
+	wantNodeAddrs := &NodeIPAMAddresses{
+		LoopbackIPv4: "10.255.0.3/32",
+		Interfaces: map[string]*IPAMInterfaceAddresses{
+			"e1-1": {IPv4: "10.0.0.3/31"},
+			"e1-3": {IPv4: "10.0.0.1/31"},
+		},
+	}
+
+	if d := cmp.Diff(wantNodeAddrs, addrs); d != "" {
+		t.Errorf("node addresses mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestAllocateAddressesPoolExhausted(t *testing.T) {
+	topo := loadIPAMTestTopology(t, `
+ipam:
+  p2p-ipv4: 10.0.0.0/31
+nodes:
+  srl1: {}
+  srl2: {}
+links:
+  - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+  - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+`)
+
+	if _, err := topo.AllocateAddresses(nil); err == nil {
+		t.Fatal("expected pool exhaustion error")
+	}
+}
+
+func TestAllocateAddressesSkipsReservedPreviousSubnet(t *testing.T) {
+	topo := loadIPAMTestTopology(t, `
+ipam:
+  p2p-ipv4: 10.0.0.0/29
+nodes:
+  srl1: {}
+  srl2: {}
+links:
+  - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+  - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+`)
+
+	alloc, err := topo.AllocateAddresses(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the subnet previously allocated to the first link is now used by user-defined addresses
+	topo = loadIPAMTestTopology(t, `
+ipam:
+  p2p-ipv4: 10.0.0.0/29
+nodes:
+  srl1: {}
+  srl2: {}
+links:
+  - endpoints: ["srl1:e1-1", "srl2:e1-1"]
+  - endpoints: ["srl1:e1-2", "srl2:e1-2"]
+    ipv4: ["10.0.0.0/31", "10.0.0.1/31"]
+`)
+
+	alloc, err = topo.AllocateAddresses(alloc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAddrs := []string{
+		"srl1:e1-1 10.0.0.4/31 ",
+		"srl2:e1-1 10.0.0.5/31 ",
+		"srl1:e1-2 10.0.0.0/31 ",
+		"srl2:e1-2 10.0.0.1/31 ",
+	}
+
+	if d := cmp.Diff(wantAddrs, linkEndpointAddrs(topo)); d != "" {
+		t.Errorf("endpoint addresses mismatch (-want +got):\n%s", d)
+	}
+
+	wantLinks := map[string]*IPAMLinkAllocation{
+		"srl1:e1-1--srl2:e1-1": {IPv4: "10.0.0.4/31"},
+	}
+
+	if d := cmp.Diff(wantLinks, alloc.Links); d != "" {
+		t.Errorf("link allocations mismatch (-want +got):\n%s", d)
+	}
+}
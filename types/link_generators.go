@@ -0,0 +1,267 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabutils "github.com/srl-labs/containerlab/utils"
+)
+
+// LinkGeneratorType is the wiring pattern produced by a link generator.
+type LinkGeneratorType string
+
+const (
+	// LinkGeneratorFullMesh connects every node with every other node.
+	LinkGeneratorFullMesh LinkGeneratorType = "full-mesh"
+	// LinkGeneratorClos connects every leaf with every spine.
+	LinkGeneratorClos LinkGeneratorType = "clos"
+	// LinkGeneratorRing connects the nodes one after another and the last node with the first.
+	LinkGeneratorRing LinkGeneratorType = "ring"
+	// LinkGeneratorChain connects the nodes one after another.
+	LinkGeneratorChain LinkGeneratorType = "chain"
+
+	// groupSelectorPrefix selects all nodes of a group in the link generator node lists.
+	groupSelectorPrefix = "group:"
+)
+
+// LinkGenerator describes a wiring pattern that is expanded into individual links
+// when the topology is loaded.
+type LinkGenerator struct {
+	Type LinkGeneratorType `yaml:"type"`
+	// nodes wired by the full-mesh, ring and chain generators.
+	// node names, name ranges (leaf[1-4]) and group selectors (group:leaves) are accepted.
+	Nodes []string `yaml:"nodes,omitempty"`
+	// leaf and spine nodes wired by the clos generator, same format as nodes.
+	Leaves []string `yaml:"leaves,omitempty"`
+	Spines []string `yaml:"spines,omitempty"`
+	// interface name format with a single %d verb (e.g. `e1-%d`)
+	// overriding the interface format of the node kinds.
+	InterfaceFormat string `yaml:"interface-format,omitempty"`
+	// parameters applied to every generated link
+	MTU    int               `yaml:"mtu,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+	Vars   map[string]any    `yaml:"vars,omitempty"`
+}
+
+// ExpandLinkGenerators expands the topology link generators into individual links
+// appended to the topology links.
+// Interfaces are allocated per node in the ascending order of the interface index,
+// skipping the interfaces already used by other links. The interface name is built from
+// the generator's interface format or the format returned by ifFormat for the node kind.
+func (t *Topology) ExpandLinkGenerators(ifFormat func(kind string) string) error {
+	if len(t.LinkGenerators) == 0 {
+		return nil
+	}
+
+	alloc := &ifaceAllocator{
+		topo:     t,
+		ifFormat: ifFormat,
+		used:     map[string]map[string]struct{}{},
+		next:     map[string]int{},
+	}
+
+	for _, ld := range t.Links {
+		for _, ep := range ld.RawEndpoints() {
+			if ep != nil {
+				alloc.markUsed(ep.Node, ep.Iface)
+			}
+		}
+	}
+
+	for i, g := range t.LinkGenerators {
+		pairs, err := t.linkGeneratorPairs(g)
+		if err != nil {
+			return fmt.Errorf("link generator %d (%s): %w", i, g.Type, err)
+		}
+
+		for _, p := range pairs {
+			ld, err := alloc.link(g, p[0], p[1])
+			if err != nil {
+				return fmt.Errorf("link generator %d (%s): %w", i, g.Type, err)
+			}
+
+			t.Links = append(t.Links, ld)
+		}
+	}
+
+	return nil
+}
+
+// linkGeneratorPairs returns the pairs of nodes a link generator connects.
+func (t *Topology) linkGeneratorPairs(g *LinkGenerator) ([][2]string, error) {
+	var pairs [][2]string
+
+	switch g.Type {
+	case LinkGeneratorClos:
+		leaves, err := t.selectNodes(g.Leaves)
+		if err != nil {
+			return nil, err
+		}
+
+		spines, err := t.selectNodes(g.Spines)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(leaves) == 0 || len(spines) == 0 {
+			return nil, fmt.Errorf("leaves and spines must be set")
+		}
+
+		for _, leaf := range leaves {
+			for _, spine := range spines {
+				pairs = append(pairs, [2]string{leaf, spine})
+			}
+		}
+
+	case LinkGeneratorFullMesh, LinkGeneratorRing, LinkGeneratorChain:
+		nodes, err := t.selectNodes(g.Nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(nodes) < 2 { //nolint: mnd
+			return nil, fmt.Errorf("at least 2 nodes are required, got %d", len(nodes))
+		}
+
+		switch g.Type {
+		case LinkGeneratorFullMesh:
+			for i := range nodes {
+				for j := i + 1; j < len(nodes); j++ {
+					pairs = append(pairs, [2]string{nodes[i], nodes[j]})
+				}
+			}
+		default:
+			for i := range len(nodes) - 1 {
+				pairs = append(pairs, [2]string{nodes[i], nodes[i+1]})
+			}
+
+			// a ring of two nodes is the same as a chain
+			if g.Type == LinkGeneratorRing && len(nodes) > 2 { //nolint: mnd
+				pairs = append(pairs, [2]string{nodes[len(nodes)-1], nodes[0]})
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown link generator type %q, supported types: %s, %s, %s, %s",
+			g.Type, LinkGeneratorFullMesh, LinkGeneratorClos, LinkGeneratorRing, LinkGeneratorChain)
+	}
+
+	return pairs, nil
+}
+
+// selectNodes resolves the node selectors of a link generator into node names.
+// The order of selectors is preserved; the nodes selected by a group are sorted naturally.
+func (t *Topology) selectNodes(selectors []string) ([]string, error) {
+	var nodes []string
+
+	for _, s := range selectors {
+		var selected []string
+
+		if group, ok := strings.CutPrefix(s, groupSelectorPrefix); ok {
+			for nodeName := range t.Nodes {
+				if t.GetNodeGroup(nodeName) == group {
+					selected = append(selected, nodeName)
+				}
+			}
+
+			if len(selected) == 0 {
+				return nil, fmt.Errorf("no nodes found in group %q", group)
+			}
+
+			slices.SortFunc(selected, clabutils.NaturalCompare)
+		} else {
+			items, err := clabutils.ExpandNameRange(s)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				if _, ok := t.Nodes[item.Name]; !ok {
+					return nil, fmt.Errorf("node %q is not defined in the topology", item.Name)
+				}
+
+				selected = append(selected, item.Name)
+			}
+		}
+
+		for _, n := range selected {
+			if !slices.Contains(nodes, n) {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// ifaceAllocator allocates the interface names for the generated links.
+type ifaceAllocator struct {
+	topo     *Topology
+	ifFormat func(kind string) string
+	// interfaces in use per node
+	used map[string]map[string]struct{}
+	// next interface index to try per node
+	next map[string]int
+}
+
+func (a *ifaceAllocator) markUsed(node, iface string) {
+	if _, ok := a.used[node]; !ok {
+		a.used[node] = map[string]struct{}{}
+	}
+
+	a.used[node][iface] = struct{}{}
+}
+
+// allocate returns the next free interface of a node.
+func (a *ifaceAllocator) allocate(node, format string) (string, error) {
+	if format == "" {
+		format = a.ifFormat(a.topo.GetNodeKind(node))
+	}
+
+	if strings.Count(format, "%d") != 1 || strings.Count(format, "%") != 1 {
+		return "", fmt.Errorf(
+			"node %q of kind %q has no usable interface format %q, set interface-format with a single %%d",
+			node, a.topo.GetNodeKind(node), format)
+	}
+
+	idx := max(a.next[node], 1)
+
+	for {
+		iface := fmt.Sprintf(format, idx)
+		idx++
+
+		if _, inUse := a.used[node][iface]; inUse {
+			continue
+		}
+
+		a.next[node] = idx
+		a.markUsed(node, iface)
+
+		return iface, nil
+	}
+}
+
+// link creates a link definition between nodes a and b using the next free interfaces.
+func (a *ifaceAllocator) link(g *LinkGenerator, nodeA, nodeB string) (*clablinks.LinkDefinition, error) {
+	ifA, err := a.allocate(nodeA, g.InterfaceFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	ifB, err := a.allocate(nodeB, g.InterfaceFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return clablinks.NewLinkDefinitionFromBrief(&clablinks.LinkBriefRaw{
+		Endpoints: []string{nodeA + ":" + ifA, nodeB + ":" + ifB},
+		LinkCommonParams: clablinks.LinkCommonParams{
+			MTU:    g.MTU,
+			Labels: maps.Clone(g.Labels),
+			Vars:   maps.Clone(g.Vars),
+		},
+	})
+}
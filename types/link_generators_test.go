@@ -0,0 +1,161 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clablinks "github.com/srl-labs/containerlab/links"
+	"gopkg.in/yaml.v2"
+)
+
+func testIfFormat(kind string) string {
+	switch kind {
+	case "nokia_srlinux":
+		return "e1-%d"
+	case "linux":
+		return "eth%d"
+	}
+
+	return ""
+}
+
+func TestExpandLinkGenerators(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "clos with used interfaces",
+			input: `
+nodes:
+  leaf[1-2]:
+    kind: nokia_srlinux
+  spine[1-2]:
+    kind: nokia_srlinux
+links:
+  - endpoints: ["leaf1:e1-1", "spine1:e1-2"]
+link-generators:
+  - type: clos
+    leaves: ["leaf[1-2]"]
+    spines: [spine1, spine2]
+`,
+			want: []string{
+				"leaf1:e1-1 spine1:e1-2",
+				"leaf1:e1-2 spine1:e1-1",
+				"leaf1:e1-3 spine2:e1-1",
+				"leaf2:e1-1 spine1:e1-3",
+				"leaf2:e1-2 spine2:e1-2",
+			},
+		},
+		{
+			name: "full mesh of a group",
+			input: `
+groups:
+  core:
+    kind: linux
+nodes:
+  r10: {group: core}
+  r2: {group: core}
+  r1: {group: core}
+link-generators:
+  - type: full-mesh
+    nodes: ["group:core"]
+`,
+			want: []string{
+				"r1:eth1 r2:eth1",
+				"r1:eth2 r10:eth1",
+				"r2:eth2 r10:eth2",
+			},
+		},
+		{
+			name: "ring with interface format override",
+			input: `
+nodes:
+  n[1-3]:
+    kind: unknown
+link-generators:
+  - type: ring
+    nodes: ["n[1-3]"]
+    interface-format: ge-0/0/%d
+`,
+			want: []string{
+				"n1:ge-0/0/1 n2:ge-0/0/1",
+				"n2:ge-0/0/2 n3:ge-0/0/1",
+				"n3:ge-0/0/2 n1:ge-0/0/2",
+			},
+		},
+		{
+			name: "chain",
+			input: `
+nodes:
+  n[1-3]:
+    kind: linux
+link-generators:
+  - type: chain
+    nodes: ["n[1-3]"]
+`,
+			want: []string{
+				"n1:eth1 n2:eth1",
+				"n2:eth2 n3:eth1",
+			},
+		},
+		{
+			name: "kind without interface format",
+			input: `
+nodes:
+  n[1-2]:
+    kind: unknown
+link-generators:
+  - type: chain
+    nodes: ["n[1-2]"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown node",
+			input: `
+nodes:
+  n1:
+    kind: linux
+link-generators:
+  - type: chain
+    nodes: [n1, n2]
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topo := NewTopology()
+			if err := yaml.UnmarshalStrict([]byte(tt.input), topo); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := topo.ExpandReplicas(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := topo.ExpandLinkGenerators(testIfFormat)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandLinkGenerators() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, ld := range topo.Links {
+				eps := ld.Link.(*clablinks.LinkVEthRaw).ToLinkBriefRaw().Endpoints
+				got = append(got, eps[0]+" "+eps[1])
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("links mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -139,6 +139,9 @@ type NodeDefinition struct {
 	// how `containerlab apply` handles dataplane link changes for this node:
 	// live, restart or recreate. Overrides the kind's own declaration.
 	LinkApplyMode LinkApplyMode `yaml:"link-apply-mode,omitempty"`
+	// number of node replicas to create out of this definition, only valid for nodes.
+	// replicas are named after the node with the replica index appended (1..count).
+	Count uint `yaml:"count,omitempty"`
 }
 
 // Interface compliance.
@@ -0,0 +1,191 @@
+package types
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TopologyOverride is a single topology field override provided with `--set path=value`
+// or read from a `--set-file` file.
+type TopologyOverride struct {
+	// dot separated path of the overridden field, e.g. `nodes.leaf1.image`
+	Path  string `yaml:"path"`
+	Value any    `yaml:"value"`
+}
+
+// ParseTopologyOverride parses the `path=value` override expression.
+// The value is decoded as YAML, so lists (`[a, b]`) and maps (`{a: b}`) can be set,
+// scalars that would not survive the YAML round trip as typed (e.g. `24.10`) are kept as strings.
+func ParseTopologyOverride(s string) (*TopologyOverride, error) {
+	path, raw, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("invalid override %q, expected path=value", s)
+	}
+
+	path = strings.TrimSpace(path)
+
+	if _, err := SplitOverridePath(path); err != nil {
+		return nil, err
+	}
+
+	value, err := parseOverrideValue(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of the override %q: %w", path, err)
+	}
+
+	return &TopologyOverride{Path: path, Value: value}, nil
+}
+
+// ReadTopologyOverridesFile reads the overrides from a YAML file with a mapping
+// of the override paths to their values. The overrides are returned in the file order.
+func ReadTopologyOverridesFile(file string) ([]*TopologyOverride, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var m yaml.MapSlice
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse overrides file %s: %w", file, err)
+	}
+
+	overrides := make([]*TopologyOverride, 0, len(m))
+
+	for _, item := range m {
+		path, ok := item.Key.(string)
+		if !ok {
+			return nil, fmt.Errorf("overrides file %s: path %v is not a string", file, item.Key)
+		}
+
+		if _, err := SplitOverridePath(path); err != nil {
+			return nil, fmt.Errorf("overrides file %s: %w", file, err)
+		}
+
+		overrides = append(overrides, &TopologyOverride{Path: path, Value: item.Value})
+	}
+
+	return overrides, nil
+}
+
+// SplitOverridePath splits the override path into its elements.
+// The elements are separated by dots, a dot that is part of a name (e.g. of a node)
+// is escaped with a backslash: `nodes.srl\.1.image`.
+func SplitOverridePath(path string) ([]string, error) {
+	var (
+		elems []string
+		cur   strings.Builder
+	)
+
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			cur.WriteByte('.')
+			i++
+		case path[i] == '.':
+			elems = append(elems, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(path[i])
+		}
+	}
+
+	elems = append(elems, cur.String())
+
+	for _, e := range elems {
+		if e == "" {
+			return nil, fmt.Errorf("invalid override path %q: empty path element", path)
+		}
+	}
+
+	return elems, nil
+}
+
+// parseOverrideValue decodes the raw override value as YAML.
+func parseOverrideValue(raw string) (any, error) {
+	var v any
+	if err := yaml.Unmarshal([]byte(raw), &v); err != nil {
+		return nil, err
+	}
+
+	switch v.(type) {
+	case nil:
+		// an empty value or an explicit null clears the field
+		if strings.TrimSpace(raw) == "" || raw == "null" || raw == "~" {
+			return nil, nil
+		}
+
+		return raw, nil
+	case map[any]any, []any, string:
+		return v, nil
+	}
+
+	// typed scalars are only kept when they marshal back to the same text,
+	// otherwise image tags like 24.10 would become 24.1
+	if fmt.Sprint(v) != strings.TrimSpace(raw) {
+		return raw, nil
+	}
+
+	return v, nil
+}
+
+// PatchYAML sets the value at path in the YAML representation of dst and decodes
+// the result back into dst. dst must be a pointer to a value with YAML tags,
+// an empty path replaces the whole value.
+// Intermediate maps are created as needed, unknown fields are reported as errors.
+func PatchYAML(dst any, path []string, value any) error {
+	var doc any = value
+
+	if len(path) != 0 {
+		b, err := yaml.Marshal(dst)
+		if err != nil {
+			return err
+		}
+
+		m := map[any]any{}
+		if err := yaml.Unmarshal(b, &m); err != nil {
+			return err
+		}
+
+		if m == nil {
+			m = map[any]any{}
+		}
+
+		doc = m
+
+		for i, elem := range path[:len(path)-1] {
+			next, ok := m[elem].(map[any]any)
+			if !ok {
+				if cur, exists := m[elem]; exists && cur != nil {
+					return fmt.Errorf("%s is not a map", strings.Join(path[:i+1], "."))
+				}
+
+				next = map[any]any{}
+				m[elem] = next
+			}
+
+			m = next
+		}
+
+		last := path[len(path)-1]
+		if value == nil {
+			delete(m, last)
+		} else {
+			m[last] = value
+		}
+	}
+
+	b, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	// decode into a zero value, so removed fields and map entries do not survive the patch
+	v := reflect.ValueOf(dst).Elem()
+	v.Set(reflect.Zero(v.Type()))
+
+	return yaml.UnmarshalStrict(b, dst)
+}
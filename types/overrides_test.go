@@ -0,0 +1,181 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTopologyOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *TopologyOverride
+		wantErr bool
+	}{
+		{
+			name:  "string",
+			input: "nodes.leaf1.image=ghcr.io/nokia/srlinux:latest",
+			want:  &TopologyOverride{Path: "nodes.leaf1.image", Value: "ghcr.io/nokia/srlinux:latest"},
+		},
+		{
+			name:  "version-like value is kept as a string",
+			input: "kinds.nokia_srlinux.image=24.10",
+			want:  &TopologyOverride{Path: "kinds.nokia_srlinux.image", Value: "24.10"},
+		},
+		{
+			name:  "int",
+			input: "mgmt.mtu=9000",
+			want:  &TopologyOverride{Path: "mgmt.mtu", Value: 9000},
+		},
+		{
+			name:  "list",
+			input: "nodes.leaf1.ports=[80:80, 443:443]",
+			want:  &TopologyOverride{Path: "nodes.leaf1.ports", Value: []any{"80:80", "443:443"}},
+		},
+		{
+			name:  "empty value clears the field",
+			input: "nodes.leaf1.type=",
+			want:  &TopologyOverride{Path: "nodes.leaf1.type"},
+		},
+		{
+			name:    "missing value",
+			input:   "nodes.leaf1.image",
+			wantErr: true,
+		},
+		{
+			name:    "empty path element",
+			input:   "nodes..image=x",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTopologyOverride(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTopologyOverride() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("ParseTopologyOverride() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestSplitOverridePath(t *testing.T) {
+	got, err := SplitOverridePath(`nodes.srl\.1.env.FOO`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := cmp.Diff([]string{"nodes", "srl.1", "env", "FOO"}, got); d != "" {
+		t.Errorf("SplitOverridePath() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestReadTopologyOverridesFile(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "overrides.yml")
+
+	err := os.WriteFile(f, []byte("nodes.leaf1.image: srlinux:24.10\nmgmt.mtu: 1500\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadTopologyOverridesFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*TopologyOverride{
+		{Path: "nodes.leaf1.image", Value: "srlinux:24.10"},
+		{Path: "mgmt.mtu", Value: 1500},
+	}
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ReadTopologyOverridesFile() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestPatchYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []string
+		value   any
+		want    *NodeDefinition
+		wantErr bool
+	}{
+		{
+			name:  "set a field",
+			path:  []string{"image"},
+			value: "alpine:3",
+			want: &NodeDefinition{
+				Kind:  "linux",
+				Image: "alpine:3",
+				Env:   map[string]string{"A": "1"},
+			},
+		},
+		{
+			name:  "add a map entry",
+			path:  []string{"env", "B"},
+			value: "2",
+			want: &NodeDefinition{
+				Kind:  "linux",
+				Image: "alpine",
+				Env:   map[string]string{"A": "1", "B": "2"},
+			},
+		},
+		{
+			name: "remove a map entry",
+			path: []string{"env", "A"},
+			want: &NodeDefinition{
+				Kind:  "linux",
+				Image: "alpine",
+				Env:   map[string]string{},
+			},
+		},
+		{
+			name:  "replace the definition",
+			value: map[any]any{"kind": "nokia_srlinux"},
+			want:  &NodeDefinition{Kind: "nokia_srlinux"},
+		},
+		{
+			name:    "unknown field",
+			path:    []string{"imgae"},
+			value:   "alpine:3",
+			wantErr: true,
+		},
+		{
+			name:    "not a map",
+			path:    []string{"image", "tag"},
+			value:   "3",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &NodeDefinition{
+				Kind:  "linux",
+				Image: "alpine",
+				Env:   map[string]string{"A": "1"},
+			}
+
+			err := PatchYAML(n, tt.path, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PatchYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if d := cmp.Diff(tt.want, n); d != "" {
+				t.Errorf("PatchYAML() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
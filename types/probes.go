@@ -0,0 +1,204 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	defaultProbeInterval = 1
+	defaultProbeTimeout  = 300
+
+	defaultHTTPProbeStatus = 200
+	defaultSSHProbePort    = 22
+	defaultNETCONFPort     = 830
+)
+
+// ReadinessProbe is a condition checked on the node a stage waits for.
+// The depending stage is entered only after the node reached its wait-for stage
+// and the probe succeeded. Exactly one probe type must be set.
+type ReadinessProbe struct {
+	// TCP probe succeeds when the TCP port is open on the node's management address
+	TCP *TCPProbe `yaml:"tcp,omitempty"`
+	// HTTP probe succeeds when an HTTP(S) request to the node's management address
+	// returns the expected status code
+	HTTP *HTTPProbe `yaml:"http,omitempty"`
+	// SSH probe succeeds when the login with the node credentials succeeds
+	SSH *SSHProbe `yaml:"ssh,omitempty"`
+	// NETCONF probe succeeds when the NETCONF session with the node credentials is established
+	NETCONF *NETCONFProbe `yaml:"netconf,omitempty"`
+	// Log probe succeeds when a line of the node's log matches the pattern
+	Log *LogProbe `yaml:"log,omitempty"`
+	// Exec probe succeeds when the command run in the node exits with the expected code
+	Exec *ExecProbe `yaml:"exec,omitempty"`
+	// Interval is the time to wait between the probe attempts in seconds
+	Interval int `yaml:"interval,omitempty"`
+	// Timeout is the time in seconds the probe has to succeed within
+	Timeout int `yaml:"timeout,omitempty"`
+}
+
+// TCPProbe checks that a TCP port is open.
+type TCPProbe struct {
+	Port int `yaml:"port"`
+}
+
+// HTTPProbe checks the status code of an HTTP(S) request.
+type HTTPProbe struct {
+	// Scheme is either http (default) or https, the server certificate is not verified
+	Scheme string `yaml:"scheme,omitempty"`
+	// Port defaults to the scheme port
+	Port int    `yaml:"port,omitempty"`
+	Path string `yaml:"path,omitempty"`
+	// Status is the expected status code, 200 by default
+	Status int `yaml:"status,omitempty"`
+}
+
+// SSHProbe checks the SSH login.
+type SSHProbe struct {
+	Port int `yaml:"port,omitempty"`
+}
+
+// NETCONFProbe checks the NETCONF hello exchange.
+type NETCONFProbe struct {
+	Port int `yaml:"port,omitempty"`
+}
+
+// LogProbe matches the node's log lines against a regular expression.
+type LogProbe struct {
+	Pattern string `yaml:"pattern"`
+}
+
+// ExecProbe checks the exit code of a command run in the node.
+type ExecProbe struct {
+	Command string `yaml:"command"`
+	// ExitCode is the expected exit code, 0 by default
+	ExitCode int `yaml:"exit-code,omitempty"`
+}
+
+// Type returns the name of the probe type.
+func (p *ReadinessProbe) Type() string {
+	var types []string
+
+	if p.TCP != nil {
+		types = append(types, "tcp")
+	}
+
+	if p.HTTP != nil {
+		types = append(types, "http")
+	}
+
+	if p.SSH != nil {
+		types = append(types, "ssh")
+	}
+
+	if p.NETCONF != nil {
+		types = append(types, "netconf")
+	}
+
+	if p.Log != nil {
+		types = append(types, "log")
+	}
+
+	if p.Exec != nil {
+		types = append(types, "exec")
+	}
+
+	return strings.Join(types, ",")
+}
+
+// Validate checks that exactly one probe type is set and its parameters are valid.
+func (p *ReadinessProbe) Validate() error {
+	switch t := p.Type(); {
+	case t == "":
+		return fmt.Errorf("probe type is missing, one of tcp, http, ssh, netconf, log, exec must be set")
+	case strings.Contains(t, ","):
+		return fmt.Errorf("only one probe type can be set, got %s", t)
+	}
+
+	if p.Interval < 0 || p.Timeout < 0 {
+		return fmt.Errorf("probe interval and timeout must not be negative")
+	}
+
+	switch {
+	case p.TCP != nil && (p.TCP.Port <= 0 || p.TCP.Port > 65535):
+		return fmt.Errorf("tcp probe port %d is invalid", p.TCP.Port)
+	case p.HTTP != nil && p.HTTP.Scheme != "" && p.HTTP.Scheme != "http" && p.HTTP.Scheme != "https":
+		return fmt.Errorf("http probe scheme %q is invalid, expected http or https", p.HTTP.Scheme)
+	case p.Log != nil && p.Log.Pattern == "":
+		return fmt.Errorf("log probe pattern is missing")
+	case p.Log != nil:
+		if _, err := regexp.Compile(p.Log.Pattern); err != nil {
+			return fmt.Errorf("log probe pattern %q is invalid: %w", p.Log.Pattern, err)
+		}
+	case p.Exec != nil && p.Exec.Command == "":
+		return fmt.Errorf("exec probe command is missing")
+	}
+
+	return nil
+}
+
+// GetIntervalDuration returns the interval between the probe attempts.
+func (p *ReadinessProbe) GetIntervalDuration() time.Duration {
+	if p.Interval == 0 {
+		return defaultProbeInterval * time.Second
+	}
+
+	return time.Duration(p.Interval) * time.Second
+}
+
+// GetTimeoutDuration returns the time the probe has to succeed within.
+func (p *ReadinessProbe) GetTimeoutDuration() time.Duration {
+	if p.Timeout == 0 {
+		return defaultProbeTimeout * time.Second
+	}
+
+	return time.Duration(p.Timeout) * time.Second
+}
+
+// URL returns the URL of the HTTP probe request for the host.
+func (h *HTTPProbe) URL(host string) string {
+	scheme := h.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	hostPort := host
+	if strings.Contains(host, ":") {
+		hostPort = "[" + host + "]"
+	}
+
+	if h.Port != 0 {
+		hostPort = fmt.Sprintf("%s:%d", hostPort, h.Port)
+	}
+
+	return fmt.Sprintf("%s://%s/%s", scheme, hostPort, strings.TrimPrefix(h.Path, "/"))
+}
+
+// GetStatus returns the expected status code.
+func (h *HTTPProbe) GetStatus() int {
+	if h.Status == 0 {
+		return defaultHTTPProbeStatus
+	}
+
+	return h.Status
+}
+
+// GetPort returns the SSH port.
+func (s *SSHProbe) GetPort() int {
+	if s.Port == 0 {
+		return defaultSSHProbePort
+	}
+
+	return s.Port
+}
+
+// GetPort returns the NETCONF port.
+func (n *NETCONFProbe) GetPort() int {
+	if n.Port == 0 {
+		return defaultNETCONFPort
+	}
+
+	return n.Port
+}
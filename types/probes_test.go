@@ -0,0 +1,81 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestReadinessProbeValidate(t *testing.T) {
+	tests := map[string]struct {
+		probe   *ReadinessProbe
+		wantErr bool
+	}{
+		"tcp": {
+			probe: &ReadinessProbe{TCP: &TCPProbe{Port: 179}},
+		},
+		"no type": {
+			probe:   &ReadinessProbe{Timeout: 10},
+			wantErr: true,
+		},
+		"multiple types": {
+			probe:   &ReadinessProbe{SSH: &SSHProbe{}, NETCONF: &NETCONFProbe{}},
+			wantErr: true,
+		},
+		"invalid tcp port": {
+			probe:   &ReadinessProbe{TCP: &TCPProbe{}},
+			wantErr: true,
+		},
+		"invalid http scheme": {
+			probe:   &ReadinessProbe{HTTP: &HTTPProbe{Scheme: "ftp"}},
+			wantErr: true,
+		},
+		"invalid log pattern": {
+			probe:   &ReadinessProbe{Log: &LogProbe{Pattern: "bgp ("}},
+			wantErr: true,
+		},
+		"missing exec command": {
+			probe:   &ReadinessProbe{Exec: &ExecProbe{ExitCode: 1}},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.probe.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPProbeURL(t *testing.T) {
+	tests := map[string]struct {
+		probe *HTTPProbe
+		host  string
+		want  string
+	}{
+		"defaults": {
+			probe: &HTTPProbe{},
+			host:  "172.20.20.2",
+			want:  "http://172.20.20.2/",
+		},
+		"https with port and path": {
+			probe: &HTTPProbe{Scheme: "https", Port: 8443, Path: "/restconf/data"},
+			host:  "172.20.20.2",
+			want:  "https://172.20.20.2:8443/restconf/data",
+		},
+		"ipv6": {
+			probe: &HTTPProbe{Port: 8080, Path: "health"},
+			host:  "3fff:172:20:20::2",
+			want:  "http://[3fff:172:20:20::2]:8080/health",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.probe.URL(tt.host); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
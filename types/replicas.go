@@ -0,0 +1,190 @@
+package types
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	clablinks "github.com/srl-labs/containerlab/links"
+	clabutils "github.com/srl-labs/containerlab/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// NodeIndexVar is the magic variable replaced with the replica index
+// in the node definitions expanded from `count` or a node name range.
+const NodeIndexVar = "__clabNodeIndex__"
+
+// ExpandReplicas expands the nodes defined with `count` or with a name range (e.g. `leaf[1-4]`)
+// into individual node definitions and the links using endpoint ranges into individual links.
+func (t *Topology) ExpandReplicas() error {
+	if err := t.expandNodeReplicas(); err != nil {
+		return err
+	}
+
+	var err error
+
+	t.Links, err = clablinks.ExpandLinkRanges(t.Links)
+
+	return err
+}
+
+func (t *Topology) expandNodeReplicas() error {
+	for kindName, k := range t.Kinds {
+		if k != nil && k.Count != 0 {
+			return fmt.Errorf("count can not be set on kind %q, it is only valid for nodes", kindName)
+		}
+	}
+
+	for groupName, g := range t.Groups {
+		if g != nil && g.Count != 0 {
+			return fmt.Errorf("count can not be set on group %q, it is only valid for nodes", groupName)
+		}
+	}
+
+	if t.Defaults != nil && t.Defaults.Count != 0 {
+		return fmt.Errorf("count can not be set in defaults, it is only valid for nodes")
+	}
+
+	nodeNames := make([]string, 0, len(t.Nodes))
+	for nodeName := range t.Nodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	slices.Sort(nodeNames)
+
+	expanded := make(map[string]*NodeDefinition, len(t.Nodes))
+
+	for _, nodeName := range nodeNames {
+		nodeDef := t.Nodes[nodeName]
+
+		replicas, err := nodeReplicaNames(nodeName, nodeDef)
+		if err != nil {
+			return err
+		}
+
+		if replicas == nil {
+			if _, exists := expanded[nodeName]; exists {
+				return fmt.Errorf("node %q is defined more than once", nodeName)
+			}
+
+			expanded[nodeName] = nodeDef
+
+			continue
+		}
+
+		for offset, r := range replicas {
+			_, expandedBefore := expanded[r.Name]
+			_, definedExplicitly := t.Nodes[r.Name]
+
+			if expandedBefore || definedExplicitly {
+				return fmt.Errorf("node %q expanded from %q is defined more than once",
+					r.Name, nodeName)
+			}
+
+			replica, err := nodeDef.replica(r.Index, offset)
+			if err != nil {
+				return fmt.Errorf("failed to expand node %q: %w", nodeName, err)
+			}
+
+			expanded[r.Name] = replica
+		}
+	}
+
+	t.Nodes = expanded
+
+	return nil
+}
+
+// nodeReplicaNames returns the names and indexes of the replicas of a given node.
+// Nil is returned when the node is not replicated.
+func nodeReplicaNames(nodeName string, nodeDef *NodeDefinition) ([]clabutils.NameRangeItem, error) {
+	var count uint
+	if nodeDef != nil {
+		count = nodeDef.Count
+	}
+
+	hasRange := clabutils.HasNameRange(nodeName)
+
+	switch {
+	case hasRange && count != 0:
+		return nil, fmt.Errorf("node %q uses a name range and count at the same time", nodeName)
+	case hasRange:
+		return clabutils.ExpandNameRange(nodeName)
+	case count != 0:
+		items := make([]clabutils.NameRangeItem, 0, count)
+		for i := 1; i <= int(count); i++ {
+			items = append(items, clabutils.NameRangeItem{
+				Name:  nodeName + strconv.Itoa(i),
+				Index: i,
+			})
+		}
+
+		return items, nil
+	}
+
+	return nil, nil
+}
+
+// replica returns a copy of the node definition for the replica with the given index.
+// The NodeIndexVar magic variable is replaced with the index in every field of the definition.
+// Management addresses without the magic variable are used as the base address of the first
+// replica and are incremented by offset for the following ones.
+func (n *NodeDefinition) replica(index, offset int) (*NodeDefinition, error) {
+	if n == nil {
+		return &NodeDefinition{}, nil
+	}
+
+	tmpl := *n
+	tmpl.Count = 0
+
+	b, err := yaml.Marshal(&tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	b = []byte(strings.ReplaceAll(string(b), NodeIndexVar, strconv.Itoa(index)))
+
+	replica := &NodeDefinition{}
+	if err := yaml.Unmarshal(b, replica); err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(n.MgmtIPv4, NodeIndexVar) {
+		replica.MgmtIPv4, err = offsetAddr(n.MgmtIPv4, offset)
+		if err != nil {
+			return nil, fmt.Errorf("mgmt-ipv4: %w", err)
+		}
+	}
+
+	if !strings.Contains(n.MgmtIPv6, NodeIndexVar) {
+		replica.MgmtIPv6, err = offsetAddr(n.MgmtIPv6, offset)
+		if err != nil {
+			return nil, fmt.Errorf("mgmt-ipv6: %w", err)
+		}
+	}
+
+	return replica, nil
+}
+
+// offsetAddr returns the address that is offset addresses after addr.
+func offsetAddr(addr string, offset int) (string, error) {
+	if addr == "" {
+		return "", nil
+	}
+
+	a, err := netip.ParseAddr(addr)
+	if err != nil {
+		return "", err
+	}
+
+	for range offset {
+		a = a.Next()
+		if !a.IsValid() {
+			return "", fmt.Errorf("address %s can not be incremented by %d", addr, offset)
+		}
+	}
+
+	return a.String(), nil
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clablinks "github.com/srl-labs/containerlab/links"
+	"gopkg.in/yaml.v2"
+)
+
+func TestExpandReplicas(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantNodes map[string]*NodeDefinition
+		wantLinks []string
+		wantErr   bool
+	}{
+		{
+			name: "count",
+			input: `
+nodes:
+  leaf:
+    kind: nokia_srlinux
+    count: 3
+    mgmt-ipv4: 172.20.20.11
+    startup-config: cfg/leaf__clabNodeIndex__.cfg
+  spine1:
+    kind: nokia_srlinux
+links:
+  - endpoints: ["leaf[1-3]:e1-49", "spine1:e1-[1-3]"]
+`,
+			wantNodes: map[string]*NodeDefinition{
+				"leaf1": {
+					Kind:          "nokia_srlinux",
+					MgmtIPv4:      "172.20.20.11",
+					StartupConfig: "cfg/leaf1.cfg",
+				},
+				"leaf2": {
+					Kind:          "nokia_srlinux",
+					MgmtIPv4:      "172.20.20.12",
+					StartupConfig: "cfg/leaf2.cfg",
+				},
+				"leaf3": {
+					Kind:          "nokia_srlinux",
+					MgmtIPv4:      "172.20.20.13",
+					StartupConfig: "cfg/leaf3.cfg",
+				},
+				"spine1": {
+					Kind: "nokia_srlinux",
+				},
+			},
+			wantLinks: []string{
+				"leaf1:e1-49 spine1:e1-1",
+				"leaf2:e1-49 spine1:e1-2",
+				"leaf3:e1-49 spine1:e1-3",
+			},
+		},
+		{
+			name: "name range with index var in mgmt ip",
+			input: `
+nodes:
+  srv[5-6]:
+    kind: linux
+    mgmt-ipv6: 3fff:172:20:20::1__clabNodeIndex__
+    env:
+      ID: __clabNodeIndex__
+`,
+			wantNodes: map[string]*NodeDefinition{
+				"srv5": {
+					Kind:     "linux",
+					MgmtIPv6: "3fff:172:20:20::15",
+					Env:      map[string]string{"ID": "5"},
+				},
+				"srv6": {
+					Kind:     "linux",
+					MgmtIPv6: "3fff:172:20:20::16",
+					Env:      map[string]string{"ID": "6"},
+				},
+			},
+		},
+		{
+			name: "count with name range",
+			input: `
+nodes:
+  leaf[1-2]:
+    count: 2
+`,
+			wantErr: true,
+		},
+		{
+			name: "expanded name clashes with explicit node",
+			input: `
+nodes:
+  leaf:
+    count: 2
+  leaf2: {}
+`,
+			wantErr: true,
+		},
+		{
+			name: "count on kind",
+			input: `
+kinds:
+  linux:
+    count: 2
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topo := NewTopology()
+			if err := yaml.UnmarshalStrict([]byte(tt.input), topo); err != nil {
+				t.Fatal(err)
+			}
+
+			err := topo.ExpandReplicas()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandReplicas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if d := cmp.Diff(tt.wantNodes, topo.Nodes); d != "" {
+				t.Errorf("nodes mismatch (-want +got):\n%s", d)
+			}
+
+			var gotLinks []string
+			for _, ld := range topo.Links {
+				eps := ld.Link.(*clablinks.LinkVEthRaw).ToLinkBriefRaw().Endpoints
+				gotLinks = append(gotLinks, eps[0]+" "+eps[1])
+			}
+
+			if d := cmp.Diff(tt.wantLinks, gotLinks); d != "" {
+				t.Errorf("links mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
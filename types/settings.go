@@ -1,10 +1,38 @@
 package types
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // Settings is the structure for global containerlab settings.
 type Settings struct {
 	CertificateAuthority *CertificateAuthority `yaml:"certificate-authority"`
+	Lint                 *LintSettings         `yaml:"lint,omitempty"`
+}
+
+// LintSettings is the structure for the topology linter settings.
+type LintSettings struct {
+	// Suppress lists the linter findings that are not reported.
+	Suppress []*LintSuppression `yaml:"suppress,omitempty"`
+}
+
+// LintSuppression suppresses the findings of a linter rule,
+// either for all nodes or for the listed nodes only.
+type LintSuppression struct {
+	Rule  string   `yaml:"rule"`
+	Nodes []string `yaml:"nodes,omitempty"`
+	// Reason documents why the findings are suppressed.
+	Reason string `yaml:"reason,omitempty"`
+}
+
+// Matches reports whether the suppression applies to a finding of a rule for a node.
+func (s *LintSuppression) Matches(rule, node string) bool {
+	if s.Rule != rule {
+		return false
+	}
+
+	return len(s.Nodes) == 0 || slices.Contains(s.Nodes, node)
 }
 
 // CertificateAuthority is the structure for global containerlab certificate authority settings.
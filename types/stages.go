@@ -2,6 +2,9 @@ package types
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"time"
 
 	clabexec "github.com/srl-labs/containerlab/exec"
 )
@@ -26,6 +29,8 @@ var (
 	defaultCommandTarget         = CommandTargetContainer
 )
 
+const defaultExecRetryInterval = 1
+
 // Stages represents a configuration of a given node deployment stage.
 type Stages struct {
 	Create      *StageCreate      `yaml:"create"`
@@ -75,6 +80,25 @@ func (s *Stages) InitDefaults() {
 	s.Exit.Execs.InitDefaults()
 }
 
+// Validate checks the exec configuration of the stages.
+func (s *Stages) Validate() error {
+	for stage, execs := range map[WaitForStage]Execs{
+		WaitForCreate:      s.Create.Execs,
+		WaitForCreateLinks: s.CreateLinks.Execs,
+		WaitForConfigure:   s.Configure.Execs,
+		WaitForHealthy:     s.Healthy.Execs,
+		WaitForExit:        s.Exit.Execs,
+	} {
+		for _, e := range execs {
+			if err := e.Validate(); err != nil {
+				return fmt.Errorf("stage %s exec %q: %w", stage, e.Command, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // GetWaitFor returns lists of nodes that need to be waited for in a map
 // that is indexed by the state for which this dependency is to be evaluated.
 func (s *Stages) GetWaitFor() map[WaitForStage]WaitForList {
@@ -152,6 +176,25 @@ type Exec struct {
 	Command string     `yaml:"command,omitempty"`
 	Target  ExecTarget `yaml:"target,omitempty"`
 	Phase   ExecPhase  `yaml:"phase,omitempty"`
+	// Retries is the number of times the command is rerun when it failed
+	Retries int `yaml:"retries,omitempty"`
+	// RetryInterval is the time to wait between the attempts in seconds
+	RetryInterval int `yaml:"retry-interval,omitempty"`
+	// Timeout is the time in seconds a single attempt has to complete within
+	Timeout int `yaml:"timeout,omitempty"`
+	// OnFailure is the action taken when all attempts failed
+	OnFailure ExecOnFailure `yaml:"on-failure,omitempty"`
+	// Expect is the result the command must produce to be considered successful,
+	// by default the command must exit with code 0
+	Expect *ExecExpect `yaml:"expect,omitempty"`
+}
+
+// ExecExpect is the expected result of an exec command.
+type ExecExpect struct {
+	// ExitCode is the expected exit code, 0 by default
+	ExitCode int `yaml:"exit-code,omitempty"`
+	// Output is a regular expression the stdout or stderr of the command must match
+	Output string `yaml:"output,omitempty"`
 }
 
 // InitDefaults sets default values for Exec.
@@ -165,6 +208,75 @@ func (c *Exec) InitDefaults() {
 	if c.Target == "" {
 		c.Target = defaultCommandTarget
 	}
+
+	// failed commands do not stop the deployment by default
+	if c.OnFailure == "" {
+		c.OnFailure = ExecOnFailureContinue
+	}
+}
+
+// Validate checks the retry, timeout, failure policy and expect parameters of the Exec.
+func (c *Exec) Validate() error {
+	if c.Retries < 0 || c.RetryInterval < 0 || c.Timeout < 0 {
+		return fmt.Errorf("retries, retry-interval and timeout must not be negative")
+	}
+
+	switch c.OnFailure {
+	case "", ExecOnFailureContinue, ExecOnFailureFailNode, ExecOnFailureFailLab:
+	default:
+		return fmt.Errorf("on-failure %q is invalid, expected one of %s, %s, %s",
+			c.OnFailure, ExecOnFailureContinue, ExecOnFailureFailNode, ExecOnFailureFailLab)
+	}
+
+	if c.Expect != nil && c.Expect.Output != "" {
+		if _, err := regexp.Compile(c.Expect.Output); err != nil {
+			return fmt.Errorf("expect output %q is invalid: %w", c.Expect.Output, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRetryIntervalDuration returns the time to wait between the attempts.
+func (c *Exec) GetRetryIntervalDuration() time.Duration {
+	if c.RetryInterval == 0 {
+		return defaultExecRetryInterval * time.Second
+	}
+
+	return time.Duration(c.RetryInterval) * time.Second
+}
+
+// GetTimeoutDuration returns the time a single attempt has to complete within,
+// zero means no timeout.
+func (c *Exec) GetTimeoutDuration() time.Duration {
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// CheckResult checks the exit code and output of an attempt against the expected result.
+func (c *Exec) CheckResult(res *clabexec.ExecResult) error {
+	var expect ExecExpect
+	if c.Expect != nil {
+		expect = *c.Expect
+	}
+
+	if res.GetReturnCode() != expect.ExitCode {
+		return fmt.Errorf("exited with code %d, expected %d", res.GetReturnCode(), expect.ExitCode)
+	}
+
+	if expect.Output == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(expect.Output)
+	if err != nil {
+		return err
+	}
+
+	if !re.MatchString(res.GetStdOutString()) && !re.MatchString(res.GetStdErrString()) {
+		return fmt.Errorf("output did not match %q", expect.Output)
+	}
+
+	return nil
 }
 
 func (c *Exec) GetExecCmd() (*clabexec.ExecCmd, error) {
@@ -185,6 +297,19 @@ const (
 	CommandExecutionPhaseExit ExecPhase = "on-exit"
 )
 
+// ExecOnFailure is the action taken when an exec command failed all its attempts.
+type ExecOnFailure string
+
+const (
+	// ExecOnFailureContinue logs the failure and continues the deployment.
+	ExecOnFailureContinue ExecOnFailure = "continue"
+	// ExecOnFailureFailNode marks the node as failed, the nodes depending on it are not deployed
+	// further while the rest of the lab continues.
+	ExecOnFailureFailNode ExecOnFailure = "fail-node"
+	// ExecOnFailureFailLab aborts the whole deployment.
+	ExecOnFailureFailLab ExecOnFailure = "fail-lab"
+)
+
 type ExecTarget string
 
 const (
@@ -316,11 +441,13 @@ type WaitForStage string
 type WaitFor struct {
 	Node  string       `json:"node"`            // the node that is to be waited for
 	Stage WaitForStage `json:"stage,omitempty"` // the stage that the node must have completed
+	// the probe that must succeed on the node after it completed the stage
+	Probe *ReadinessProbe `json:"probe,omitempty"`
 }
 
-// Equals returns true if the Node and the State of the WaitFor structs are value equal.
+// Equals returns true if the Node, the State and the Probe of the WaitFor structs are value equal.
 func (w *WaitFor) Equals(other *WaitFor) bool {
-	if w.Node == other.Node && w.Stage == other.Stage {
+	if w.Node == other.Node && w.Stage == other.Stage && reflect.DeepEqual(w.Probe, other.Probe) {
 		return true
 	}
 
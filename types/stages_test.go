@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	clabexec "github.com/srl-labs/containerlab/exec"
 )
 
 func TestEquals(t *testing.T) {
@@ -120,3 +121,85 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestExecValidate(t *testing.T) {
+	tests := map[string]struct {
+		exec    *Exec
+		wantErr bool
+	}{
+		"defaults": {
+			exec: &Exec{Command: "ip link"},
+		},
+		"all options": {
+			exec: &Exec{
+				Command:       "ip link",
+				Retries:       3,
+				RetryInterval: 5,
+				Timeout:       10,
+				OnFailure:     ExecOnFailureFailLab,
+				Expect:        &ExecExpect{ExitCode: 1, Output: "eth[0-9]+"},
+			},
+		},
+		"negative retries": {
+			exec:    &Exec{Command: "ip link", Retries: -1},
+			wantErr: true,
+		},
+		"invalid on-failure": {
+			exec:    &Exec{Command: "ip link", OnFailure: "abort"},
+			wantErr: true,
+		},
+		"invalid output regex": {
+			exec:    &Exec{Command: "ip link", Expect: &ExecExpect{Output: "eth("}},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.exec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecCheckResult(t *testing.T) {
+	tests := map[string]struct {
+		expect  *ExecExpect
+		result  *clabexec.ExecResult
+		wantErr bool
+	}{
+		"zero exit code by default": {
+			result: &clabexec.ExecResult{ReturnCode: 0},
+		},
+		"non-zero exit code by default": {
+			result:  &clabexec.ExecResult{ReturnCode: 2},
+			wantErr: true,
+		},
+		"expected exit code": {
+			expect: &ExecExpect{ExitCode: 2},
+			result: &clabexec.ExecResult{ReturnCode: 2},
+		},
+		"output matches stderr": {
+			expect: &ExecExpect{Output: "^Established"},
+			result: &clabexec.ExecResult{Stderr: "Established"},
+		},
+		"output does not match": {
+			expect:  &ExecExpect{Output: "Established"},
+			result:  &clabexec.ExecResult{Stdout: "Active"},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &Exec{Command: "show bgp", Expect: tt.expect}
+
+			err := e.CheckResult(tt.result)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
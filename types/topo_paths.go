@@ -16,10 +16,15 @@ const (
 	nornirSimpleInventoryFileName = "nornir-simple-inventory.yml"
 	topologyExportDatFileName     = "topology-data.json"
 	stateFileName                 = ".state.clab.yaml"
+	stateHistoryDirName           = ".state-history"
+	labLockFileName               = ".lab.lock"
+	deployProfileFileName         = "deploy-profile.json"
+	stageExecResultsFileName      = "stage-exec-results.json"
 	authzKeysFileName             = "authorized_keys"
 	tlsDir                        = ".tls"
 	caDir                         = "ca"
 	graph                         = "graph"
+	netBoxDir                     = "netbox"
 	labDirPrefix                  = "clab-"
 	backupDirName                 = "bak"
 	CertFileSuffix                = ".pem"
@@ -193,6 +198,11 @@ func (t *TopoPaths) GraphFilename(ext string) string {
 	return filepath.Join(t.GraphDir(), t.TopologyFilenameWithoutExt()+ext)
 }
 
+// NetBoxDir returns the directory that takes the NetBox export files.
+func (t *TopoPaths) NetBoxDir() string {
+	return filepath.Join(t.labDir, netBoxDir)
+}
+
 // NodeDir returns the directory in the labDir for the provided node.
 func (t *TopoPaths) NodeDir(nodeName string) string {
 	return filepath.Join(t.labDir, nodeName)
@@ -209,6 +219,26 @@ func (t *TopoPaths) StateFile() string {
 	return filepath.Join(t.labDir, stateFileName)
 }
 
+// StateHistoryDir returns the directory with the recorded topology states of the lab.
+func (t *TopoPaths) StateHistoryDir() string {
+	return filepath.Join(t.labDir, stateHistoryDirName)
+}
+
+// LabLockFile returns the path of the lab lock file held by the commands changing the lab.
+func (t *TopoPaths) LabLockFile() string {
+	return filepath.Join(t.labDir, labLockFileName)
+}
+
+// DeployProfileFile returns the path for the deployment profile file.
+func (t *TopoPaths) DeployProfileFile() string {
+	return filepath.Join(t.labDir, deployProfileFileName)
+}
+
+// StageExecResultsFile returns the path for the stage exec results file.
+func (t *TopoPaths) StageExecResultsFile() string {
+	return filepath.Join(t.labDir, stageExecResultsFileName)
+}
+
 // AnsibleInventoryFileAbsPath returns the absolute path to the ansible-inventory file.
 func (t *TopoPaths) AnsibleInventoryFileAbsPath() string {
 	return filepath.Join(t.labDir, ansibleInventoryFileName)
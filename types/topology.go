@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 
@@ -201,6 +202,10 @@ type Topology struct {
 	Nodes    map[string]*NodeDefinition  `yaml:"nodes,omitempty"`
 	Groups   map[string]*NodeDefinition  `yaml:"groups,omitempty"`
 	Links    []*clablinks.LinkDefinition `yaml:"links,omitempty"`
+	// link generators expanded into Links when the topology is loaded
+	LinkGenerators []*LinkGenerator `yaml:"link-generators,omitempty"`
+	// address pools used to automatically address the links and nodes
+	IPAM *IPAM `yaml:"ipam,omitempty"`
 }
 
 // NewTopology creates a new Topology instance with initialized fields.
@@ -970,6 +975,10 @@ func (t *Topology) GetStages(nodeName string) (*Stages, error) {
 	// set nil values to their respective defaults
 	s.InitDefaults()
 
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("node %q: %w", nodeName, err)
+	}
+
 	return s, nil
 }
 
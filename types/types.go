@@ -244,6 +244,8 @@ type NodeConfig struct {
 	// they should be present by definition.
 	SkipUniquenessCheck bool
 	Components          []*Component
+	// Addresses allocated to the node and its interfaces by the topology IPAM
+	IPAM *NodeIPAMAddresses `json:"ipam,omitempty"`
 }
 
 // GetHostname returns the configured runtime hostname or the topology node name.
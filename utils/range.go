@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// nameRangeRe matches a numeric range expression in a name, e.g. `leaf[1-4]` or `e1-[1,3,5-8]`.
+var nameRangeRe = regexp.MustCompile(`\[([0-9][0-9,\-]*)\]`)
+
+// NameRangeItem is a single value produced by expanding a name range.
+type NameRangeItem struct {
+	// Name is the expanded name with the range expression replaced by Index.
+	Name string
+	// Index is the numeric value the range expression was replaced with.
+	Index int
+}
+
+// HasNameRange reports whether s contains a numeric range expression.
+func HasNameRange(s string) bool {
+	return nameRangeRe.MatchString(s)
+}
+
+// ExpandNameRange expands a name containing a single numeric range expression
+// into the list of names it describes.
+// The range expression is a comma separated list of numbers and inclusive intervals
+// enclosed in square brackets, e.g. `leaf[1-3]` expands to leaf1, leaf2, leaf3 and
+// `leaf[1,5-6]` to leaf1, leaf5, leaf6. When the interval start has leading zeroes
+// the produced numbers are zero-padded to the same width, e.g. `leaf[01-03]`.
+// A name without a range expression is returned as a single item with index 0.
+func ExpandNameRange(s string) ([]NameRangeItem, error) {
+	locs := nameRangeRe.FindAllStringSubmatchIndex(s, -1)
+	switch len(locs) {
+	case 0:
+		return []NameRangeItem{{Name: s}}, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("%q contains more than one range expression", s)
+	}
+
+	loc := locs[0]
+	prefix, expr, suffix := s[:loc[0]], s[loc[2]:loc[3]], s[loc[1]:]
+
+	var items []NameRangeItem
+
+	for part := range strings.SplitSeq(expr, ",") {
+		startStr, endStr, isInterval := strings.Cut(part, "-")
+		if !isInterval {
+			endStr = startStr
+		}
+
+		start, err := strconv.Atoi(startStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q in %q: %w", part, s, err)
+		}
+
+		end, err := strconv.Atoi(endStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q in %q: %w", part, s, err)
+		}
+
+		if end < start {
+			return nil, fmt.Errorf("invalid range %q in %q: end is lower than start", part, s)
+		}
+
+		format := "%s%d%s"
+		if len(startStr) > 1 && startStr[0] == '0' {
+			format = fmt.Sprintf("%%s%%0%dd%%s", len(startStr))
+		}
+
+		for i := start; i <= end; i++ {
+			items = append(items, NameRangeItem{
+				Name:  fmt.Sprintf(format, prefix, i, suffix),
+				Index: i,
+			})
+		}
+	}
+
+	return items, nil
+}
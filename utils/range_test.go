@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandNameRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []NameRangeItem
+		wantErr bool
+	}{
+		{
+			name:  "no_range",
+			input: "leaf1",
+			want:  []NameRangeItem{{Name: "leaf1"}},
+		},
+		{
+			name:  "interval",
+			input: "leaf[1-3]",
+			want: []NameRangeItem{
+				{Name: "leaf1", Index: 1},
+				{Name: "leaf2", Index: 2},
+				{Name: "leaf3", Index: 3},
+			},
+		},
+		{
+			name:  "list_and_interval_with_suffix",
+			input: "e1-[1,5-6]-a",
+			want: []NameRangeItem{
+				{Name: "e1-1-a", Index: 1},
+				{Name: "e1-5-a", Index: 5},
+				{Name: "e1-6-a", Index: 6},
+			},
+		},
+		{
+			name:  "zero_padded",
+			input: "leaf[08-10]",
+			want: []NameRangeItem{
+				{Name: "leaf08", Index: 8},
+				{Name: "leaf09", Index: 9},
+				{Name: "leaf10", Index: 10},
+			},
+		},
+		{
+			name:    "reversed_interval",
+			input:   "leaf[3-1]",
+			wantErr: true,
+		},
+		{
+			name:    "multiple_ranges",
+			input:   "leaf[1-2]-[1-2]",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandNameRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandNameRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("ExpandNameRange() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"cmp"
 	"strings"
 	"unicode"
 )
@@ -24,3 +25,46 @@ func ShortID(id string) string {
 
 	return id
 }
+
+// NaturalCompare compares two strings treating the runs of digits as numbers,
+// so that `leaf2` sorts before `leaf10`. It returns -1, 0 or +1.
+func NaturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		aDigits := leadingDigits(a)
+		bDigits := leadingDigits(b)
+
+		if aDigits != "" && bDigits != "" {
+			aNum := strings.TrimLeft(aDigits, "0")
+			bNum := strings.TrimLeft(bDigits, "0")
+
+			if c := cmp.Compare(len(aNum), len(bNum)); c != 0 {
+				return c
+			}
+
+			if c := strings.Compare(aNum, bNum); c != 0 {
+				return c
+			}
+
+			a, b = a[len(aDigits):], b[len(bDigits):]
+
+			continue
+		}
+
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return s[:i]
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	got := []string{"leaf10", "leaf2", "spine1", "leaf1", "leaf02a", "leaf"}
+	want := []string{"leaf", "leaf1", "leaf2", "leaf02a", "leaf10", "spine1"}
+
+	slices.SortStableFunc(got, NaturalCompare)
+
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("NaturalCompare() sort mismatch (-want +got):\n%s", d)
+	}
+}